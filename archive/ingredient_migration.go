@@ -44,5 +44,5 @@ fmt.Printf("Hello, world.\n")
     model := models.NewIngredientModel(config.PostgreSQL, *config.Logger)
     controller := controllers.NewIngredientController(model)
 
-    controller.IngredientModel.CreateIngredients(ingredients)
+    controller.IngredientModel.CreateIngredients(context.Background(), ingredients)
 }
\ No newline at end of file
@@ -53,6 +53,6 @@ func main3() {
         recipe := models.NewRecipe(nil, raw_item.RecipeTitle, raw_item.RecipeLink, "Just One Cookbook", ingredients)
         recipes = append(recipes, *recipe)
     }
-    model.CreateRecipes(recipes)
+    model.CreateRecipes(context.Background(), recipes)
 
 }
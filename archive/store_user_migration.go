@@ -47,7 +47,7 @@ func main2() {
     }
     
     for _, store := range stores {
-        model.CreateStore(store)
+        model.CreateStore(context.Background(), store)
     }
     
 }
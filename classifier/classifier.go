@@ -0,0 +1,191 @@
+// Package classifier turns raw ad item names into simple ingredient names and
+// classifies ingredients by food type and season. LLM-backed implementations validate
+// every reply against a strict JSON schema and ask the model to repair invalid replies.
+package classifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"backend/main/models"
+)
+
+// DefaultMaxAttempts bounds how many times an LLM is asked for a valid reply
+const DefaultMaxAttempts = 3
+
+// ErrInvalidResponse is returned when an LLM reply still fails its schema after every attempt
+var ErrInvalidResponse = errors.New("classifier: invalid response")
+
+// IngredientClassifier simplifies ad item names and classifies ingredients
+type IngredientClassifier interface {
+	// Simplify maps each raw ad item name onto a simple ingredient name
+	Simplify(ctx context.Context, names []string) (Simplification, error)
+	// Classify returns the food type of an ingredient and, for fruit and vegetables,
+	// the months it is in season
+	Classify(ctx context.Context, name string) (Classification, error)
+}
+
+// Exchange is the raw prompt and reply behind an answer, kept for human review
+type Exchange struct {
+	Prompt   string
+	Response string
+}
+
+// Suggestion is the simple ingredient name proposed for one ad item name
+type Suggestion struct {
+	Name string
+	// Confidence is the model's self-reported confidence from 0 to 1
+	Confidence float64
+}
+
+// Simplification is the answer to a Simplify call, keyed by the raw ad item name
+type Simplification struct {
+	Names map[string]Suggestion
+	Exchange
+}
+
+// Classification is the answer to a Classify call. Season is nil unless Type is Fruit or Vegetable.
+type Classification struct {
+	Type       models.FoodType
+	Season     *[]int
+	Confidence float64
+	Exchange
+}
+
+// Generator sends a prompt and its input to an LLM and returns the text reply
+type Generator interface {
+	Generate(ctx context.Context, prompt string, input string) (string, error)
+}
+
+const simplifyPrompt = "Given the following JSON array of names of grocery ingredients, return a simple ingredient name for each. The ingredient name has to be a food. For example: Fresh Green Bell Pepper -> bell peppers. Fresh Antibiotic Free Family Pack Thin Sliced Chicken Breast -> chicken breast. The output should be only a JSON object with the key being the original input ingredient name and the value being an object with the output simple ingredient name and your confidence from 0 to 1. For example: {\"Fresh Green Bell Pepper\": {\"ingredient\": \"bell peppers\", \"confidence\": 0.95}, \"Fresh Antibiotic Free Family Pack Thin Sliced Chicken Breast\": {\"ingredient\": \"chicken breast\", \"confidence\": 0.9}}. Every input name must be a key. Do not return anything else except a json. If the item name is two or more items (ex: Green Peppers and Cucumbers, Salmon and Ocean Perch, etc.) generalize the food (vegetables, fish, etc.) If the item is a fruit or vegetable, make sure the returned ingredient is plural. For example fresh avocados -> avocados"
+
+const classifyPrompt = "Given the following name of an ingredient, return only a json object of the form {\"type\": <type>, \"season\": <season>, \"confidence\": <confidence>}. Choose the type out of: %s. If the type is Fruit or Vegetable, provide the season as an array of month numbers from 1 to 12 in the northern hemisphere, otherwise use null. The confidence is from 0 to 1. For example brussel sprouts -> {\"type\": \"Vegetable\", \"season\": [9, 10, 11], \"confidence\": 0.9}."
+
+// LLMClassifier is an IngredientClassifier backed by a Generator
+type LLMClassifier struct {
+	Generator   Generator
+	MaxAttempts int
+}
+
+// NewLLMClassifier returns an LLMClassifier allowing DefaultMaxAttempts per request
+func NewLLMClassifier(generator Generator) *LLMClassifier {
+	return &LLMClassifier{
+		Generator:   generator,
+		MaxAttempts: DefaultMaxAttempts,
+	}
+}
+
+var confidenceSchema = &Schema{Type: "number", Minimum: float64Ptr(0), Maximum: float64Ptr(1)}
+
+type suggestion struct {
+	Ingredient string  `json:"ingredient"`
+	Confidence float64 `json:"confidence"`
+}
+
+// Simplify asks the LLM for the simple ingredient name of each ad item name
+func (c *LLMClassifier) Simplify(ctx context.Context, names []string) (Simplification, error) {
+	result := Simplification{Names: make(map[string]Suggestion, len(names))}
+	if len(names) == 0 {
+		return result, nil
+	}
+	suggestionSchema := &Schema{
+		Type:     "object",
+		Required: []string{"ingredient", "confidence"},
+		Properties: map[string]*Schema{
+			"ingredient": {Type: "string", MinLength: 1},
+			"confidence": confidenceSchema,
+		},
+	}
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema, len(names))}
+	for _, name := range names {
+		schema.Properties[name] = suggestionSchema
+		schema.Required = append(schema.Required, name)
+	}
+	input, err := json.Marshal(names)
+	if err != nil {
+		return result, err
+	}
+	var suggestions map[string]suggestion
+	result.Exchange, err = c.generate(ctx, simplifyPrompt, string(input), schema, &suggestions)
+	if err != nil {
+		return result, err
+	}
+	for name, s := range suggestions {
+		result.Names[name] = Suggestion{
+			Name:       strings.ToLower(strings.TrimSpace(s.Ingredient)),
+			Confidence: s.Confidence,
+		}
+	}
+	return result, nil
+}
+
+type classification struct {
+	Type       string  `json:"type"`
+	Season     *[]int  `json:"season"`
+	Confidence float64 `json:"confidence"`
+}
+
+// Classify asks the LLM for the food type and season of an ingredient
+func (c *LLMClassifier) Classify(ctx context.Context, name string) (Classification, error) {
+	foodTypes := models.FoodTypeNames()
+	schema := &Schema{
+		Type:     "object",
+		Required: []string{"type", "confidence"},
+		Properties: map[string]*Schema{
+			"type":       {Type: "string", Enum: foodTypes},
+			"confidence": confidenceSchema,
+			"season": {
+				Type:        "array",
+				Nullable:    true,
+				MaxItems:    12,
+				UniqueItems: true,
+				Items:       &Schema{Type: "integer", Minimum: float64Ptr(1), Maximum: float64Ptr(12)},
+			},
+		},
+	}
+	var reply classification
+	prompt := fmt.Sprintf(classifyPrompt, strings.Join(foodTypes, ", "))
+	exchange, err := c.generate(ctx, prompt, name, schema, &reply)
+	if err != nil {
+		return Classification{Type: models.Other, Exchange: exchange}, err
+	}
+	result := Classification{
+		Type:       models.ParseFoodType(reply.Type),
+		Confidence: reply.Confidence,
+		Exchange:   exchange,
+	}
+	if result.Type == models.Fruit || result.Type == models.Vegetable {
+		result.Season = reply.Season
+	}
+	return result, nil
+}
+
+// generate asks for a reply until one validates against schema, feeding the validation
+// error back to the model on each retry, and decodes the valid reply into out. The
+// returned Exchange is the last prompt and reply.
+func (c *LLMClassifier) generate(ctx context.Context, prompt string, input string, schema *Schema, out any) (Exchange, error) {
+	attempts := c.MaxAttempts
+	if attempts <= 0 {
+		attempts = DefaultMaxAttempts
+	}
+	request := prompt
+	var exchange Exchange
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		reply, err := c.Generator.Generate(ctx, request, input)
+		if err != nil {
+			return exchange, err
+		}
+		exchange = Exchange{Prompt: request + "\n\n" + input, Response: reply}
+		body := extractJSON(reply)
+		if lastErr = schema.Validate([]byte(body)); lastErr == nil {
+			return exchange, json.Unmarshal([]byte(body), out)
+		}
+		request = prompt + "\n\nYour previous reply was rejected because " + lastErr.Error() +
+			". Previous reply: " + reply + "\nReply again with only the corrected JSON."
+	}
+	return exchange, fmt.Errorf("%w after %d attempts: %v", ErrInvalidResponse, attempts, lastErr)
+}
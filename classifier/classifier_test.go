@@ -0,0 +1,241 @@
+package classifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend/main/models"
+)
+
+var (
+	_ IngredientClassifier = (*LLMClassifier)(nil)
+	_ IngredientClassifier = (*Fake)(nil)
+)
+
+// scriptedGenerator replies with each reply in turn and records the prompts it was sent
+type scriptedGenerator struct {
+	replies []string
+	prompts []string
+}
+
+func (g *scriptedGenerator) Generate(ctx context.Context, prompt string, input string) (string, error) {
+	g.prompts = append(g.prompts, prompt)
+	if len(g.prompts) > len(g.replies) {
+		return "", errors.New("no more replies")
+	}
+	return g.replies[len(g.prompts)-1], nil
+}
+
+func TestSimplify(t *testing.T) {
+	generator := &scriptedGenerator{replies: []string{
+		"```json\n{\"Fresh Green Bell Pepper\": {\"ingredient\": \"Bell Peppers\", \"confidence\": 0.9}, \"Fresh Avocados\": {\"ingredient\": \"avocados\", \"confidence\": 1}}\n```",
+	}}
+	got, err := NewLLMClassifier(generator).Simplify(context.Background(), []string{"Fresh Green Bell Pepper", "Fresh Avocados"})
+	if err != nil {
+		t.Fatalf("Simplify() error = %v", err)
+	}
+	if got.Names["Fresh Green Bell Pepper"] != (Suggestion{Name: "bell peppers", Confidence: 0.9}) || got.Names["Fresh Avocados"].Name != "avocados" {
+		t.Errorf("Simplify() = %v", got.Names)
+	}
+	if !strings.Contains(got.Prompt, `["Fresh Green Bell Pepper","Fresh Avocados"]`) || got.Response != generator.replies[0] {
+		t.Errorf("Simplify() exchange = %+v", got.Exchange)
+	}
+}
+
+func TestSimplifyRepairsInvalidReply(t *testing.T) {
+	generator := &scriptedGenerator{replies: []string{
+		"Sure! Here are the names: bell peppers",
+		`{"Fresh Green Bell Pepper": {"ingredient": "bell peppers", "confidence": 0.8}, "Family Pack": {"ingredient": "chicken", "confidence": 0.5}}`,
+		`{"Fresh Green Bell Pepper": {"ingredient": "bell peppers", "confidence": 0.8}}`,
+	}}
+	got, err := NewLLMClassifier(generator).Simplify(context.Background(), []string{"Fresh Green Bell Pepper"})
+	if err != nil {
+		t.Fatalf("Simplify() error = %v", err)
+	}
+	if got.Names["Fresh Green Bell Pepper"].Name != "bell peppers" {
+		t.Errorf("Simplify() = %v", got.Names)
+	}
+	if got.Response != generator.replies[2] {
+		t.Errorf("Simplify() response = %q, want the repaired reply", got.Response)
+	}
+	if len(generator.prompts) != 3 {
+		t.Fatalf("sent %d prompts, want 3", len(generator.prompts))
+	}
+	if !strings.Contains(generator.prompts[2], `unexpected property "Family Pack"`) {
+		t.Errorf("repair prompt does not explain the error: %q", generator.prompts[2])
+	}
+}
+
+func TestSimplifyGivesUpAfterMaxAttempts(t *testing.T) {
+	generator := &scriptedGenerator{replies: []string{`{"Fresh Avocados": "avocados"}`, `{}`, `{}`, `{}`}}
+	c := NewLLMClassifier(generator)
+	c.MaxAttempts = 2
+	_, err := c.Simplify(context.Background(), []string{"Fresh Avocados"})
+	if !errors.Is(err, ErrInvalidResponse) {
+		t.Fatalf("Simplify() error = %v, want ErrInvalidResponse", err)
+	}
+	if len(generator.prompts) != 2 {
+		t.Errorf("sent %d prompts, want 2", len(generator.prompts))
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name       string
+		replies    []string
+		wantType   models.FoodType
+		wantSeason []int
+		wantErr    bool
+	}{
+		{name: "vegetable", replies: []string{`{"type": "Vegetable", "season": [9, 10, 11], "confidence": 0.9}`}, wantType: models.Vegetable, wantSeason: []int{9, 10, 11}},
+		{name: "season dropped for meat", replies: []string{`{"type": "Meat", "season": [1], "confidence": 0.9}`}, wantType: models.Meat},
+		{name: "null season", replies: []string{`{"type": "Dairy", "season": null, "confidence": 0.9}`}, wantType: models.Dairy},
+		{name: "repairs unknown type", replies: []string{`{"type": "vegetables", "confidence": 0.9}`, `{"type": "Vegetable", "season": [6], "confidence": 0.9}`}, wantType: models.Vegetable, wantSeason: []int{6}},
+		{name: "repairs missing confidence", replies: []string{`{"type": "Dairy"}`, `{"type": "Dairy", "confidence": 0.7}`}, wantType: models.Dairy},
+		{name: "repairs confidence out of range", replies: []string{`{"type": "Dairy", "confidence": 90}`, `{"type": "Dairy", "confidence": 0.9}`}, wantType: models.Dairy},
+		{name: "repairs month out of range", replies: []string{`{"type": "Fruit", "season": [0, 13], "confidence": 0.9}`, `{"type": "Fruit", "season": [7, 8], "confidence": 0.9}`}, wantType: models.Fruit, wantSeason: []int{7, 8}},
+		{name: "repairs duplicate months", replies: []string{`{"type": "Fruit", "season": [7, 7], "confidence": 0.9}`, `{"type": "Fruit", "season": [7], "confidence": 0.9}`}, wantType: models.Fruit, wantSeason: []int{7}},
+		{name: "rejects extra fields", replies: []string{`{"type": "Fruit", "notes": "x", "confidence": 0.9}`, `{"type": "Fruit", "notes": "x", "confidence": 0.9}`, `{"type": "Fruit", "notes": "x", "confidence": 0.9}`}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := &scriptedGenerator{replies: tt.replies}
+			got, err := NewLLMClassifier(generator).Classify(context.Background(), "ingredient")
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidResponse) {
+					t.Fatalf("Classify() error = %v, want ErrInvalidResponse", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Classify() error = %v", err)
+			}
+			if got.Type != tt.wantType {
+				t.Errorf("Classify() type = %v, want %v", got.Type, tt.wantType)
+			}
+			if got.Response != tt.replies[len(tt.replies)-1] {
+				t.Errorf("Classify() response = %q, want the last reply", got.Response)
+			}
+			season := got.Season
+			if tt.wantSeason == nil {
+				if season != nil {
+					t.Errorf("Classify() season = %v, want nil", *season)
+				}
+				return
+			}
+			if season == nil || len(*season) != len(tt.wantSeason) {
+				t.Fatalf("Classify() season = %v, want %v", season, tt.wantSeason)
+			}
+			for i := range tt.wantSeason {
+				if (*season)[i] != tt.wantSeason[i] {
+					t.Errorf("Classify() season = %v, want %v", *season, tt.wantSeason)
+				}
+			}
+		})
+	}
+}
+
+func TestSchemaValidate(t *testing.T) {
+	schema := &Schema{
+		Type:     "object",
+		Required: []string{"name"},
+		Properties: map[string]*Schema{
+			"name":  {Type: "string", MinLength: 1},
+			"count": {Type: "integer", Minimum: float64Ptr(0)},
+		},
+	}
+	tests := []struct {
+		body    string
+		wantErr bool
+	}{
+		{`{"name": "milk"}`, false},
+		{`{"name": "milk", "count": 2}`, false},
+		{`{"name": ""}`, true},
+		{`{"count": 2}`, true},
+		{`{"name": "milk", "count": 1.5}`, true},
+		{`{"name": "milk", "count": -1}`, true},
+		{`{"name": "milk", "extra": true}`, true},
+		{`{"name": "milk"} {"name": "eggs"}`, true},
+		{`["milk"]`, true},
+		{`not json`, true},
+	}
+	for _, tt := range tests {
+		err := schema.Validate([]byte(tt.body))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%s) error = %v, wantErr %v", tt.body, err, tt.wantErr)
+		}
+	}
+}
+
+func TestOpenAIGenerator(t *testing.T) {
+	var got chatRequest
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			http.NotFound(w, r)
+			return
+		}
+		auth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"role": "assistant", "content": "{\"type\": \"Seafood\", \"confidence\": 0.9}"}}]}`))
+	}))
+	defer server.Close()
+
+	c := NewOpenAI(server.URL+"/v1/", "llama3", "secret", server.Client())
+	result, err := c.Classify(context.Background(), "salmon")
+	if err != nil {
+		t.Fatalf("Classify() error = %v", err)
+	}
+	if result.Type != models.Seafood || result.Confidence != 0.9 {
+		t.Errorf("Classify() = %+v, want Seafood with confidence 0.9", result)
+	}
+	if got.Model != "llama3" || len(got.Messages) != 2 || got.Messages[1].Content != "salmon" {
+		t.Errorf("request = %+v", got)
+	}
+	if auth != "Bearer secret" {
+		t.Errorf("Authorization = %q", auth)
+	}
+}
+
+func TestOpenAIGeneratorStatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "model not loaded", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewOpenAI(server.URL, "llama3", "", server.Client()).Classify(context.Background(), "salmon")
+	var statusErr *OpenAIStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Classify() error = %v, want OpenAIStatusError 503", err)
+	}
+}
+
+func TestFake(t *testing.T) {
+	fake := &Fake{
+		Names:   map[string]string{"Fresh Green Bell Pepper": "bell peppers"},
+		Types:   map[string]models.FoodType{"bell peppers": models.Vegetable},
+		Seasons: map[string][]int{"bell peppers": {7, 8, 9}},
+	}
+	simplified, err := fake.Simplify(context.Background(), []string{"Fresh Green Bell Pepper", " Whole Milk "})
+	if err != nil {
+		t.Fatalf("Simplify() error = %v", err)
+	}
+	if simplified.Names["Fresh Green Bell Pepper"].Name != "bell peppers" || simplified.Names[" Whole Milk "].Name != "whole milk" {
+		t.Errorf("Simplify() = %v", simplified.Names)
+	}
+	got, _ := fake.Classify(context.Background(), "bell peppers")
+	if got.Type != models.Vegetable || got.Season == nil || len(*got.Season) != 3 {
+		t.Errorf("Classify() = %+v", got)
+	}
+	if got, _ := fake.Classify(context.Background(), "paper towels"); got.Type != models.Other || got.Season != nil {
+		t.Errorf("Classify() = %+v", got)
+	}
+}
@@ -0,0 +1,58 @@
+package classifier
+
+import (
+	"context"
+	"strings"
+
+	"backend/main/models"
+)
+
+// Fake is a deterministic IngredientClassifier for tests. Simplify returns Names[name]
+// when set and the lowercased, trimmed name otherwise; Classify returns Types[name] or
+// Other, with Seasons[name]. Every answer has a confidence of 1.
+type Fake struct {
+	Names   map[string]string
+	Types   map[string]models.FoodType
+	Seasons map[string][]int
+	// Err, when set, is returned by every call
+	Err error
+	// Simplified records every name passed to Simplify, in order
+	Simplified []string
+	// Classified records every name passed to Classify, in order
+	Classified []string
+}
+
+// Simplify maps each name through Names, falling back to the lowercased name
+func (f *Fake) Simplify(ctx context.Context, names []string) (Simplification, error) {
+	if f.Err != nil {
+		return Simplification{}, f.Err
+	}
+	simplified := Simplification{Names: make(map[string]Suggestion, len(names))}
+	for _, name := range names {
+		f.Simplified = append(f.Simplified, name)
+		simple, ok := f.Names[name]
+		if !ok {
+			simple = strings.ToLower(strings.TrimSpace(name))
+		}
+		simplified.Names[name] = Suggestion{Name: simple, Confidence: 1}
+	}
+	simplified.Prompt = "fake: simplify " + strings.Join(names, ", ")
+	return simplified, nil
+}
+
+// Classify returns Types[name], or Other, and Seasons[name]
+func (f *Fake) Classify(ctx context.Context, name string) (Classification, error) {
+	if f.Err != nil {
+		return Classification{Type: models.Other}, f.Err
+	}
+	f.Classified = append(f.Classified, name)
+	result := Classification{Type: models.Other, Confidence: 1}
+	result.Prompt = "fake: classify " + name
+	if foodType, ok := f.Types[name]; ok {
+		result.Type = foodType
+	}
+	if season, ok := f.Seasons[name]; ok {
+		result.Season = &season
+	}
+	return result, nil
+}
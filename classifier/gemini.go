@@ -0,0 +1,42 @@
+package classifier
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// GeminiGenerator is a Generator backed by a Gemini model
+type GeminiGenerator struct {
+	Model *genai.GenerativeModel
+}
+
+// NewGemini returns a classifier backed by a Gemini model
+func NewGemini(model *genai.GenerativeModel) *LLMClassifier {
+	return NewLLMClassifier(&GeminiGenerator{Model: model})
+}
+
+// Generate sends the prompt and input as two text parts and joins the text of the reply
+func (g *GeminiGenerator) Generate(ctx context.Context, prompt string, input string) (string, error) {
+	if g.Model == nil {
+		return "", errors.New("classifier: gemini model is not initialized")
+	}
+	resp, err := g.Model.GenerateContent(ctx, genai.Text(prompt), genai.Text(input))
+	if err != nil {
+		return "", err
+	}
+	var reply strings.Builder
+	for _, candidate := range resp.Candidates {
+		if candidate.Content == nil {
+			continue
+		}
+		for _, part := range candidate.Content.Parts {
+			if text, ok := part.(genai.Text); ok {
+				reply.WriteString(string(text))
+			}
+		}
+	}
+	return reply.String(), nil
+}
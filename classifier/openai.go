@@ -0,0 +1,112 @@
+package classifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const openAITimeout = 60 * time.Second
+
+// Doer sends an HTTP request. *http.Client satisfies it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// OpenAIStatusError is returned when an OpenAI-compatible endpoint responds with a non-2xx status
+type OpenAIStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *OpenAIStatusError) Error() string {
+	return fmt.Sprintf("classifier: openai endpoint returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// OpenAIGenerator is a Generator for an OpenAI-compatible chat completions endpoint,
+// such as a local llama.cpp, Ollama or vLLM server
+type OpenAIGenerator struct {
+	// BaseURL is the API root, e.g. "http://localhost:11434/v1"
+	BaseURL string
+	Model   string
+	// APIKey is sent as a bearer token when set
+	APIKey string
+	HTTP   Doer
+}
+
+// NewOpenAI returns a classifier backed by an OpenAI-compatible endpoint. A nil client
+// uses an http.Client with a 60 second timeout.
+func NewOpenAI(baseURL string, model string, apiKey string, client Doer) *LLMClassifier {
+	if client == nil {
+		client = &http.Client{Timeout: openAITimeout}
+	}
+	return NewLLMClassifier(&OpenAIGenerator{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		Model:   model,
+		APIKey:  apiKey,
+		HTTP:    client,
+	})
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model          string            `json:"model"`
+	Messages       []chatMessage     `json:"messages"`
+	Temperature    float64           `json:"temperature"`
+	ResponseFormat map[string]string `json:"response_format"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// Generate sends the prompt as the system message and the input as the user message
+func (g *OpenAIGenerator) Generate(ctx context.Context, prompt string, input string) (string, error) {
+	body, err := json.Marshal(chatRequest{
+		Model: g.Model,
+		Messages: []chatMessage{
+			{Role: "system", Content: prompt},
+			{Role: "user", Content: input},
+		},
+		ResponseFormat: map[string]string{"type": "json_object"},
+	})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.BaseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if g.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+g.APIKey)
+	}
+	resp, err := g.HTTP.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", &OpenAIStatusError{StatusCode: resp.StatusCode, Body: string(snippet)}
+	}
+	var completion chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		return "", fmt.Errorf("classifier: decoding openai response: %w", err)
+	}
+	if len(completion.Choices) == 0 {
+		return "", fmt.Errorf("classifier: openai response has no choices")
+	}
+	return completion.Choices[0].Message.Content, nil
+}
@@ -0,0 +1,166 @@
+package classifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Schema is the subset of JSON Schema used to validate LLM replies. Objects are strict:
+// properties not listed in Properties are rejected unless AdditionalProperties is set.
+type Schema struct {
+	// Type is "object", "array", "string", "integer", "number" or "boolean"
+	Type                 string
+	Nullable             bool
+	Properties           map[string]*Schema
+	Required             []string
+	AdditionalProperties *Schema
+	Items                *Schema
+	MaxItems             int
+	UniqueItems          bool
+	Enum                 []string
+	MinLength            int
+	Minimum              *float64
+	Maximum              *float64
+}
+
+// ValidationError reports where a reply failed its schema
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// Validate decodes data as a single JSON value and checks it against the schema
+func (s *Schema) Validate(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return &ValidationError{Path: "$", Message: "invalid JSON: " + err.Error()}
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return &ValidationError{Path: "$", Message: "unexpected data after JSON value"}
+	}
+	return s.validate("$", value)
+}
+
+func (s *Schema) validate(path string, value any) error {
+	if value == nil {
+		if s.Nullable {
+			return nil
+		}
+		return &ValidationError{Path: path, Message: "must not be null"}
+	}
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return &ValidationError{Path: path, Message: "must be an object"}
+		}
+		for _, name := range s.Required {
+			if _, ok := object[name]; !ok {
+				return &ValidationError{Path: path, Message: fmt.Sprintf("missing required property %q", name)}
+			}
+		}
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			property, ok := s.Properties[key]
+			if !ok {
+				property = s.AdditionalProperties
+			}
+			if property == nil {
+				return &ValidationError{Path: path, Message: fmt.Sprintf("unexpected property %q", key)}
+			}
+			if err := property.validate(path+"."+key, object[key]); err != nil {
+				return err
+			}
+		}
+	case "array":
+		array, ok := value.([]any)
+		if !ok {
+			return &ValidationError{Path: path, Message: "must be an array"}
+		}
+		if s.MaxItems > 0 && len(array) > s.MaxItems {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("must have at most %d items", s.MaxItems)}
+		}
+		seen := make(map[string]bool, len(array))
+		for i, item := range array {
+			if s.Items != nil {
+				if err := s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+					return err
+				}
+			}
+			if s.UniqueItems {
+				key := fmt.Sprint(item)
+				if seen[key] {
+					return &ValidationError{Path: path, Message: fmt.Sprintf("duplicate item %v", item)}
+				}
+				seen[key] = true
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return &ValidationError{Path: path, Message: "must be a string"}
+		}
+		if len(strings.TrimSpace(str)) < s.MinLength {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("must be at least %d characters", s.MinLength)}
+		}
+		if len(s.Enum) > 0 && !slices.Contains(s.Enum, str) {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("must be one of %s", strings.Join(s.Enum, ", "))}
+		}
+	case "integer", "number":
+		number, ok := value.(json.Number)
+		if !ok {
+			return &ValidationError{Path: path, Message: "must be a " + s.Type}
+		}
+		if s.Type == "integer" {
+			if _, err := number.Int64(); err != nil {
+				return &ValidationError{Path: path, Message: "must be an integer"}
+			}
+		}
+		f, err := number.Float64()
+		if err != nil {
+			return &ValidationError{Path: path, Message: "must be a number"}
+		}
+		if s.Minimum != nil && f < *s.Minimum {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("must be at least %v", *s.Minimum)}
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("must be at most %v", *s.Maximum)}
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return &ValidationError{Path: path, Message: "must be a boolean"}
+		}
+	default:
+		return &ValidationError{Path: path, Message: fmt.Sprintf("unsupported schema type %q", s.Type)}
+	}
+	return nil
+}
+
+// extractJSON strips markdown code fences and any prose around the outermost JSON object
+func extractJSON(reply string) string {
+	reply = strings.TrimSpace(reply)
+	start := strings.IndexByte(reply, '{')
+	end := strings.LastIndexByte(reply, '}')
+	if start < 0 || end < start {
+		return reply
+	}
+	return reply[start : end+1]
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
@@ -30,6 +30,10 @@ func main() {
 
 	fmt.Println("Response:", res)
 	for _, ad := range res.Ads {
-		log.Printf("Ad Item: Ingredient=%s, Name=%s, Price=%f, Sale=%f\n", ad.AdItems[0].Ingredient, ad.AdItems[0].Name, ad.AdItems[0].Price, ad.AdItems[0].Sale)
+		if len(ad.AdItems) == 0 {
+			continue
+		}
+		item := ad.AdItems[0]
+		log.Printf("Ad Item: Ingredient=%s, Name=%s, Price=%f, Sale=%s, Deal=%v\n", item.Ingredient, item.Name, item.GetPrice(), item.GetSale(), item.GetDeal())
 	}
 }
@@ -2,7 +2,6 @@ package controllers
 
 import (
 	"encoding/json"
-	"strconv"
 	
 	
 	"net/http"
@@ -22,15 +21,14 @@ func NewAdController(model *models.AdModel) *AdController {
 	}
 }
 
+// GetAd returns the most recent ad for the store given store_id
 func (a *AdController) GetAd(w http.ResponseWriter, r *http.Request) {
-	id := r.URL.Query().Get("id")
-
-	adID, err := strconv.ParseUint(id, 10, 32)
+	storeID, err := pathID(r, "store_id")
 	if err != nil {
 		http.Error(w, "Invalid ID", http.StatusBadRequest)
 		return
 	}
-	ad, err := a.AdModel.GetRecentAd(uint(adID))
+	ad, err := a.AdModel.GetRecentAd(r.Context(), storeID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
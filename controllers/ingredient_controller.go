@@ -2,12 +2,17 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
 	"strconv"
+	"strings"
 
 	"backend/main/config"
 	"backend/main/models"
 	"net/http"
 
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"go.uber.org/zap"
 )
 
@@ -25,13 +30,16 @@ func NewIngredientController(model *models.IngredientModel) *IngredientControlle
 
 // GetIngredientByID is a function to get ingredient by ID
 func (i *IngredientController) GetIngredientByID(w http.ResponseWriter, r *http.Request) {
-	id := r.URL.Query().Get("id")
-	ingredientID, err := strconv.ParseUint(id, 10, 32)
+	ingredientID, err := pathID(r, "id")
 	if err != nil {
 		http.Error(w, "Invalid ID", http.StatusBadRequest)
 		return
 	}
-	ingredient, err := i.IngredientModel.GetIngredientByID(uint(ingredientID))
+	ingredient, err := i.IngredientModel.GetIngredientByID(r.Context(), ingredientID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		http.Error(w, "Ingredient not found", http.StatusNotFound)
+		return
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -41,49 +49,100 @@ func (i *IngredientController) GetIngredientByID(w http.ResponseWriter, r *http.
 	json.NewEncoder(w).Encode(ingredient)
 }
 
-// CreateIngredients is a function to create new ingredient(s)
+// CreateIngredients is a function to create new ingredient(s). Ingredients whose name
+// already exists are updated. It returns the IDs in request order.
 func (i *IngredientController) CreateIngredients(w http.ResponseWriter, r *http.Request) {
 	var ingredients []models.Ingredient
 	err := json.NewDecoder(r.Body).Decode(&ingredients)
 	if err != nil {
-		config.Logger.Error("Error getting Firestore client", zap.Error(err), zap.String("function", "FindIngredientByID"))
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	err = i.IngredientModel.CreateIngredients(ingredients)
+	for _, ingredient := range ingredients {
+		if strings.TrimSpace(ingredient.Name) == "" {
+			http.Error(w, "Ingredient name is required", http.StatusBadRequest)
+			return
+		}
+	}
+	ids, err := i.IngredientModel.CreateIngredients(r.Context(), ingredients)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(ids)
 }
 
 // UpdateIngredient is a function to update an existing ingredient
 func (i *IngredientController) UpdateIngredient(w http.ResponseWriter, r *http.Request) {
-	var ingredient models.Ingredient
-	err := json.NewDecoder(r.Body).Decode(&ingredient)
+	ingredientID, err := pathID(r, "id")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
 		return
 	}
-	err = i.IngredientModel.UpdateIngredient(ingredient)
+	var ingredient models.Ingredient
+	err = json.NewDecoder(r.Body).Decode(&ingredient)
 	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(ingredient.Name) == "" {
+		http.Error(w, "Ingredient name is required", http.StatusBadRequest)
+		return
+	}
+	ingredient.ID = &ingredientID
+	err = i.IngredientModel.UpdateIngredient(r.Context(), ingredient)
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		http.Error(w, "Ingredient not found", http.StatusNotFound)
+		return
+	case errors.As(err, &pgErr) && pgErr.Code == "23505":
+		http.Error(w, "An ingredient with that name already exists", http.StatusConflict)
+		return
+	case err != nil:
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
 }
 
-// DeleteIngredient is a function to delete an existing ingredient
+// DeleteIngredient is a function to delete an existing ingredient. It is refused with 409
+// while recipes, ads, pantries or shopping lists use the ingredient, unless a
+// ?replacement_id= ingredient is given to reassign them to.
 func (i *IngredientController) DeleteIngredient(w http.ResponseWriter, r *http.Request) {
-	id := r.URL.Query().Get("id")
-	ingredientID, err := strconv.ParseUint(id, 10, 32)
+	ingredientID, err := pathID(r, "id")
 	if err != nil {
 		http.Error(w, "Invalid ID", http.StatusBadRequest)
 		return
 	}
-	err = i.IngredientModel.DeleteIngredient(uint(ingredientID))
-	if err != nil {
+	var replacementID *uint
+	if raw := r.URL.Query().Get("replacement_id"); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 0)
+		if err != nil {
+			http.Error(w, "Invalid replacement_id", http.StatusBadRequest)
+			return
+		}
+		id := uint(parsed)
+		replacementID = &id
+	}
+	err = i.IngredientModel.DeleteIngredient(r.Context(), ingredientID, replacementID)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		http.Error(w, "Ingredient not found", http.StatusNotFound)
+		return
+	case errors.Is(err, models.ErrReplacementNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, models.ErrInvalidMerge):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, models.ErrIngredientInUse):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		config.Logger.Error("Error deleting ingredient", zap.Error(err), zap.String("function", "DeleteIngredient"))
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -92,7 +151,7 @@ func (i *IngredientController) DeleteIngredient(w http.ResponseWriter, r *http.R
 
 // GetAllIngredients is a function to get all ingredients
 func (i *IngredientController) GetAllIngredients(w http.ResponseWriter, r *http.Request) {
-	ingredients, err := i.IngredientModel.GetAllIngredients()
+	ingredients, err := i.IngredientModel.GetAllIngredients(r.Context())
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -102,3 +161,148 @@ func (i *IngredientController) GetAllIngredients(w http.ResponseWriter, r *http.
 	json.NewEncoder(w).Encode(ingredients)
 }
 
+
+// GetAliases lists the aliases of an ingredient given id
+func (i *IngredientController) GetAliases(w http.ResponseWriter, r *http.Request) {
+	ingredientID, err := pathID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	aliases, err := i.IngredientModel.GetAliases(r.Context(), ingredientID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(aliases)
+}
+
+// AddAlias adds an alias to an ingredient given id and a JSON body {"alias": "scallions"}
+func (i *IngredientController) AddAlias(w http.ResponseWriter, r *http.Request) {
+	ingredientID, err := pathID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	var body models.IngredientAlias
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || strings.TrimSpace(body.Alias) == "" {
+		http.Error(w, "Invalid alias", http.StatusBadRequest)
+		return
+	}
+	err = i.IngredientModel.AddAlias(r.Context(), ingredientID, body.Alias)
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.As(err, &pgErr) && pgErr.Code == "23505":
+		http.Error(w, "Alias already exists", http.StatusConflict)
+		return
+	case errors.As(err, &pgErr) && pgErr.Code == "23503":
+		http.Error(w, "Ingredient not found", http.StatusNotFound)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// DeleteAlias removes an alias from an ingredient given id and alias
+func (i *IngredientController) DeleteAlias(w http.ResponseWriter, r *http.Request) {
+	ingredientID, err := pathID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	err = i.IngredientModel.DeleteAlias(r.Context(), ingredientID, mux.Vars(r)["alias"])
+	if errors.Is(err, pgx.ErrNoRows) {
+		http.Error(w, "Alias not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MergeIngredient merges a duplicate ingredient into the ingredient given id, with a JSON
+// body {"duplicate_id": 12, "merged_by": "admin"}, and returns the merge record
+func (i *IngredientController) MergeIngredient(w http.ResponseWriter, r *http.Request) {
+	canonicalID, err := pathID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	var body struct {
+		DuplicateID uint   `json:"duplicate_id"`
+		MergedBy    string `json:"merged_by"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.DuplicateID == 0 {
+		http.Error(w, "Invalid duplicate_id", http.StatusBadRequest)
+		return
+	}
+	merge, err := i.IngredientModel.MergeIngredients(r.Context(), body.DuplicateID, canonicalID, body.MergedBy)
+	switch {
+	case errors.Is(err, models.ErrInvalidMerge):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, pgx.ErrNoRows):
+		http.Error(w, "Ingredient not found", http.StatusNotFound)
+		return
+	case err != nil:
+		config.Logger.Error("Error merging ingredients", zap.Error(err), zap.String("function", "MergeIngredient"))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(merge)
+}
+
+// SetIngredientParent sets the parent of the ingredient given id, with a JSON body
+// {"parent_id": 3}, or clears it with {"parent_id": null}
+func (i *IngredientController) SetIngredientParent(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	var body struct {
+		ParentID *uint `json:"parent_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = i.IngredientModel.SetIngredientParent(r.Context(), id, body.ParentID)
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, models.ErrIngredientCycle):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case errors.Is(err, pgx.ErrNoRows):
+		http.Error(w, "Ingredient not found", http.StatusNotFound)
+		return
+	case errors.As(err, &pgErr) && pgErr.Code == "23503":
+		http.Error(w, "Parent ingredient not found", http.StatusNotFound)
+		return
+	case err != nil:
+		config.Logger.Error("Error setting ingredient parent", zap.Error(err), zap.String("function", "SetIngredientParent"))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetIngredientMerges returns the ingredient merge audit trail, newest first
+func (i *IngredientController) GetIngredientMerges(w http.ResponseWriter, r *http.Request) {
+	merges, err := i.IngredientModel.GetIngredientMerges(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(merges)
+}
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	
 	"net/http"
-	"strconv"
 	"backend/main/models"
 
 )
@@ -23,13 +22,12 @@ func NewPantryController(model *models.PantryModel) *PantryController {
 
 // GetPantry retrieves all pantry items
 func (pc *PantryController) GetPantryItems(w http.ResponseWriter, r *http.Request) {
-	id := r.URL.Query().Get("user_id")
-	userID, err := strconv.ParseUint(id, 10, 32)
+	userID, err := pathID(r, "user_id")
 	if err != nil {
 		http.Error(w, "Invalid ID", http.StatusBadRequest)
 		return
 	}
-	pantry, err := pc.PantryModel.GetPantryItems(uint(userID))
+	pantry, err := pc.PantryModel.GetPantryItems(r.Context(), userID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -41,8 +39,7 @@ func (pc *PantryController) GetPantryItems(w http.ResponseWriter, r *http.Reques
 
 // AddToPantry takes in user_id and cart items and adds cart items to user's pantry
 func (pc *PantryController) AddPantryIngredients(w http.ResponseWriter, r *http.Request) {
-	id := r.URL.Query().Get("user_id")
-	userID, err := strconv.ParseUint(id, 10, 32)
+	userID, err := pathID(r, "user_id")
 	if err != nil {
 		http.Error(w, "Invalid ID", http.StatusBadRequest)
 		return
@@ -50,11 +47,11 @@ func (pc *PantryController) AddPantryIngredients(w http.ResponseWriter, r *http.
 	var pantryItems []models.PantryIngredient
 	err = json.NewDecoder(r.Body).Decode(&pantryItems)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	pantry := models.NewPantry(nil, uint(userID), pantryItems)
-	err = pc.PantryModel.AddPantryIngredients(*pantry)
+	pantry := models.NewPantry(nil, userID, pantryItems)
+	err = pc.PantryModel.AddPantryIngredients(r.Context(), *pantry)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -22,13 +22,23 @@ func NewRecipeController(model *models.RecipeModel) *RecipeController {
 
 // SetRecipe creates a recipe given recipe title, link, and ingredients
 func (rc *RecipeController) SetRecipe(w http.ResponseWriter, r *http.Request) {
-
-	
+	var recipe models.Recipe
+	err := json.NewDecoder(r.Body).Decode(&recipe)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = rc.RecipeModel.CreateRecipes(r.Context(), []models.Recipe{recipe})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
 }
 
 // GetAllRecipes retrieves all recipes
 func (rc *RecipeController) GetAllRecipes(w http.ResponseWriter, r *http.Request) {
-	recipes, err := rc.RecipeModel.GetAllRecipes()
+	recipes, err := rc.RecipeModel.GetAllRecipes(r.Context())
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -0,0 +1,17 @@
+package controllers
+
+import (
+	"backend/main/services"
+	"backend/main/models"
+	"backend/main/config"
+)
+
+func InitRecommendationController(db models.DB) *services.RecommendationService {
+	storeModel := models.NewStoreModel(db, *config.Logger)
+	adModel := models.NewAdModel(db, *config.Logger)
+	recipeModel := models.NewRecipeModel(db, *config.Logger)
+	pantryModel := models.NewPantryModel(db, *config.Logger)
+	ingredientModel := models.NewIngredientModel(db, *config.Logger)
+
+	return services.NewRecommendationService(storeModel, adModel, recipeModel, pantryModel, ingredientModel)
+}
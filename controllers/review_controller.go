@@ -0,0 +1,171 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"backend/main/config"
+	"backend/main/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+const defaultReviewLimit = 100
+
+// ReviewController defines a struct for the admin review queue controller
+type ReviewController struct {
+	ReviewModel *models.ReviewModel
+}
+
+// NewReviewController is a constructor for ReviewController
+func NewReviewController(model *models.ReviewModel) *ReviewController {
+	return &ReviewController{
+		ReviewModel: model,
+	}
+}
+
+// GetReviewItems lists review items, filtered by the status and kind query parameters.
+// status defaults to pending; status=all lists every item.
+func (rc *ReviewController) GetReviewItems(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	status := models.ReviewStatus(query.Get("status"))
+	switch status {
+	case "":
+		status = models.ReviewPending
+	case "all":
+		status = ""
+	case models.ReviewPending, models.ReviewApproved, models.ReviewRejected:
+	default:
+		http.Error(w, "Invalid status", http.StatusBadRequest)
+		return
+	}
+	kind := models.ReviewKind(query.Get("kind"))
+	if kind != "" && kind != models.ReviewTranslation && kind != models.ReviewIngredient {
+		http.Error(w, "Invalid kind", http.StatusBadRequest)
+		return
+	}
+	limit := defaultReviewLimit
+	if raw := query.Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+	items, err := rc.ReviewModel.GetReviewItems(r.Context(), status, kind, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(items)
+}
+
+// GetReviewItem returns one review item given id
+func (rc *ReviewController) GetReviewItem(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	item, err := rc.ReviewModel.GetReviewItemByID(r.Context(), id)
+	if err != nil {
+		writeReviewError(w, err, "GetReviewItem")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(item)
+}
+
+// ApproveReviewItem approves a pending review item given id
+func (rc *ReviewController) ApproveReviewItem(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	if err := rc.ReviewModel.ApproveReviewItem(r.Context(), id); err != nil {
+		writeReviewError(w, err, "ApproveReviewItem")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// EditReviewItem corrects and approves a pending review item given id and a JSON
+// ReviewEdit body
+func (rc *ReviewController) EditReviewItem(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	var edit models.ReviewEdit
+	if err := json.NewDecoder(r.Body).Decode(&edit); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := rc.ReviewModel.EditReviewItem(r.Context(), id, edit); err != nil {
+		writeReviewError(w, err, "EditReviewItem")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RejectReviewItem rejects a pending review item given id and a JSON body
+// {"replacement_ingredient_id": N} to remap affected rows onto
+func (rc *ReviewController) RejectReviewItem(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	var body struct {
+		ReplacementIngredientID *uint `json:"replacement_ingredient_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if body.ReplacementIngredientID == nil {
+		http.Error(w, "replacement_ingredient_id is required", http.StatusBadRequest)
+		return
+	}
+	if err := rc.ReviewModel.RejectReviewItem(r.Context(), id, *body.ReplacementIngredientID); err != nil {
+		writeReviewError(w, err, "RejectReviewItem")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeReviewError maps review model errors onto HTTP statuses. Unexpected errors are
+// logged and answered without their detail.
+func writeReviewError(w http.ResponseWriter, err error, function string) {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		http.Error(w, "Review item not found", http.StatusNotFound)
+	case errors.Is(err, models.ErrReplacementNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.As(err, &pgErr) && pgErr.Code == "23503":
+		// an ingredient removed while the review ran
+		http.Error(w, "Ingredient not found", http.StatusNotFound)
+	case errors.As(err, &pgErr) && pgErr.Code == "23505":
+		// an edit renaming an ingredient onto a name already taken
+		http.Error(w, "Ingredient name already exists", http.StatusConflict)
+	case errors.Is(err, models.ErrReviewNotPending), errors.Is(err, models.ErrIngredientInUse):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, models.ErrInvalidReviewEdit):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		config.Logger.Error("Error reviewing item", zap.Error(err), zap.String("function", function))
+		http.Error(w, "Error reviewing item", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"backend/main/config"
+	"backend/main/geo"
+	"backend/main/models"
+
+	"github.com/gorilla/mux"
+)
+
+// Route table for the REST API. Every handler below is served alongside the
+// gRPC UserFeedService.
+//
+//	GET    /ingredients                        list all ingredients
+//	POST   /ingredients                        create or update ingredients by name (JSON array body)
+//	GET    /ingredients/{id}                   get an ingredient
+//	PUT    /ingredients/{id}                   update an ingredient (JSON body)
+//	DELETE /ingredients/{id}                   delete an unused ingredient (?replacement_id= reassigns its uses)
+//	GET    /ingredients/{id}/aliases           list an ingredient's aliases
+//	POST   /ingredients/{id}/aliases           add an alias (JSON body {"alias": ...})
+//	DELETE /ingredients/{id}/aliases/{alias}   remove an alias
+//	PUT    /ingredients/{id}/parent            set or clear an ingredient's parent (JSON body {"parent_id": ...})
+//	POST   /ingredients/{id}/merge             merge a duplicate into this ingredient (JSON body {"duplicate_id": ...})
+//	GET    /ingredients/merges                 list the ingredient merge audit trail
+//	GET    /recipes                            list all recipes
+//	POST   /recipes                            create a recipe (JSON body)
+//	POST   /stores                             create a store (JSON body)
+//	GET    /stores/{store_id}/ad               get the most recent ad for a store
+//	GET    /users/{user_id}/stores             list a user's subscribed stores
+//	PUT    /users/{user_id}/stores/{store_id}  subscribe a user to a store
+//	DELETE /users/{user_id}/stores/{store_id}  unsubscribe a user from a store
+//	GET    /users/{user_id}/pantry             list a user's pantry items
+//	POST   /users/{user_id}/pantry             add items to a user's pantry (JSON array body)
+//	GET    /admin/review                       list LLM-created items (?status=pending|approved|rejected|all&kind=&limit=)
+//	GET    /admin/review/{id}                  get a review item
+//	PUT    /admin/review/{id}                  correct and approve a review item (JSON body)
+//	POST   /admin/review/{id}/approve          approve a review item
+//	POST   /admin/review/{id}/reject           reject a review item (JSON body with replacement_ingredient_id)
+
+// InitRouter builds the REST controllers against the shared PostgreSQL pool. geocoder
+// places new stores and may be nil.
+func InitRouter(db models.DB, geocoder *geo.Table) *mux.Router {
+	ingredientController := NewIngredientController(models.NewIngredientModel(db, *config.Logger))
+	recipeController := NewRecipeController(models.NewRecipeModel(db, *config.Logger))
+	storeController := NewStoreController(models.NewStoreModel(db, *config.Logger), geocoder)
+	adController := NewAdController(models.NewAdModel(db, *config.Logger))
+	pantryController := NewPantryController(models.NewPantryModel(db, *config.Logger))
+	reviewController := NewReviewController(models.NewReviewModel(db, *config.Logger))
+
+	return NewRouter(ingredientController, recipeController, storeController, adController, pantryController, reviewController)
+}
+
+// NewRouter registers every controller handler on a gorilla/mux router
+func NewRouter(ic *IngredientController, rc *RecipeController, sc *StoreController, ac *AdController, pc *PantryController, rv *ReviewController) *mux.Router {
+	r := mux.NewRouter()
+
+	r.HandleFunc("/ingredients", ic.GetAllIngredients).Methods(http.MethodGet)
+	r.HandleFunc("/ingredients", ic.CreateIngredients).Methods(http.MethodPost)
+	r.HandleFunc("/ingredients/{id:[0-9]+}", ic.GetIngredientByID).Methods(http.MethodGet)
+	r.HandleFunc("/ingredients/{id:[0-9]+}", ic.UpdateIngredient).Methods(http.MethodPut)
+	r.HandleFunc("/ingredients/{id:[0-9]+}", ic.DeleteIngredient).Methods(http.MethodDelete)
+	r.HandleFunc("/ingredients/{id:[0-9]+}/aliases", ic.GetAliases).Methods(http.MethodGet)
+	r.HandleFunc("/ingredients/{id:[0-9]+}/aliases", ic.AddAlias).Methods(http.MethodPost)
+	r.HandleFunc("/ingredients/{id:[0-9]+}/aliases/{alias}", ic.DeleteAlias).Methods(http.MethodDelete)
+	r.HandleFunc("/ingredients/{id:[0-9]+}/parent", ic.SetIngredientParent).Methods(http.MethodPut)
+	r.HandleFunc("/ingredients/{id:[0-9]+}/merge", ic.MergeIngredient).Methods(http.MethodPost)
+	r.HandleFunc("/ingredients/merges", ic.GetIngredientMerges).Methods(http.MethodGet)
+
+	r.HandleFunc("/recipes", rc.GetAllRecipes).Methods(http.MethodGet)
+	r.HandleFunc("/recipes", rc.SetRecipe).Methods(http.MethodPost)
+
+	r.HandleFunc("/stores", sc.AddStore).Methods(http.MethodPost)
+	r.HandleFunc("/stores/{store_id:[0-9]+}/ad", ac.GetAd).Methods(http.MethodGet)
+
+	r.HandleFunc("/users/{user_id:[0-9]+}/stores", sc.GetSubscribedStores).Methods(http.MethodGet)
+	r.HandleFunc("/users/{user_id:[0-9]+}/stores/{store_id:[0-9]+}", sc.SubscribeStore).Methods(http.MethodPut)
+	r.HandleFunc("/users/{user_id:[0-9]+}/stores/{store_id:[0-9]+}", sc.UnsubscribeStore).Methods(http.MethodDelete)
+
+	r.HandleFunc("/users/{user_id:[0-9]+}/pantry", pc.GetPantryItems).Methods(http.MethodGet)
+	r.HandleFunc("/users/{user_id:[0-9]+}/pantry", pc.AddPantryIngredients).Methods(http.MethodPost)
+
+	r.HandleFunc("/admin/review", rv.GetReviewItems).Methods(http.MethodGet)
+	r.HandleFunc("/admin/review/{id:[0-9]+}", rv.GetReviewItem).Methods(http.MethodGet)
+	r.HandleFunc("/admin/review/{id:[0-9]+}", rv.EditReviewItem).Methods(http.MethodPut)
+	r.HandleFunc("/admin/review/{id:[0-9]+}/approve", rv.ApproveReviewItem).Methods(http.MethodPost)
+	r.HandleFunc("/admin/review/{id:[0-9]+}/reject", rv.RejectReviewItem).Methods(http.MethodPost)
+
+	return r
+}
+
+// pathID parses a numeric path parameter registered on the route
+func pathID(r *http.Request, name string) (uint, error) {
+	id, err := strconv.ParseUint(mux.Vars(r)[name], 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
@@ -0,0 +1,17 @@
+package controllers
+
+import (
+	"backend/main/services"
+	"backend/main/models"
+	"backend/main/config"
+)
+
+func InitShoppingListController(db models.DB) *services.ShoppingListService {
+	shoppingListModel := models.NewShoppingListModel(db, *config.Logger)
+	recipeModel := models.NewRecipeModel(db, *config.Logger)
+	pantryModel := models.NewPantryModel(db, *config.Logger)
+	storeModel := models.NewStoreModel(db, *config.Logger)
+	adModel := models.NewAdModel(db, *config.Logger)
+
+	return services.NewShoppingListService(shoppingListModel, recipeModel, pantryModel, storeModel, adModel)
+}
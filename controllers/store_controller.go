@@ -3,25 +3,29 @@ package controllers
 import (
 	"encoding/json"
 	"net/http"
-	"strconv"
 	"backend/main/models"
 	"backend/main/config"
+	"backend/main/geo"
 	"go.uber.org/zap"
 )
 
 // StoreController defines a struct for Store controller
 type StoreController struct {
 	StoreModel *models.StoreModel
+	// Geocoder places new stores by postal code, nil saves them without coordinates
+	Geocoder *geo.Table
 }
 
 // NewStoreController is a constructor for StoreController
-func NewStoreController(model *models.StoreModel) *StoreController {
+func NewStoreController(model *models.StoreModel, geocoder *geo.Table) *StoreController {
 	return &StoreController{
 		StoreModel: model,
+		Geocoder: geocoder,
 	}
 }
 
-// Add Store adds store given store_name and address
+// Add Store adds store given store_name and address, geocoding its postal code when no
+// latitude and longitude are given
 func (sc *StoreController) AddStore(w http.ResponseWriter, r *http.Request) {
 	var store models.Store
 	err := json.NewDecoder(r.Body).Decode(&store)
@@ -30,7 +34,19 @@ func (sc *StoreController) AddStore(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	err = sc.StoreModel.CreateStore(store)
+	if (store.Latitude == nil) != (store.Longitude == nil) {
+		http.Error(w, "latitude and longitude must be given together", http.StatusBadRequest)
+		return
+	}
+	if point, ok := store.Point(); ok && point.Validate() != nil {
+		http.Error(w, geo.ErrInvalidPoint.Error(), http.StatusBadRequest)
+		return
+	}
+	// stores whose postal code is unknown are saved without coordinates
+	if !store.Geocode(sc.Geocoder) {
+		config.Logger.Warn("Saving store without coordinates", zap.String("postal_code", store.PostalCode()), zap.String("function", "AddStore"))
+	}
+	err = sc.StoreModel.CreateStore(r.Context(), store)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -40,33 +56,60 @@ func (sc *StoreController) AddStore(w http.ResponseWriter, r *http.Request) {
 
 // Subscribe store subscribes user to a store given user_id and store_id
 func (sc *StoreController) SubscribeStore(w http.ResponseWriter, r *http.Request) {
-	id := r.URL.Query().Get("user_id")
-	userID, err := strconv.ParseUint(id, 10, 32)
+	userID, err := pathID(r, "user_id")
 	if err != nil {
 		http.Error(w, "Invalid ID", http.StatusBadRequest)
 		return
 	}
-	storeID, err := strconv.ParseUint(id, 10, 32)
+	storeID, err := pathID(r, "store_id")
 	if err != nil {
 		http.Error(w, "Invalid ID", http.StatusBadRequest)
 		return
 	}
+	err = sc.StoreModel.SubscribeStore(r.Context(), userID, storeID)
 	if err != nil {
-		config.Logger.Error("Error getting Firestore client", zap.Error(err), zap.String("function", "SubscribeStore"))
+		config.Logger.Error("Error subscribing to store", zap.Error(err), zap.String("function", "SubscribeStore"))
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	err = sc.StoreModel.SubscribeStore(uint(userID), uint(storeID))
+	w.WriteHeader(http.StatusCreated)
+}
+
+// UnsubscribeStore removes a user's subscription to a store given user_id and store_id
+func (sc *StoreController) UnsubscribeStore(w http.ResponseWriter, r *http.Request) {
+	userID, err := pathID(r, "user_id")
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	storeID, err := pathID(r, "store_id")
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	err = sc.StoreModel.UnsubscribeStore(r.Context(), userID, storeID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	w.WriteHeader(http.StatusCreated)
+	w.WriteHeader(http.StatusNoContent)
 }
 
-// GetSubscriptions returns all store_ids of a subscried stores given user_id
+// GetSubscriptions returns all subscribed stores given user_id
 func (sc *StoreController) GetSubscribedStores(w http.ResponseWriter, r *http.Request) {
-	
+	userID, err := pathID(r, "user_id")
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	stores, err := sc.StoreModel.GetSubscribedStores(r.Context(), userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(stores)
 }
 
 
@@ -1,16 +1,21 @@
 package controllers
 
 import (
+	"backend/main/geo"
 	"backend/main/services"
 	"backend/main/models"
 	"backend/main/config"
 )
 
-func InitUserFeedController() *services.UserFeedService {
-	userModel := models.NewUserModel(config.PostgreSQL, *config.Logger)
-	storeModel := models.NewStoreModel(config.PostgreSQL, *config.Logger)
-	adModel := models.NewAdModel(config.PostgreSQL, *config.Logger)
-	ingredientModel := models.NewIngredientModel(config.PostgreSQL, *config.Logger)
+// InitUserFeedController builds the UserFeedService. geocoder may be nil, which disables
+// searching stores by postal code.
+func InitUserFeedController(db models.DB, geocoder *geo.Table) *services.UserFeedService {
+	userModel := models.NewUserModel(db, *config.Logger)
+	storeModel := models.NewStoreModel(db, *config.Logger)
+	adModel := models.NewAdModel(db, *config.Logger)
+	ingredientModel := models.NewIngredientModel(db, *config.Logger)
 
-	return services.NewUserFeedService(userModel, storeModel, adModel, ingredientModel)
+	service := services.NewUserFeedService(userModel, storeModel, adModel, ingredientModel)
+	service.Geocoder = geocoder
+	return service
 }
\ No newline at end of file
@@ -0,0 +1,113 @@
+// Package deals turns the free-form price text on ad items, such as "/lb",
+// "2 for $5", "BOGO", "buy 1 get 1 50% off" or "with card", into structured deal terms.
+package deals
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"backend/main/units"
+)
+
+// Deal is the structured form of an ad item's price and post_price_text
+type Deal struct {
+	// UnitPrice is the price of one Unit after multi-buy, BOGO and second-item terms are applied
+	UnitPrice *float32 `json:"unit_price"`
+	// Unit is the canonical unit the price is quoted in: "each", "lb", "kg", "oz" or "g"
+	Unit string `json:"unit"`
+	// Quantity is how many must be bought to get the deal, 1 unless multi-buy, BOGO or second-item
+	Quantity int `json:"quantity"`
+	// MultiBuyPrice is the total price for Quantity items on multi-buy deals
+	MultiBuyPrice      *float32 `json:"multi_buy_price"`
+	MembershipRequired bool     `json:"membership_required"`
+	// BOGO is buy one get one free
+	BOGO bool `json:"bogo"`
+	// SecondItemPercentOff is the discount on the second item of a buy one get one at a
+	// percentage off, such as 50 for "buy 1 get 1 50% off" or "half price"
+	SecondItemPercentOff *float32 `json:"second_item_percent_off"`
+}
+
+var (
+	multiBuyPattern   = regexp.MustCompile(`(\d+)\s*(?:for|/)\s*\$?\s*(\d+(?:\.\d+)?)`)
+	buyOneGetOne      = `(?:\bbogo\b|\bb1g1\b|buy\s*(?:1|one)\s*,?\s*get\s*(?:1|one)\b)`
+	secondItemPattern = regexp.MustCompile(buyOneGetOne + `\s*(?:(?:at|for)\s+)?(?:(\d+(?:\.\d+)?)\s*%|half)\s*(?:off|price)?`)
+	// the second item only comes free when the text says so, or is just "bogo"
+	bogoPattern       = regexp.MustCompile(`\bbogo\b|\bb1g1\b|buy\s*(?:1|one)\s*,?\s*get\s*(?:1|one)\s*(?:free\b|$)`)
+	membershipPattern = regexp.MustCompile(`\bw(?:ith|/)\s*(?:\w+\s+)?(?:card|app|coupon|rewards?|membership)\b|\bmembers?\b|\bclub\s*(?:card|price)\b|\bdigital\s*coupon\b|\bloyalty\b`)
+	// a unit counts only on its own, after "/" or "per", or right after a price, so package
+	// sizes such as "2 lb bag" or "16 oz" are not read as per-unit prices
+	perUnitPattern = regexp.MustCompile(`(?:^|/|\bper\s+|\$\d+(?:\.\d+)?)\s*(100\s*g|kg|lbs?|oz|g|ea|each)\b`)
+)
+
+// Parse builds a Deal from an ad item's post_price_text and current price. Unrecognized
+// text yields a plain per-each deal at the current price.
+func Parse(postPriceText *string, currentPrice *float32) Deal {
+	deal := Deal{Unit: units.Each.Name, Quantity: 1, UnitPrice: currentPrice}
+	if postPriceText == nil {
+		return deal
+	}
+	text := strings.ToLower(strings.TrimSpace(*postPriceText))
+	if text == "" {
+		return deal
+	}
+
+	deal.MembershipRequired = membershipPattern.MatchString(text)
+
+	// the unit applies to BOGO prices too, as in "BOGO $4.99/lb"
+	if match := perUnitPattern.FindStringSubmatch(text); match != nil {
+		unit := strings.ReplaceAll(match[1], " ", "")
+		if unit == "100g" {
+			// quote per-100g prices per kg so they compare with "/kg"
+			deal.Unit = "kg"
+			if currentPrice != nil {
+				deal.UnitPrice = float32Ptr(*currentPrice * 10)
+			}
+		} else {
+			deal.Unit = units.NormalizeUnit(unit)
+		}
+	}
+
+	if match := secondItemPattern.FindStringSubmatch(text); match != nil {
+		percent := float32(50)
+		if match[1] != "" {
+			parsed, err := strconv.ParseFloat(match[1], 32)
+			if err != nil || parsed <= 0 || parsed > 100 {
+				return deal
+			}
+			percent = float32(parsed)
+		}
+		deal.Quantity = 2
+		deal.SecondItemPercentOff = float32Ptr(percent)
+		if deal.UnitPrice != nil {
+			deal.UnitPrice = float32Ptr(*deal.UnitPrice * (2 - percent/100) / 2)
+		}
+		return deal
+	}
+
+	if bogoPattern.MatchString(text) {
+		deal.BOGO = true
+		deal.Quantity = 2
+		if deal.UnitPrice != nil {
+			deal.UnitPrice = float32Ptr(*deal.UnitPrice / 2)
+		}
+		return deal
+	}
+
+	if match := multiBuyPattern.FindStringSubmatch(text); match != nil {
+		quantity, qErr := strconv.Atoi(match[1])
+		total, pErr := strconv.ParseFloat(match[2], 32)
+		if qErr == nil && pErr == nil && quantity > 0 {
+			deal.Unit = units.Each.Name
+			deal.Quantity = quantity
+			deal.MultiBuyPrice = float32Ptr(float32(total))
+			deal.UnitPrice = float32Ptr(float32(total) / float32(quantity))
+			return deal
+		}
+	}
+	return deal
+}
+
+func float32Ptr(v float32) *float32 {
+	return &v
+}
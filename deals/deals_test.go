@@ -0,0 +1,89 @@
+package deals
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	price := func(v float32) *float32 { return &v }
+	tests := []struct {
+		text       string
+		price      *float32
+		wantUnit   string
+		wantPrice  *float32
+		wantQty    int
+		wantMulti  *float32
+		wantMember bool
+		wantBOGO   bool
+		wantOff    *float32
+	}{
+		{text: "", price: price(3), wantUnit: "each", wantPrice: price(3), wantQty: 1},
+		{text: "/lb", price: price(2.99), wantUnit: "lb", wantPrice: price(2.99), wantQty: 1},
+		{text: "lb", price: price(2.99), wantUnit: "lb", wantPrice: price(2.99), wantQty: 1},
+		{text: "per lb", price: price(2.99), wantUnit: "lb", wantPrice: price(2.99), wantQty: 1},
+		{text: "/kg", price: price(6.59), wantUnit: "kg", wantPrice: price(6.59), wantQty: 1},
+		{text: "/100 g", price: price(1.5), wantUnit: "kg", wantPrice: price(15), wantQty: 1},
+		{text: "ea", price: price(1), wantUnit: "each", wantPrice: price(1), wantQty: 1},
+		{text: "$3.99 lb", price: price(3.99), wantUnit: "lb", wantPrice: price(3.99), wantQty: 1},
+		{text: "$3.99/lb", price: price(3.99), wantUnit: "lb", wantPrice: price(3.99), wantQty: 1},
+		{text: "2 lb bag $5.99", price: price(5.99), wantUnit: "each", wantPrice: price(5.99), wantQty: 1},
+		{text: "16 oz", price: price(2.49), wantUnit: "each", wantPrice: price(2.49), wantQty: 1},
+		{text: "500 g tub", price: price(4), wantUnit: "each", wantPrice: price(4), wantQty: 1},
+		{text: "2 for $5", price: nil, wantUnit: "each", wantPrice: price(2.5), wantQty: 2, wantMulti: price(5)},
+		{text: "3/$10", price: price(10), wantUnit: "each", wantPrice: price(10.0 / 3), wantQty: 3, wantMulti: price(10)},
+		{text: "BOGO", price: price(4), wantUnit: "each", wantPrice: price(2), wantQty: 2, wantBOGO: true},
+		{text: "Buy 1 Get 1 Free", price: price(6), wantUnit: "each", wantPrice: price(3), wantQty: 2, wantBOGO: true},
+		{text: "buy one get one", price: price(6), wantUnit: "each", wantPrice: price(3), wantQty: 2, wantBOGO: true},
+		{text: "BOGO $4.99/lb", price: price(4.99), wantUnit: "lb", wantPrice: price(2.495), wantQty: 2, wantBOGO: true},
+		{text: "Buy 1 Get 1 50% off", price: price(6), wantUnit: "each", wantPrice: price(4.5), wantQty: 2, wantOff: price(50)},
+		{text: "buy one get one half price", price: price(6), wantUnit: "each", wantPrice: price(4.5), wantQty: 2, wantOff: price(50)},
+		{text: "buy 1, get 1 at 25% off /lb", price: price(4), wantUnit: "lb", wantPrice: price(3.5), wantQty: 2, wantOff: price(25)},
+		{text: "BOGO 40% off", price: nil, wantUnit: "each", wantPrice: nil, wantQty: 2, wantOff: price(40)},
+		{text: "buy 1 get 1 with card", price: price(6), wantUnit: "each", wantPrice: price(6), wantQty: 1, wantMember: true},
+		{text: "with card", price: price(3.49), wantUnit: "each", wantPrice: price(3.49), wantQty: 1, wantMember: true},
+		{text: "/lb with Club Card", price: price(1.99), wantUnit: "lb", wantPrice: price(1.99), wantQty: 1, wantMember: true},
+		{text: "w/ card 2 for $6", price: nil, wantUnit: "each", wantPrice: price(3), wantQty: 2, wantMulti: price(6), wantMember: true},
+		{text: "save $1", price: price(2), wantUnit: "each", wantPrice: price(2), wantQty: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			text := tt.text
+			got := Parse(&text, tt.price)
+			if got.Unit != tt.wantUnit || got.Quantity != tt.wantQty || got.MembershipRequired != tt.wantMember || got.BOGO != tt.wantBOGO {
+				t.Errorf("Parse(%q) = %+v", tt.text, got)
+			}
+			if !equalPrice(got.UnitPrice, tt.wantPrice) {
+				t.Errorf("Parse(%q) unit price = %v, want %v", tt.text, deref(got.UnitPrice), deref(tt.wantPrice))
+			}
+			if !equalPrice(got.SecondItemPercentOff, tt.wantOff) {
+				t.Errorf("Parse(%q) second item percent off = %v, want %v", tt.text, deref(got.SecondItemPercentOff), deref(tt.wantOff))
+			}
+			if !equalPrice(got.MultiBuyPrice, tt.wantMulti) {
+				t.Errorf("Parse(%q) multi-buy price = %v, want %v", tt.text, deref(got.MultiBuyPrice), deref(tt.wantMulti))
+			}
+		})
+	}
+}
+
+func TestParseNilText(t *testing.T) {
+	price := float32(1.25)
+	got := Parse(nil, &price)
+	if got.Unit != "each" || got.Quantity != 1 || got.UnitPrice == nil || *got.UnitPrice != price {
+		t.Errorf("Parse(nil) = %+v", got)
+	}
+}
+
+func equalPrice(a, b *float32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	diff := *a - *b
+	return diff < 1e-4 && diff > -1e-4
+}
+
+func deref(p *float32) interface{} {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
@@ -0,0 +1,181 @@
+// Package geo geocodes postal codes offline and measures distances between points, so
+// stores can be placed on a map and searched by distance without a geocoding service.
+//
+// The postal code table uses the GeoNames postal code dump format: tab separated
+// country code, postal code, place name, admin name 1, admin code 1, admin name 2,
+// admin code 2, admin name 3, admin code 3, latitude, longitude and accuracy. Download a
+// country's dump, e.g. US.zip, or allCountries.zip from download.geonames.org/export/zip
+// and Load the extracted text file.
+package geo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// earthRadiusKm is the mean radius of the Earth
+const earthRadiusKm = 6371.0
+
+// DefaultCountry is assumed when a postal code comes without a country
+const DefaultCountry = "US"
+
+// ErrInvalidPoint is returned for a latitude outside -90..90 or a longitude outside -180..180
+var ErrInvalidPoint = errors.New("geo: latitude must be within -90 and 90 and longitude within -180 and 180")
+
+// Point is a position in decimal degrees
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Validate returns ErrInvalidPoint if p is not on the globe
+func (p Point) Validate() error {
+	if math.IsNaN(p.Latitude) || math.IsNaN(p.Longitude) || math.Abs(p.Latitude) > 90 || math.Abs(p.Longitude) > 180 {
+		return ErrInvalidPoint
+	}
+	return nil
+}
+
+// Distance returns the great-circle distance between a and b in kilometers
+func Distance(a, b Point) float64 {
+	lat1, lat2 := radians(a.Latitude), radians(b.Latitude)
+	dLat, dLng := lat2-lat1, radians(b.Longitude-a.Longitude)
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+func degrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
+
+// Box is a latitude and longitude range, Min being the south west corner
+type Box struct {
+	Min Point
+	Max Point
+}
+
+// Contains reports whether p is inside b, edges included
+func (b Box) Contains(p Point) bool {
+	return p.Latitude >= b.Min.Latitude && p.Latitude <= b.Max.Latitude &&
+		p.Longitude >= b.Min.Longitude && p.Longitude <= b.Max.Longitude
+}
+
+// BoundingBox returns the smallest latitude and longitude range holding every point within
+// radiusKm of center. A circle reaching a pole or the antimeridian spans every longitude.
+func BoundingBox(center Point, radiusKm float64) Box {
+	angle := radiusKm / earthRadiusKm
+	lat := radians(center.Latitude)
+	box := Box{
+		Min: Point{Latitude: degrees(lat - angle), Longitude: -180},
+		Max: Point{Latitude: degrees(lat + angle), Longitude: 180},
+	}
+	if box.Min.Latitude <= -90 || box.Max.Latitude >= 90 {
+		box.Min.Latitude, box.Max.Latitude = math.Max(box.Min.Latitude, -90), math.Min(box.Max.Latitude, 90)
+		return box
+	}
+	dLng := degrees(math.Asin(math.Min(1, math.Sin(angle)/math.Cos(lat))))
+	if west, east := center.Longitude-dLng, center.Longitude+dLng; west >= -180 && east <= 180 {
+		box.Min.Longitude, box.Max.Longitude = west, east
+	}
+	return box
+}
+
+// Place is where a postal code is
+type Place struct {
+	Point
+	City  string
+	State string
+}
+
+// Table maps postal codes onto places
+type Table struct {
+	places map[string]Place
+}
+
+// Parse reads a postal code table in the GeoNames format
+func Parse(r io.Reader) (*Table, error) {
+	t := &Table{places: make(map[string]Place)}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+		if len(fields) < 11 {
+			return nil, fmt.Errorf("geo: line %d: expected at least 11 tab separated fields, got %d", line, len(fields))
+		}
+		latitude, err := strconv.ParseFloat(fields[9], 64)
+		if err != nil {
+			return nil, fmt.Errorf("geo: line %d: latitude: %w", line, err)
+		}
+		longitude, err := strconv.ParseFloat(fields[10], 64)
+		if err != nil {
+			return nil, fmt.Errorf("geo: line %d: longitude: %w", line, err)
+		}
+		place := Place{Point: Point{latitude, longitude}, City: fields[2], State: fields[4]}
+		if err := place.Validate(); err != nil {
+			return nil, fmt.Errorf("geo: line %d: %w", line, err)
+		}
+		t.places[key(fields[0], fields[1])] = place
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Load reads a postal code table from a GeoNames dump on disk
+func Load(path string) (*Table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	t, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return t, nil
+}
+
+// Lookup finds a postal code. Case and spacing do not matter, an empty country means
+// DefaultCountry, and when the full code is unknown its first part is tried, so that
+// ZIP+4 codes like 94105-1234 and full Canadian codes like M5V 2T6 are found by their
+// 94105 and M5V prefixes.
+func (t *Table) Lookup(country, postalCode string) (Place, bool) {
+	if t == nil {
+		return Place{}, false
+	}
+	postalCode = strings.TrimSpace(postalCode)
+	if place, ok := t.places[key(country, postalCode)]; ok {
+		return place, true
+	}
+	if prefix, _, found := strings.Cut(postalCode, "-"); found {
+		postalCode = prefix
+	} else if prefix, _, found := strings.Cut(postalCode, " "); found {
+		postalCode = prefix
+	} else {
+		return Place{}, false
+	}
+	place, ok := t.places[key(country, postalCode)]
+	return place, ok
+}
+
+func key(country, postalCode string) string {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if country == "" {
+		country = DefaultCountry
+	}
+	return country + "|" + strings.ToUpper(strings.Join(strings.Fields(postalCode), " "))
+}
@@ -0,0 +1,138 @@
+package geo
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Point
+		want float64
+	}{
+		{"same point", Point{37.7864, -122.3892}, Point{37.7864, -122.3892}, 0},
+		{"one degree of latitude", Point{0, 0}, Point{1, 0}, 111.19},
+		{"New York to Chicago", Point{40.7484, -73.9967}, Point{41.8858, -87.6181}, 1146},
+		{"across the antimeridian", Point{0, 179.5}, Point{0, -179.5}, 111.19},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Distance(tt.a, tt.b); math.Abs(got-tt.want) > tt.want*0.01+0.01 {
+				t.Errorf("Distance() = %.2f km, want %.2f km", got, tt.want)
+			}
+		})
+	}
+}
+
+// destination is the point distanceKm from p along bearing, in degrees clockwise from north
+func destination(p Point, bearing, distanceKm float64) Point {
+	angle, theta := distanceKm/earthRadiusKm, radians(bearing)
+	lat1, lng1 := radians(p.Latitude), radians(p.Longitude)
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(angle) + math.Cos(lat1)*math.Sin(angle)*math.Cos(theta))
+	lng2 := lng1 + math.Atan2(math.Sin(theta)*math.Sin(angle)*math.Cos(lat1), math.Cos(angle)-math.Sin(lat1)*math.Sin(lat2))
+	return Point{degrees(lat2), math.Remainder(degrees(lng2), 360)}
+}
+
+func TestBoundingBox(t *testing.T) {
+	tests := []struct {
+		name          string
+		center        Point
+		radiusKm      float64
+		wantAllLngs   bool
+		wantMaxLatGte float64
+	}{
+		{"San Francisco", Point{37.7864, -122.3892}, 10, false, 37.87},
+		{"equator", Point{0, 0}, 100, false, 0.89},
+		{"far north", Point{70, 25}, 500, false, 74.4},
+		{"across the antimeridian", Point{-17.7, 179.9}, 50, true, -17.26},
+		{"reaching the pole", Point{89.5, 0}, 100, true, 90},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box := BoundingBox(tt.center, tt.radiusKm)
+			for bearing := 0.0; bearing < 360; bearing += 5 {
+				p := destination(tt.center, bearing, tt.radiusKm*0.999)
+				if !box.Contains(p) {
+					t.Errorf("%+v does not contain %+v at bearing %v", box, p, bearing)
+				}
+			}
+			if allLngs := box.Min.Longitude == -180 && box.Max.Longitude == 180; allLngs != tt.wantAllLngs {
+				t.Errorf("box %+v spans every longitude = %v, want %v", box, allLngs, tt.wantAllLngs)
+			}
+			if box.Max.Latitude < tt.wantMaxLatGte || box.Max.Latitude > 90 || box.Min.Latitude < -90 {
+				t.Errorf("box %+v latitude out of range", box)
+			}
+			if far := destination(tt.center, 180, tt.radiusKm*1.5); !tt.wantAllLngs && box.Contains(far) {
+				t.Errorf("%+v contains %+v, %v km away", box, far, tt.radiusKm*1.5)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	table, err := Load("testdata/postal_codes.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		country, postalCode string
+		wantCity            string
+	}{
+		{"US", "94105", "San Francisco"},
+		{"", "94105", "San Francisco"},
+		{"us", " 94105-1234 ", "San Francisco"},
+		{"CA", "m5v 2t6", "Toronto"},
+		{"US", "00000", ""},
+		{"CA", "94105", ""},
+	}
+	for _, tt := range tests {
+		place, ok := table.Lookup(tt.country, tt.postalCode)
+		if ok != (tt.wantCity != "") || place.City != tt.wantCity {
+			t.Errorf("Lookup(%q, %q) = %+v, %v, want %q", tt.country, tt.postalCode, place, ok, tt.wantCity)
+		}
+	}
+	if place, _ := table.Lookup("US", "94105"); place.State != "CA" || place.Validate() != nil {
+		t.Errorf("Lookup(94105) = %+v", place)
+	}
+	if _, ok := (*Table)(nil).Lookup("US", "94105"); ok {
+		t.Error("nil table found a postal code")
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	if _, err := Load("testdata/missing.txt"); err == nil {
+		t.Error("Load() of a missing file succeeded")
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"comments and blank lines", "# header\n\nUS\t1\tA\tB\tB\t\t\t\t\t1.5\t2.5\t4\n", ""},
+		{"too few fields", "US\t94105\tSan Francisco\n", "expected at least 11"},
+		{"bad latitude", "US\t1\tA\tB\tB\t\t\t\t\tnorth\t2.5\t4\n", "latitude"},
+		{"off the globe", "US\t1\tA\tB\tB\t\t\t\t\t91\t2.5\t4\n", "latitude must be"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := Parse(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Parse() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if place, ok := table.Lookup("US", "1"); !ok || place.Latitude != 1.5 || place.Longitude != 2.5 {
+				t.Errorf("Lookup(1) = %+v, %v", place, ok)
+			}
+		})
+	}
+}
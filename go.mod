@@ -6,7 +6,8 @@ require (
 	github.com/google/generative-ai-go v0.19.0
 	github.com/gorilla/mux v1.8.1
 	github.com/jackc/pgx/v5 v5.7.2
-	github.com/robfig/cron/v3 v3.0.1
+	github.com/stretchr/testify v1.10.0
+	go.temporal.io/api v1.44.1
 	go.temporal.io/sdk v1.33.0
 )
 
@@ -21,14 +22,13 @@ require (
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.22.0 // indirect
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
+	github.com/jackc/puddle/v2 v2.2.2 // indirect
 	github.com/nexus-rpc/sdk-go v0.3.0 // indirect
 	github.com/pborman/uuid v1.2.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/robfig/cron v1.2.0 // indirect
 	github.com/stretchr/objx v0.5.2 // indirect
-	github.com/stretchr/testify v1.10.0 // indirect
 	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
-	go.temporal.io/api v1.44.1 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
@@ -4,17 +4,28 @@ import (
 	"backend/main/config"
 
 	"backend/main/controllers"
+	"backend/main/geo"
+	"backend/main/models"
 	"context"
+	"errors"
 
 	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"google.golang.org/grpc"
 	"backend/main/pb"
 	"net"
 )
 
+const grpcAddr = ":50051"
+const httpAddr = ":8080"
+const shutdownTimeout = 10 * time.Second
+
 func main() {
-	config.ConnectPostgreSQL()
 	config.InitFirebase()
 	config.InitLogger()
 	config.InitGenAI()
@@ -23,8 +34,27 @@ func main() {
 	if err != nil {
 		return
 	}
+	// one pool shared by every gRPC and REST handler; DATABASE_URL falls back to the PG* variables
+	pool, err := models.NewPool(ctx, os.Getenv("DATABASE_URL"))
+	if err != nil {
+		fmt.Println("Failed to connect to PostgreSQL:", err)
+		return
+	}
+	defer pool.Close()
 
-	lis, err := net.Listen("tcp", ":50051")
+	// POSTAL_CODES_PATH is a GeoNames postal code dump used to place stores and search them by postal code
+	var geocoder *geo.Table
+	if path := os.Getenv("POSTAL_CODES_PATH"); path != "" {
+		geocoder, err = geo.Load(path)
+		if err != nil {
+			fmt.Println("Failed to load postal codes:", err)
+			return
+		}
+	} else {
+		fmt.Println("POSTAL_CODES_PATH is not set, stores cannot be searched or placed by postal code")
+	}
+
+	lis, err := net.Listen("tcp", grpcAddr)
 	if err != nil {
 		fmt.Println("Failed to listen:", err)
 		return
@@ -32,11 +62,67 @@ func main() {
 
 	grpcServer := grpc.NewServer()
 
-	userFeedService := controllers.InitUserFeedController()
+	userFeedService := controllers.InitUserFeedController(pool, geocoder)
+	// stores saved before the postal code table was loaded are placed once here, so
+	// searches never geocode them
+	if geocoder != nil {
+		placed, unplaced, err := userFeedService.PlaceStores(ctx)
+		if err != nil {
+			fmt.Println("Failed to place stores:", err)
+		} else if placed > 0 || unplaced > 0 {
+			fmt.Printf("Placed %d store(s), %d could not be placed by postal code\n", placed, unplaced)
+		}
+	}
 	pb.RegisterUserFeedServiceServer(grpcServer, userFeedService)
+	recommendationService := controllers.InitRecommendationController(pool)
+	pb.RegisterRecommendationServiceServer(grpcServer, recommendationService)
+	shoppingListService := controllers.InitShoppingListController(pool)
+	pb.RegisterShoppingListServiceServer(grpcServer, shoppingListService)
 
-	fmt.Println("gRPC server running on :50051")
-	if err := grpcServer.Serve(lis); err != nil {
-		fmt.Println("failed to serve: %v", err)
+	httpServer := &http.Server{
+		Addr:              httpAddr,
+		Handler:           controllers.InitRouter(pool, geocoder),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// Either server failing, or SIGINT/SIGTERM, stops both
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	serveErr := make(chan error, 2)
+
+	go func() {
+		fmt.Println("gRPC server running on", grpcAddr)
+		serveErr <- grpcServer.Serve(lis)
+	}()
+	go func() {
+		fmt.Println("REST server running on", httpAddr)
+		if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
+			return
+		}
+		serveErr <- nil
+	}()
+
+	select {
+	case <-ctx.Done():
+		fmt.Println("Shutting down servers")
+	case err := <-serveErr:
+		fmt.Println("Server stopped:", err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		fmt.Println("Failed to shut down REST server:", err)
+	}
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-shutdownCtx.Done():
+		grpcServer.Stop()
 	}
-}
\ No newline at end of file
+}
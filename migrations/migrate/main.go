@@ -0,0 +1,100 @@
+// Command migrate creates or updates the database schema from the embedded migrations.
+//
+//	migrate [up]        apply every pending migration
+//	migrate down [N]    roll back the newest N migrations (default 1)
+//	migrate to VERSION  move up or down to VERSION
+//	migrate status      list applied and pending migrations
+//
+// DATABASE_URL selects the database; when unset the standard PG* variables are used.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"backend/main/migrations"
+	"backend/main/models"
+)
+
+func main() {
+	if err := run(context.Background(), os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "migrate:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	command := "up"
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+	pool, err := models.NewPool(ctx, os.Getenv("DATABASE_URL"))
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+	migrator, err := migrations.New(pool)
+	if err != nil {
+		return err
+	}
+
+	var done []int
+	switch command {
+	case "up":
+		done, err = migrator.Up(ctx)
+	case "down":
+		steps := 1
+		if len(args) > 0 {
+			if steps, err = strconv.Atoi(args[0]); err != nil || steps < 1 {
+				return fmt.Errorf("invalid step count %q", args[0])
+			}
+		}
+		done, err = migrator.Down(ctx, steps)
+	case "to":
+		if len(args) == 0 {
+			return fmt.Errorf("to needs a version")
+		}
+		target, convErr := strconv.Atoi(args[0])
+		if convErr != nil {
+			return fmt.Errorf("invalid version %q", args[0])
+		}
+		done, err = migrator.Migrate(ctx, target)
+	case "status":
+		return status(ctx, migrator)
+	default:
+		return fmt.Errorf("unknown command %q", command)
+	}
+	for _, version := range done {
+		fmt.Println("migrated", version, migrator.Migrations[version-1].Name)
+	}
+	if err != nil {
+		return err
+	}
+	version, err := migrator.Version(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("schema at version %d of %d\n", version, migrator.Latest())
+	return nil
+}
+
+func status(ctx context.Context, migrator *migrations.Migrator) error {
+	applied, err := migrator.Applied(ctx)
+	if err != nil {
+		return err
+	}
+	appliedAt := make(map[int]string, len(applied))
+	for _, m := range applied {
+		appliedAt[m.Version] = m.AppliedAt
+	}
+	for _, m := range migrator.Migrations {
+		state := "pending"
+		if at, ok := appliedAt[m.Version]; ok {
+			state = "applied " + at
+		}
+		fmt.Printf("%04d %-32s %s\n", m.Version, m.Name, state)
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+// Package migrations holds the versioned database schema. Each version is a pair of
+// sql/NNNN_name.up.sql and sql/NNNN_name.down.sql files embedded into the binary, and
+// applied versions are recorded in the schema_migrations table.
+package migrations
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"backend/main/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+//go:embed sql/*.sql
+var files embed.FS
+
+// lockID is the pg_advisory_xact_lock key that serializes concurrent migrators
+const lockID = 4242001
+
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is one schema version
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Load reads the embedded migrations in version order. Every version must have both
+// an up and a down file, and versions must run 1, 2, 3, ... without gaps.
+func Load() ([]Migration, error) {
+	return load(files, "sql")
+}
+
+func load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migrations: unexpected file %q", entry.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+		body, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migrations: version %d is named both %q and %q", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migrations: version %d (%s) needs both an up and a down file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(a, b int) bool {
+		return migrations[a].Version < migrations[b].Version
+	})
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			return nil, fmt.Errorf("migrations: expected version %d, found %d", i+1, m.Version)
+		}
+	}
+	return migrations, nil
+}
+
+// ErrUnknownVersion is returned when a target version is outside the known migrations
+var ErrUnknownVersion = errors.New("migrations: unknown version")
+
+// Migrator applies and rolls back migrations against a database
+type Migrator struct {
+	DB         models.DB
+	Migrations []Migration
+}
+
+// New is a constructor for Migrator using the embedded migrations
+func New(db models.DB) (*Migrator, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{DB: db, Migrations: migrations}, nil
+}
+
+// Latest returns the newest known version
+func (m *Migrator) Latest() int {
+	return len(m.Migrations)
+}
+
+// Version returns the newest applied version, 0 for an empty database
+func (m *Migrator) Version(ctx context.Context) (int, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return 0, err
+	}
+	var version int
+	err := m.DB.QueryRow(ctx, "SELECT COALESCE(max(version), 0) FROM schema_migrations").Scan(&version)
+	return version, err
+}
+
+// Migrate moves the schema up or down to target, one transaction per version, and
+// returns the versions it applied or rolled back in order
+func (m *Migrator) Migrate(ctx context.Context, target int) ([]int, error) {
+	if target < 0 || target > m.Latest() {
+		return nil, ErrUnknownVersion
+	}
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	var done []int
+	for {
+		version, changed, err := m.step(ctx, target)
+		if err != nil {
+			return done, err
+		}
+		if !changed {
+			return done, nil
+		}
+		done = append(done, version)
+	}
+}
+
+// Up applies every pending migration
+func (m *Migrator) Up(ctx context.Context) ([]int, error) {
+	return m.Migrate(ctx, m.Latest())
+}
+
+// Down rolls back the newest steps applied migrations
+func (m *Migrator) Down(ctx context.Context, steps int) ([]int, error) {
+	current, err := m.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.Migrate(ctx, max(current-steps, 0))
+}
+
+// step applies or rolls back the one version between the current version and target,
+// holding an advisory lock so concurrent migrators cannot both apply it
+func (m *Migrator) step(ctx context.Context, target int) (int, bool, error) {
+	tx, err := m.DB.Begin(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	defer tx.Rollback(ctx)
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", lockID); err != nil {
+		return 0, false, err
+	}
+	var current int
+	if err := tx.QueryRow(ctx, "SELECT COALESCE(max(version), 0) FROM schema_migrations").Scan(&current); err != nil {
+		return 0, false, err
+	}
+	if current > m.Latest() {
+		return 0, false, fmt.Errorf("migrations: database is at version %d, newer than this binary's %d", current, m.Latest())
+	}
+	var version int
+	switch {
+	case current < target:
+		migration := m.Migrations[current]
+		version = migration.Version
+		if _, err := tx.Exec(ctx, migration.Up); err != nil {
+			return 0, false, fmt.Errorf("migrations: applying %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if _, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", migration.Version, migration.Name); err != nil {
+			return 0, false, err
+		}
+	case current > target:
+		migration := m.Migrations[current-1]
+		version = migration.Version
+		if _, err := tx.Exec(ctx, migration.Down); err != nil {
+			return 0, false, fmt.Errorf("migrations: rolling back %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if _, err := tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version); err != nil {
+			return 0, false, err
+		}
+	default:
+		return 0, false, nil
+	}
+	return version, true, tx.Commit(ctx)
+}
+
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	_, err := m.DB.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    int PRIMARY KEY,
+		name       text NOT NULL,
+		applied_at timestamptz NOT NULL DEFAULT now()
+	)`)
+	return err
+}
+
+// Applied lists the applied migrations with when they were applied
+func (m *Migrator) Applied(ctx context.Context) ([]AppliedMigration, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	rows, err := m.DB.Query(ctx, "SELECT version, name, applied_at::text FROM schema_migrations ORDER BY version")
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[AppliedMigration])
+}
+
+// AppliedMigration is a row of schema_migrations
+type AppliedMigration struct {
+	Version   int
+	Name      string
+	AppliedAt string
+}
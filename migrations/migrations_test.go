@@ -0,0 +1,95 @@
+package migrations
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadEmbedded(t *testing.T) {
+	migrations, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("Load() found no migrations")
+	}
+	if migrations[0].Name != "initial_schema" {
+		t.Errorf("first migration = %q, want initial_schema", migrations[0].Name)
+	}
+	for _, table := range []string{"ingredient", "translation", "ad", "ad_ingredient", "store", "store_subscription",
+		"recipe", "recipe_ingredient", "pantry", "grocery_user"} {
+		if !strings.Contains(migrations[0].Up, "CREATE TABLE "+table+" (") {
+			t.Errorf("initial schema does not create %s", table)
+		}
+		if !strings.Contains(migrations[0].Down, "DROP TABLE "+table+";") {
+			t.Errorf("initial schema does not drop %s", table)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	file := func(body string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(body)} }
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		want    []int
+		wantErr string
+	}{
+		{
+			name: "sorted by version",
+			files: fstest.MapFS{
+				"sql/0002_b.up.sql": file("b"), "sql/0002_b.down.sql": file("-b"),
+				"sql/0001_a.up.sql": file("a"), "sql/0001_a.down.sql": file("-a"),
+			},
+			want: []int{1, 2},
+		},
+		{
+			name:    "missing down",
+			files:   fstest.MapFS{"sql/0001_a.up.sql": file("a")},
+			wantErr: "needs both",
+		},
+		{
+			name: "gap",
+			files: fstest.MapFS{
+				"sql/0001_a.up.sql": file("a"), "sql/0001_a.down.sql": file("-a"),
+				"sql/0003_c.up.sql": file("c"), "sql/0003_c.down.sql": file("-c"),
+			},
+			wantErr: "expected version 2",
+		},
+		{
+			name:    "bad name",
+			files:   fstest.MapFS{"sql/create.sql": file("a")},
+			wantErr: "unexpected file",
+		},
+		{
+			name: "mismatched names",
+			files: fstest.MapFS{
+				"sql/0001_a.up.sql": file("a"), "sql/0001_b.down.sql": file("-a"),
+			},
+			wantErr: "named both",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := load(tt.files, "sql")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("load() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("load() = %d migrations, want %d", len(got), len(tt.want))
+			}
+			for i, version := range tt.want {
+				if got[i].Version != version {
+					t.Errorf("load()[%d].Version = %d, want %d", i, got[i].Version, version)
+				}
+			}
+		})
+	}
+}
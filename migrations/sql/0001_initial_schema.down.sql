@@ -0,0 +1,10 @@
+DROP TABLE pantry;
+DROP TABLE recipe_ingredient;
+DROP TABLE recipe;
+DROP TABLE ad_ingredient;
+DROP TABLE ad;
+DROP TABLE store_subscription;
+DROP TABLE store;
+DROP TABLE translation;
+DROP TABLE ingredient;
+DROP TABLE grocery_user;
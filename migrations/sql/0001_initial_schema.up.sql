@@ -0,0 +1,79 @@
+CREATE TABLE grocery_user (
+	id    serial PRIMARY KEY,
+	name  text NOT NULL,
+	email text NOT NULL UNIQUE
+);
+
+CREATE TABLE ingredient (
+	id     serial PRIMARY KEY,
+	name   text NOT NULL UNIQUE,
+	type   text NOT NULL DEFAULT 'Other',
+	season int[] NOT NULL DEFAULT '{}'
+);
+
+CREATE TABLE translation (
+	name          text PRIMARY KEY,
+	ingredient_id int NOT NULL REFERENCES ingredient (id)
+);
+CREATE INDEX translation_ingredient_id_idx ON translation (ingredient_id);
+
+CREATE TABLE store (
+	id             serial PRIMARY KEY,
+	name           text,
+	location       text NOT NULL DEFAULT '',
+	flipp_merchant text NOT NULL DEFAULT ''
+);
+
+CREATE TABLE store_subscription (
+	user_id  int NOT NULL REFERENCES grocery_user (id) ON DELETE CASCADE,
+	store_id int NOT NULL REFERENCES store (id) ON DELETE CASCADE,
+	PRIMARY KEY (user_id, store_id)
+);
+CREATE INDEX store_subscription_store_id_idx ON store_subscription (store_id);
+
+CREATE TABLE ad (
+	id         serial PRIMARY KEY,
+	store_id   int NOT NULL REFERENCES store (id) ON DELETE CASCADE,
+	sale_start date NOT NULL,
+	sale_end   date NOT NULL
+);
+CREATE INDEX ad_store_id_sale_start_idx ON ad (store_id, sale_start DESC);
+
+CREATE TABLE ad_ingredient (
+	id            serial PRIMARY KEY,
+	ad_id         int NOT NULL REFERENCES ad (id) ON DELETE CASCADE,
+	ingredient_id int NOT NULL REFERENCES ingredient (id),
+	name          text NOT NULL,
+	price         real,
+	sale          text
+);
+CREATE INDEX ad_ingredient_ad_id_idx ON ad_ingredient (ad_id);
+CREATE INDEX ad_ingredient_ingredient_id_idx ON ad_ingredient (ingredient_id);
+
+CREATE TABLE recipe (
+	id     serial PRIMARY KEY,
+	title  text NOT NULL,
+	link   text,
+	author text
+);
+
+CREATE TABLE recipe_ingredient (
+	id            serial PRIMARY KEY,
+	recipe_id     int NOT NULL REFERENCES recipe (id) ON DELETE CASCADE,
+	ingredient_id int REFERENCES ingredient (id),
+	amount        text,
+	unit          text,
+	name          text NOT NULL
+);
+CREATE INDEX recipe_ingredient_recipe_id_idx ON recipe_ingredient (recipe_id);
+CREATE INDEX recipe_ingredient_ingredient_id_idx ON recipe_ingredient (ingredient_id);
+
+CREATE TABLE pantry (
+	id            serial PRIMARY KEY,
+	user_id       int NOT NULL REFERENCES grocery_user (id) ON DELETE CASCADE,
+	ingredient_id int REFERENCES ingredient (id),
+	quantity      text,
+	unit          text
+);
+CREATE INDEX pantry_user_id_idx ON pantry (user_id);
+CREATE INDEX pantry_ingredient_id_idx ON pantry (ingredient_id);
@@ -0,0 +1,8 @@
+ALTER TABLE ad_ingredient
+	DROP COLUMN original_price,
+	DROP COLUMN deal_unit_price,
+	DROP COLUMN deal_unit,
+	DROP COLUMN deal_quantity,
+	DROP COLUMN deal_multi_buy_price,
+	DROP COLUMN deal_membership_required,
+	DROP COLUMN deal_bogo;
@@ -0,0 +1,8 @@
+ALTER TABLE ad_ingredient
+	ADD COLUMN original_price real,
+	ADD COLUMN deal_unit_price real,
+	ADD COLUMN deal_unit text,
+	ADD COLUMN deal_quantity int,
+	ADD COLUMN deal_multi_buy_price real,
+	ADD COLUMN deal_membership_required boolean,
+	ADD COLUMN deal_bogo boolean;
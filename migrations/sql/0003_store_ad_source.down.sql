@@ -0,0 +1,3 @@
+ALTER TABLE store
+	DROP COLUMN ad_source,
+	DROP COLUMN ad_source_path;
@@ -0,0 +1,3 @@
+ALTER TABLE store
+	ADD COLUMN ad_source text NOT NULL DEFAULT 'flipp',
+	ADD COLUMN ad_source_path text;
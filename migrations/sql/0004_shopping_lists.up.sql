@@ -0,0 +1,21 @@
+CREATE TABLE shopping_list (
+	id         serial PRIMARY KEY,
+	user_id    int NOT NULL REFERENCES grocery_user (id) ON DELETE CASCADE,
+	name       text NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX shopping_list_user_id_idx ON shopping_list (user_id, created_at DESC);
+
+CREATE TABLE shopping_list_item (
+	id               serial PRIMARY KEY,
+	shopping_list_id int NOT NULL REFERENCES shopping_list (id) ON DELETE CASCADE,
+	ingredient_id    int REFERENCES ingredient (id),
+	name             text NOT NULL,
+	amount           text,
+	unit             text,
+	store_id         int REFERENCES store (id) ON DELETE SET NULL,
+	price            real,
+	checked          boolean NOT NULL DEFAULT false
+);
+CREATE INDEX shopping_list_item_shopping_list_id_idx ON shopping_list_item (shopping_list_id);
+CREATE INDEX shopping_list_item_ingredient_id_idx ON shopping_list_item (ingredient_id);
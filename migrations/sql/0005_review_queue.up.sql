@@ -0,0 +1,16 @@
+-- ingredient_id has no foreign key: rejected ingredients are deleted but their review
+-- rows are kept as history
+CREATE TABLE review_item (
+	id            serial PRIMARY KEY,
+	kind          text NOT NULL CHECK (kind IN ('translation', 'ingredient')),
+	status        text NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+	name          text NOT NULL,
+	ingredient_id int NOT NULL,
+	confidence    real NOT NULL DEFAULT 0,
+	prompt        text NOT NULL DEFAULT '',
+	response      text NOT NULL DEFAULT '',
+	created_at    timestamptz NOT NULL DEFAULT now(),
+	reviewed_at   timestamptz
+);
+CREATE INDEX review_item_status_created_at_idx ON review_item (status, created_at);
+CREATE INDEX review_item_ingredient_id_idx ON review_item (ingredient_id);
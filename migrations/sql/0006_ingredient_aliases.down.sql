@@ -0,0 +1,2 @@
+DROP TABLE ingredient_merge;
+DROP TABLE ingredient_alias;
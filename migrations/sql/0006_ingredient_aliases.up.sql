@@ -0,0 +1,22 @@
+CREATE TABLE ingredient_alias (
+	id            serial PRIMARY KEY,
+	ingredient_id int NOT NULL REFERENCES ingredient (id) ON DELETE CASCADE,
+	alias         text NOT NULL UNIQUE
+);
+CREATE INDEX ingredient_alias_ingredient_id_idx ON ingredient_alias (ingredient_id);
+
+-- audit trail of merges; the duplicate no longer exists, so neither id is a foreign key
+CREATE TABLE ingredient_merge (
+	id                       serial PRIMARY KEY,
+	duplicate_id             int NOT NULL,
+	duplicate_name           text NOT NULL,
+	canonical_id             int NOT NULL,
+	canonical_name           text NOT NULL,
+	translations_moved       bigint NOT NULL DEFAULT 0,
+	recipe_ingredients_moved bigint NOT NULL DEFAULT 0,
+	pantry_items_moved       bigint NOT NULL DEFAULT 0,
+	ad_ingredients_moved     bigint NOT NULL DEFAULT 0,
+	aliases_moved            bigint NOT NULL DEFAULT 0,
+	merged_by                text NOT NULL DEFAULT '',
+	merged_at                timestamptz NOT NULL DEFAULT now()
+);
@@ -0,0 +1,4 @@
+ALTER TABLE ingredient
+	ADD COLUMN parent_id int REFERENCES ingredient (id) ON DELETE SET NULL,
+	ADD CONSTRAINT ingredient_parent_not_self CHECK (parent_id <> id);
+CREATE INDEX ingredient_parent_id_idx ON ingredient (parent_id);
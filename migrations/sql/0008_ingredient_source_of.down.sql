@@ -0,0 +1 @@
+ALTER TABLE ingredient DROP COLUMN source_of;
@@ -0,0 +1 @@
+ALTER TABLE ingredient ADD COLUMN source_of text[] NOT NULL DEFAULT '{}';
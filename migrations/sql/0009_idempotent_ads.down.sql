@@ -0,0 +1,4 @@
+CREATE INDEX ad_ingredient_ad_id_idx ON ad_ingredient (ad_id);
+DROP INDEX ad_ingredient_ad_id_name_key;
+DROP INDEX ad_store_id_window_flyer_key;
+ALTER TABLE ad DROP COLUMN source_flyer_id;
@@ -0,0 +1,13 @@
+-- '' when the ad source has no flyer ID, so it still takes part in the unique key
+ALTER TABLE ad ADD COLUMN source_flyer_id text NOT NULL DEFAULT '';
+
+-- keep the newest of any ads ingested more than once; their items go with them
+DELETE FROM ad a USING ad newer
+WHERE newer.store_id = a.store_id AND newer.sale_start = a.sale_start AND newer.sale_end = a.sale_end
+	AND newer.id > a.id;
+CREATE UNIQUE INDEX ad_store_id_window_flyer_key ON ad (store_id, sale_start, sale_end, source_flyer_id);
+
+DELETE FROM ad_ingredient ai USING ad_ingredient newer
+WHERE newer.ad_id = ai.ad_id AND newer.name = ai.name AND newer.id > ai.id;
+CREATE UNIQUE INDEX ad_ingredient_ad_id_name_key ON ad_ingredient (ad_id, name);
+DROP INDEX ad_ingredient_ad_id_idx;
@@ -0,0 +1,10 @@
+DROP INDEX store_coordinates_idx;
+ALTER TABLE store
+	DROP CONSTRAINT store_coordinates,
+	DROP COLUMN street,
+	DROP COLUMN city,
+	DROP COLUMN state,
+	DROP COLUMN postal_code,
+	DROP COLUMN country,
+	DROP COLUMN latitude,
+	DROP COLUMN longitude;
@@ -0,0 +1,14 @@
+ALTER TABLE store
+	ADD COLUMN street      text NOT NULL DEFAULT '',
+	ADD COLUMN city        text NOT NULL DEFAULT '',
+	ADD COLUMN state       text NOT NULL DEFAULT '',
+	ADD COLUMN postal_code text NOT NULL DEFAULT '',
+	ADD COLUMN country     text NOT NULL DEFAULT 'US',
+	ADD COLUMN latitude    double precision,
+	ADD COLUMN longitude   double precision,
+	ADD CONSTRAINT store_coordinates CHECK ((latitude IS NULL) = (longitude IS NULL));
+
+-- free-form locations used to end in a ZIP code
+UPDATE store SET postal_code = substring(location FROM '(\d{5})\s*$') WHERE location ~ '\d{5}\s*$';
+-- store searches select a latitude and longitude bounding box
+CREATE INDEX store_coordinates_idx ON store (latitude, longitude);
@@ -0,0 +1 @@
+ALTER TABLE ad_ingredient DROP COLUMN deal_second_item_percent_off;
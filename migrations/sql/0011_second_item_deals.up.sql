@@ -0,0 +1,5 @@
+ALTER TABLE ad_ingredient ADD COLUMN deal_second_item_percent_off real;
+
+-- "buy 1 get 1 50% off" was stored as a free BOGO, and "BOGO $4.99/lb" without its unit;
+-- these rows go back to how rows from before deal parsing read, and are parsed again when loaded
+UPDATE ad_ingredient SET deal_unit = NULL, deal_unit_price = NULL WHERE deal_bogo;
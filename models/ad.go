@@ -2,6 +2,9 @@ package models
 
 import (
 	"context"
+	"time"
+
+	"backend/main/deals"
 
 	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
@@ -9,7 +12,7 @@ import (
 
 // RecipeModel defines a struct for Recipe service
 type AdModel struct {
-	PostgreSQL *pgx.Conn
+	PostgreSQL DB
 	Logger     zap.Logger
 }
 
@@ -19,6 +22,8 @@ type Ad struct {
 	StoreID uint `json:"store_id"`
 	SaleStart  string  `json:"sale_start"`
 	SaleEnd   string `json:"sale_end"`
+	// SourceFlyerID is the ad source's ID for the flyer, empty when it has none
+	SourceFlyerID string `json:"source_flyer_id"`
 	Ingredient []AdIngredient 
 }
 
@@ -26,11 +31,13 @@ type AdIngredient struct {
 	ID    *uint `json:"id"`
 	IngredientID     uint `json:"ingredient_id"`
 	Price		   *float32 `json:"amount"`
+	OriginalPrice  *float32 `json:"original_price"`
 	Sale		   *string `json:"unit"`
 	Name 		 string `json:"name"`
+	Deal         deals.Deal `json:"deal"`
 }
 
-func NewAdModel(PostgreSQL *pgx.Conn, logger zap.Logger) *AdModel {
+func NewAdModel(PostgreSQL DB, logger zap.Logger) *AdModel {
 	return &AdModel{
 		PostgreSQL: PostgreSQL,
 		Logger:     logger,
@@ -59,67 +66,169 @@ func NewAdIngredient(id *uint, ingredient_id uint, price *float32, sale *string,
 	}
 }
 
-// CreateAd adds an ad to the database. This adds to the ad and ad_ingredient tables
-func (i *AdModel) CreateAd(ad Ad) error {
-	
+// CreateAd adds an ad to the database. This adds to the ad and ad_ingredient tables.
+// An ad is identified by its store, sale window and source flyer, so ingesting the same
+// flyer again updates the stored ad instead of duplicating it: items are matched by name,
+// new ones are added and ones no longer in the flyer are removed.
+func (i *AdModel) CreateAd(ctx context.Context, ad Ad) error {
+	tx, err := i.PostgreSQL.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
 	var adID int
-	err := i.PostgreSQL.QueryRow(context.Background(),
-		"INSERT INTO ad (store_id, sale_start, sale_end) VALUES ($1, $2, $3) RETURNING id;",
-		ad.StoreID, ad.SaleStart, ad.SaleEnd).Scan(&adID)
+	err = tx.QueryRow(ctx,
+		`INSERT INTO ad (store_id, sale_start, sale_end, source_flyer_id) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (store_id, sale_start, sale_end, source_flyer_id) DO UPDATE SET store_id = EXCLUDED.store_id
+		RETURNING id`,
+		ad.StoreID, ad.SaleStart, ad.SaleEnd, ad.SourceFlyerID).Scan(&adID)
 	if err != nil {
-		i.Logger.Error("Error adding recipe to database", zap.Error(err))
+		i.Logger.Error("Error adding ad to database", zap.Error(err))
 		return err
 	}
 
-	rows := make([][]interface{}, len(ad.Ingredient))
+	names := make([]string, len(ad.Ingredient))
 	ingredient_ids := make([]uint, len(ad.Ingredient))
+	batch := &pgx.Batch{}
 	for i, ingredient := range ad.Ingredient {
-		rows[i] = []interface{}{adID, ingredient.IngredientID, ingredient.Name, ingredient.Price, ingredient.Sale}
+		deal := ingredient.Deal
+		batch.Queue(`INSERT INTO ad_ingredient (ad_id, ingredient_id, name, price, original_price, sale,
+			deal_unit_price, deal_unit, deal_quantity, deal_multi_buy_price, deal_membership_required, deal_bogo,
+			deal_second_item_percent_off)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			ON CONFLICT (ad_id, name) DO UPDATE SET ingredient_id = EXCLUDED.ingredient_id, price = EXCLUDED.price,
+			original_price = EXCLUDED.original_price, sale = EXCLUDED.sale, deal_unit_price = EXCLUDED.deal_unit_price,
+			deal_unit = EXCLUDED.deal_unit, deal_quantity = EXCLUDED.deal_quantity, deal_multi_buy_price = EXCLUDED.deal_multi_buy_price,
+			deal_membership_required = EXCLUDED.deal_membership_required, deal_bogo = EXCLUDED.deal_bogo,
+			deal_second_item_percent_off = EXCLUDED.deal_second_item_percent_off`,
+			adID, ingredient.IngredientID, ingredient.Name, ingredient.Price, ingredient.OriginalPrice, ingredient.Sale,
+			deal.UnitPrice, deal.Unit, deal.Quantity, deal.MultiBuyPrice, deal.MembershipRequired, deal.BOGO,
+			deal.SecondItemPercentOff)
+		names[i] = ingredient.Name
 		ingredient_ids[i] = ingredient.IngredientID
 	}
 
-	_, err = i.PostgreSQL.CopyFrom(
-		context.Background(),
-		pgx.Identifier{"ad_ingredient"},
-		[]string{"ad_id", "ingredient_id", "name", "price", "sale"},
-		pgx.CopyFromRows(rows),
-	)
+	// items dropped from the flyer since it was last ingested
+	removed, err := tx.Exec(ctx, "DELETE FROM ad_ingredient WHERE ad_id = $1 AND NOT (name = ANY($2))", adID, names)
+	if err != nil {
+		i.Logger.Error("Error removing stale ad_ingredients", zap.Error(err), zap.Int("ad", adID))
+		return err
+	}
+	err = tx.SendBatch(ctx, batch).Close()
 	if err != nil {
 		i.Logger.Error("Error adding ad_ingredients to database", 
 		zap.Error(err), zap.Int("ad", adID),
 		zap.Any("ingredient_ids", ingredient_ids))
 		return err
 	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return err
+	}
 	
-	i.Logger.Info("Successfully added ad to database")
+	i.Logger.Info("Successfully added ad to database", zap.Int("ad", adID),
+		zap.Int("items", len(ad.Ingredient)), zap.Int64("removed", removed.RowsAffected()))
 	return nil
 
 }
 
 // GetRecentAd
-func (i * AdModel) GetRecentAd(storeID uint) (ad Ad, err error) {
+func (i * AdModel) GetRecentAd(ctx context.Context, storeID uint) (ad Ad, err error) {
 
-	err = i.PostgreSQL.QueryRow(context.Background(), 
-		"SELECT id, store_id, sale_start::text, sale_end::text FROM ad WHERE store_id = $1 order by sale_start desc limit 1", storeID).Scan(&ad.ID, &ad.StoreID, &ad.SaleStart, &ad.SaleEnd)
+	err = i.PostgreSQL.QueryRow(ctx, 
+		"SELECT "+adColumns+" FROM ad WHERE store_id = $1 order by sale_start desc, id desc limit 1", storeID).Scan(&ad.ID, &ad.StoreID, &ad.SaleStart, &ad.SaleEnd, &ad.SourceFlyerID)
 	if err != nil {
 		i.Logger.Error("Error getting ad by ID", zap.Error(err))
 		return Ad{}, err
 	}
-	rows, err := i.PostgreSQL.Query(context.Background(),
-		"SELECT ingredient_id, name, price, sale FROM ad_ingredient WHERE ad_id = $1", ad.ID)
+	ads := []Ad{ad}
+	err = i.loadAdIngredients(ctx, ads)
 	if err != nil {
-		i.Logger.Error("Error getting ad ingredients", zap.Error(err))
 		return Ad{}, err
 	}
+	return ads[0], nil
+}
+
+// GetActiveAds returns every ad of a store whose sale window includes day, newest first.
+// A store can have several at once, e.g. a weekly and a monthly flyer.
+func (i *AdModel) GetActiveAds(ctx context.Context, storeID uint, day time.Time) ([]Ad, error) {
+	rows, err := i.PostgreSQL.Query(ctx,
+		"SELECT "+adColumns+" FROM ad WHERE store_id = $1 AND sale_start <= $2::date AND sale_end >= $2::date ORDER BY sale_start DESC, id DESC",
+		storeID, day.Format(time.DateOnly))
+	if err != nil {
+		i.Logger.Error("Error getting active ads", zap.Error(err))
+		return nil, err
+	}
+	ads, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (ad Ad, err error) {
+		err = row.Scan(&ad.ID, &ad.StoreID, &ad.SaleStart, &ad.SaleEnd, &ad.SourceFlyerID)
+		return ad, err
+	})
+	if err != nil {
+		i.Logger.Error("Error scanning active ads", zap.Error(err))
+		return nil, err
+	}
+	err = i.loadAdIngredients(ctx, ads)
+	if err != nil {
+		return nil, err
+	}
+	return ads, nil
+}
+
+const adColumns = "id, store_id, sale_start::text, sale_end::text, source_flyer_id"
+
+// loadAdIngredients fills in the items of ads
+func (i *AdModel) loadAdIngredients(ctx context.Context, ads []Ad) error {
+	if len(ads) == 0 {
+		return nil
+	}
+	byID := make(map[uint]*Ad, len(ads))
+	ids := make([]uint, len(ads))
+	for n := range ads {
+		byID[*ads[n].ID] = &ads[n]
+		ids[n] = *ads[n].ID
+	}
+	rows, err := i.PostgreSQL.Query(ctx,
+		`SELECT ad_id, ingredient_id, name, price, original_price, sale,
+		deal_unit_price, deal_unit, deal_quantity, deal_multi_buy_price, deal_membership_required, deal_bogo,
+		deal_second_item_percent_off
+		FROM ad_ingredient WHERE ad_id = ANY($1) ORDER BY id`, ids)
+	if err != nil {
+		i.Logger.Error("Error getting ad ingredients", zap.Error(err))
+		return err
+	}
 	defer rows.Close()
 	for rows.Next() {
+		var adID uint
 		var ai AdIngredient
-		err := rows.Scan(&ai.IngredientID, &ai.Name, &ai.Price, &ai.Sale)
+		var dealUnit *string
+		var dealQuantity *int
+		var dealMembership, dealBOGO *bool
+		err := rows.Scan(&adID, &ai.IngredientID, &ai.Name, &ai.Price, &ai.OriginalPrice, &ai.Sale,
+			&ai.Deal.UnitPrice, &dealUnit, &dealQuantity, &ai.Deal.MultiBuyPrice, &dealMembership, &dealBOGO,
+			&ai.Deal.SecondItemPercentOff)
 		if err != nil {
 			i.Logger.Error("Error scanning row", zap.Error(err))
-			return Ad{}, err
+			return err
 		}
+		if dealUnit == nil {
+			// rows written before deals were parsed, or whose deal is parsed again
+			ai.Deal = deals.Parse(ai.Sale, ai.Price)
+		} else {
+			ai.Deal.Unit = *dealUnit
+			ai.Deal.Quantity = derefOr(dealQuantity, 1)
+			ai.Deal.MembershipRequired = derefOr(dealMembership, false)
+			ai.Deal.BOGO = derefOr(dealBOGO, false)
+		}
+		ad := byID[adID]
 		ad.Ingredient = append(ad.Ingredient, ai)
 	}
-	return ad, nil
+	return rows.Err()
+}
+
+func derefOr[T any](p *T, fallback T) T {
+	if p == nil {
+		return fallback
+	}
+	return *p
 }
@@ -2,8 +2,7 @@ package models
 
 import (
 	"context"
-	"fmt"
-	"strconv"
+	"errors"
 	"unicode"
 
 	"backend/main/config"
@@ -12,6 +11,10 @@ import (
 	"go.uber.org/zap"
 )
 
+// ErrReplacementNotFound is returned when an ingredient is deleted in favor of a
+// replacement that does not exist
+var ErrReplacementNotFound = errors.New("replacement ingredient not found")
+
 type FoodType int
 
 const (
@@ -56,7 +59,7 @@ func (ff FoodType) String() string {
 
 // IngredientModel defines a struct for ingredient service
 type IngredientModel struct {
-	PostgreSQL *pgx.Conn
+	PostgreSQL DB
 	Logger    zap.Logger
 }
 
@@ -67,9 +70,11 @@ type Ingredient struct {
 	Season   *[]int    `json:"season"`
 	Type     FoodType  `json:"type"`
 	SourceOf *[]string `json:"sourceof"`
+	// ParentID is the more general ingredient this one rolls up to, e.g. cheddar for sharp cheddar
+	ParentID *uint `json:"parent_id"`
 }
 
-func NewIngredientModel(PostgreSQL *pgx.Conn, logger zap.Logger) *IngredientModel {
+func NewIngredientModel(PostgreSQL DB, logger zap.Logger) *IngredientModel {
 	return &IngredientModel{
 		PostgreSQL: PostgreSQL,
 		Logger: logger,
@@ -89,6 +94,14 @@ func NewIngredient(id *uint, name string, season *[]int, foodtype FoodType, sour
 
 // ToFoodType converts a string to a FoodType
 func (i *IngredientModel) ToFoodType(s string) FoodType {
+	return ParseFoodType(s)
+}
+
+// ParseFoodType converts a food type display name such as "Vegetable" to a FoodType
+func ParseFoodType(s string) FoodType {
+	if s == "" {
+		return Other
+	}
 	r := []rune(s)
     r[0] = unicode.ToUpper(r[0])
     s = string(r)
@@ -104,59 +117,84 @@ func (i *IngredientModel) ToFoodType(s string) FoodType {
 }
 
 func (i *IngredientModel) GetFoodTypes() []string {
-	var foodTypes []string
-	for _, v := range foodTypeName {
-		foodTypes = append(foodTypes, v)
+	return FoodTypeNames()
+}
+
+// FoodTypeNames returns the display name of every FoodType in declaration order
+func FoodTypeNames() []string {
+	foodTypes := make([]string, 0, len(foodTypeName))
+	for ft := Fruit; ft <= Other; ft++ {
+		foodTypes = append(foodTypes, foodTypeName[ft])
 	}
 	return foodTypes
 }
 
-// GetIngredientByID to find ingredient by ID from database
-func (i *IngredientModel) GetIngredientByID(id uint) (Ingredient, error) {
+// ingredientColumns is the column list scanIngredient expects
+const ingredientColumns = "id, name, type, season, source_of, parent_id"
+
+// scanIngredient scans a row selected with ingredientColumns
+func (i *IngredientModel) scanIngredient(row pgx.Row) (Ingredient, error) {
 	var ingredient Ingredient
 	var typeStr string
-	err := i.PostgreSQL.QueryRow(context.Background(), 
-	"SELECT * FROM ingredient WHERE id = $1", id).Scan(&ingredient.ID, &ingredient.Name, &typeStr, &ingredient.Season)
+	err := row.Scan(&ingredient.ID, &ingredient.Name, &typeStr, &ingredient.Season, &ingredient.SourceOf, &ingredient.ParentID)
 	if err != nil {
-		config.Logger.Error("Error processing query", zap.Error(err), zap.String("function", "GetIngredientByID"))
 		return ingredient, err
 	}
 	ingredient.Type = i.ToFoodType(typeStr)
 	return ingredient, nil
 }
 
+// GetIngredientByID to find ingredient by ID from database
+func (i *IngredientModel) GetIngredientByID(ctx context.Context, id uint) (Ingredient, error) {
+	ingredient, err := i.scanIngredient(i.PostgreSQL.QueryRow(ctx,
+		"SELECT "+ingredientColumns+" FROM ingredient WHERE id = $1", id))
+	if err != nil {
+		config.Logger.Error("Error processing query", zap.Error(err), zap.String("function", "GetIngredientByID"))
+		return ingredient, err
+	}
+	return ingredient, nil
+}
+
 // GetAllIngredients retrieves all ingredients from database
-func (i *IngredientModel) GetAllIngredients() ([]Ingredient, error) {
+func (i *IngredientModel) GetAllIngredients(ctx context.Context) ([]Ingredient, error) {
+	return i.queryIngredients(ctx, "GetAllIngredients", "SELECT "+ingredientColumns+" FROM ingredient")
+}
+
+// GetIngredientsInSeason retrieves the ingredients whose season includes month, a
+// northern hemisphere month number from 1 to 12, ordered by name
+func (i *IngredientModel) GetIngredientsInSeason(ctx context.Context, month int) ([]Ingredient, error) {
+	return i.queryIngredients(ctx, "GetIngredientsInSeason",
+		"SELECT "+ingredientColumns+" FROM ingredient WHERE $1 = ANY(season) ORDER BY name", month)
+}
+
+// queryIngredients runs a query selecting ingredientColumns and scans every row
+func (i *IngredientModel) queryIngredients(ctx context.Context, function string, query string, args ...any) ([]Ingredient, error) {
 	var ingredients []Ingredient
-	rows, err := i.PostgreSQL.Query(context.Background(),
-	"SELECT * FROM ingredient")
+	rows, err := i.PostgreSQL.Query(ctx, query, args...)
 	if err != nil {
-		config.Logger.Error("Error getting Postgres client", zap.Error(err), zap.String("function", "GetAllIngredients"))
+		config.Logger.Error("Error querying ingredients", zap.Error(err), zap.String("function", function))
 		return nil, err
 	}
 	defer rows.Close()
 	for rows.Next() {
-		var ing Ingredient
-		var typeStr string
-		err := rows.Scan(&ing.ID, &ing.Name, &typeStr, &ing.Season,)
+		ing, err := i.scanIngredient(rows)
 		if err != nil {
-			config.Logger.Error("Error scanning row", zap.Error(err), zap.String("function", "GetAllIngredients"))
+			config.Logger.Error("Error scanning row", zap.Error(err), zap.String("function", function))
 			return nil, err
 		}
-		ing.Type = i.ToFoodType(typeStr)
 		ingredients = append(ingredients, ing)
 	}
 	if err := rows.Err(); err != nil {
-		config.Logger.Error("Error processing rows", zap.Error(err), zap.String("function", "GetAllIngredients"))
+		config.Logger.Error("Error processing rows", zap.Error(err), zap.String("function", function))
 		return nil, err
 	}
 	return ingredients, nil
 }
 
 // GetAllIngredientsNameID retrieves all ingredient ids and names from database
-func (i *IngredientModel) GetAllIngredientsNameID() (map[string]uint, error) {
+func (i *IngredientModel) GetAllIngredientsNameID(ctx context.Context) (map[string]uint, error) {
 	var mapIngredients = make(map[string]uint)
-	rows, err := i.PostgreSQL.Query(context.Background(),
+	rows, err := i.PostgreSQL.Query(ctx,
 	"SELECT id, name FROM ingredient")
 	if err != nil {
 		config.Logger.Error("Error getting Firestore client", zap.Error(err), zap.String("function", "GetAllIngredientsNameID"))
@@ -182,14 +220,25 @@ func (i *IngredientModel) GetAllIngredientsNameID() (map[string]uint, error) {
 	return mapIngredients, nil
 }
 
-// CreateIngredient creates a new ingredient in the database and returns the ID
-func (i *IngredientModel) CreateIngredient(ingredient Ingredient) (id uint, err error) {
-	if ingredient.Season == nil {
-		ingredient.Season = &[]int{}
+// ingredientArgs returns the name, season, type and source_of query arguments for an
+// ingredient, storing a missing season or source_of as an empty array
+func ingredientArgs(ingredient Ingredient) []any {
+	season := ingredient.Season
+	if season == nil {
+		season = &[]int{}
+	}
+	sourceOf := ingredient.SourceOf
+	if sourceOf == nil {
+		sourceOf = &[]string{}
 	}
-	err = i.PostgreSQL.QueryRow(context.Background(),
-		"INSERT INTO ingredient (name, season, type) VALUES ($1, $2, $3) RETURNING id",
-		ingredient.Name, ingredient.Season, ingredient.Type.String()).Scan(&id)
+	return []any{ingredient.Name, *season, ingredient.Type.String(), *sourceOf}
+}
+
+// CreateIngredient creates a new ingredient in the database and returns the ID
+func (i *IngredientModel) CreateIngredient(ctx context.Context, ingredient Ingredient) (id uint, err error) {
+	err = i.PostgreSQL.QueryRow(ctx,
+		"INSERT INTO ingredient (name, season, type, source_of) VALUES ($1, $2, $3, $4) RETURNING id",
+		ingredientArgs(ingredient)...).Scan(&id)
 	if err != nil {
 		config.Logger.Error("Error creating ingredient in database", zap.Error(err), zap.String("function", "CreateIngredient"))
 		return 0, err
@@ -197,73 +246,244 @@ func (i *IngredientModel) CreateIngredient(ingredient Ingredient) (id uint, err
 	return id, nil
 }
 
-// CreateIngredients creates a new ingredients in the database
-func (i *IngredientModel) CreateIngredients(ingredients []Ingredient) error {
-	for _, ingredient := range ingredients {
-		if ingredient.Season == nil {
-			ingredient.Season = &[]int{}
-		} 
+// CreateIngredientIfMissing adds an ingredient unless one with its name exists and returns
+// the ID stored under the name, and whether this call added it. Concurrent callers adding
+// the same name all get the one row instead of a unique violation.
+func (i *IngredientModel) CreateIngredientIfMissing(ctx context.Context, ingredient Ingredient) (id uint, created bool, err error) {
+	err = i.PostgreSQL.QueryRow(ctx,
+		`INSERT INTO ingredient (name, season, type, source_of) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (name) DO NOTHING RETURNING id`,
+		ingredientArgs(ingredient)...).Scan(&id)
+	if err == nil {
+		return id, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		config.Logger.Error("Error creating ingredient in database", zap.Error(err), zap.String("function", "CreateIngredientIfMissing"))
+		return 0, false, err
+	}
+	err = i.PostgreSQL.QueryRow(ctx, "SELECT id FROM ingredient WHERE name = $1", ingredient.Name).Scan(&id)
+	if err != nil {
+		config.Logger.Error("Error getting existing ingredient", zap.Error(err), zap.String("function", "CreateIngredientIfMissing"))
+		return 0, false, err
+	}
+	return id, false, nil
+}
+
+// CreateIngredients upserts ingredients by name in one transaction, updating the season,
+// type and source_of of names that already exist, and returns their IDs in order
+func (i *IngredientModel) CreateIngredients(ctx context.Context, ingredients []Ingredient) ([]uint, error) {
+	tx, err := i.PostgreSQL.Begin(ctx)
+	if err != nil {
+		config.Logger.Error("Error starting transaction", zap.Error(err), zap.String("function", "CreateIngredients"))
+		return nil, err
 	}
-	rows := [][]interface{}{}
+	defer tx.Rollback(ctx)
+
+	batch := &pgx.Batch{}
 	for _, ingredient := range ingredients {
-		rows = append(rows, []interface{}{ingredient.Name, ingredient.Season, ingredient.Type.String()})
+		batch.Queue(`INSERT INTO ingredient (name, season, type, source_of) VALUES ($1, $2, $3, $4)
+			ON CONFLICT (name) DO UPDATE SET season = EXCLUDED.season, type = EXCLUDED.type, source_of = EXCLUDED.source_of
+			RETURNING id`, ingredientArgs(ingredient)...)
+	}
+	results := tx.SendBatch(ctx, batch)
+	ids := make([]uint, 0, len(ingredients))
+	for range ingredients {
+		var id uint
+		if err := results.QueryRow().Scan(&id); err != nil {
+			results.Close()
+			config.Logger.Error("Error upserting ingredients", zap.Error(err), zap.String("function", "CreateIngredients"))
+			return nil, err
+		}
+		ids = append(ids, id)
 	}
+	if err := results.Close(); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// UpdateIngredient updates an existing ingredient in the database, returning
+// pgx.ErrNoRows if it does not exist
+func (i *IngredientModel) UpdateIngredient(ctx context.Context, ingredient Ingredient) error {
+	return i.UpdateIngredients(ctx, []Ingredient{ingredient})
+}
 
-	copyCount, err := i.PostgreSQL.CopyFrom(
-		context.Background(),
-		pgx.Identifier{"ingredient"},
-		[]string{"name", "season", "type"},
-		pgx.CopyFromRows(rows),
-	)
+// UpdateIngredients updates existing ingredients in one transaction. Nothing is updated
+// and pgx.ErrNoRows is returned if any of them does not exist.
+func (i *IngredientModel) UpdateIngredients(ctx context.Context, ingredients []Ingredient) error {
+	tx, err := i.PostgreSQL.Begin(ctx)
 	if err != nil {
-		config.Logger.Error("Error creating ingredients in database", zap.Error(err), zap.String("function", "CreateIngredients"))
+		config.Logger.Error("Error starting transaction", zap.Error(err), zap.String("function", "UpdateIngredients"))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	batch := &pgx.Batch{}
+	for _, ingredient := range ingredients {
+		if ingredient.ID == nil {
+			return pgx.ErrNoRows
+		}
+		args := append([]any{*ingredient.ID}, ingredientArgs(ingredient)...)
+		batch.Queue("UPDATE ingredient SET name = $2, season = $3, type = $4, source_of = $5 WHERE id = $1", args...)
+	}
+	results := tx.SendBatch(ctx, batch)
+	for range ingredients {
+		tag, err := results.Exec()
+		if err != nil {
+			results.Close()
+			config.Logger.Error("Error updating ingredient in database", zap.Error(err), zap.String("function", "UpdateIngredients"))
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			results.Close()
+			return pgx.ErrNoRows
+		}
+	}
+	if err := results.Close(); err != nil {
 		return err
 	}
-	fmt.Println(copyCount)
-	return nil
+	return tx.Commit(ctx)
 }
 
-// UpdateIngredient updates an existing ingredient in the database
-func (i *IngredientModel) UpdateIngredient(ingredient Ingredient) error {
-	seasonStr := fmt.Sprintf("%v", *ingredient.Season)
-	_, err := i.PostgreSQL.Query(context.Background(), 
-	"UPDATE ingredients SET name =" + ingredient.Name + ", season =" + seasonStr + ", type =" + 
-	ingredient.Type.String() + " WHERE id =" + strconv.FormatUint(uint64(*ingredient.ID), 10),)
+// ingredientReferences repoint every row that references ingredient $1 onto ingredient $2,
+// keyed by the table they update. Merging, replacing and rejecting an ingredient all run
+// them, so a table that references ingredients only needs adding here. Review rows of kind
+// ingredient keep the old ID as history.
+var ingredientReferences = []struct {
+	table string
+	query string
+}{
+	{"translation", "UPDATE translation SET ingredient_id = $2 WHERE ingredient_id = $1"},
+	{"recipe_ingredient", "UPDATE recipe_ingredient SET ingredient_id = $2 WHERE ingredient_id = $1"},
+	{"pantry", "UPDATE pantry SET ingredient_id = $2 WHERE ingredient_id = $1"},
+	{"ad_ingredient", "UPDATE ad_ingredient SET ingredient_id = $2 WHERE ingredient_id = $1"},
+	{"shopping_list_item", "UPDATE shopping_list_item SET ingredient_id = $2 WHERE ingredient_id = $1"},
+	{"ingredient_alias", "UPDATE ingredient_alias SET ingredient_id = $2 WHERE ingredient_id = $1"},
+	{"review_item", "UPDATE review_item SET ingredient_id = $2 WHERE ingredient_id = $1 AND kind = 'translation'"},
+}
+
+// reassignIngredient runs ingredientReferences from one ingredient onto another, moves the
+// old ingredient's children, and returns the number of rows moved per table
+func reassignIngredient(ctx context.Context, tx pgx.Tx, from uint, to uint) (map[string]int64, error) {
+	moved := make(map[string]int64, len(ingredientReferences)+1)
+	for _, ref := range ingredientReferences {
+		tag, err := tx.Exec(ctx, ref.query, from, to)
+		if err != nil {
+			return nil, err
+		}
+		moved[ref.table] = tag.RowsAffected()
+	}
+	children, err := reparentChildren(ctx, tx, from, to)
 	if err != nil {
-		config.Logger.Error("Error updating ingredient to database", zap.Error(err), zap.String("function", "UpdateIngredient"))
-		return err
+		return nil, err
 	}
-	return nil
+	moved["ingredient"] = children
+	return moved, nil
 }
 
-// UpdateIngredients updates existing ingredients in the database
-func (i *IngredientModel) UpdateIngredients(ingredients []Ingredient) error {
-	query := ""
-	for _, ingredient := range ingredients {
-		seasonStr := fmt.Sprintf("%v", *ingredient.Season)
-		query += "UPDATE ingredients SET name =" + ingredient.Name + ", season =" + seasonStr + 
-		", type =" + ingredient.Type.String() + " WHERE id =" + strconv.FormatUint(uint64(*ingredient.ID), 10)
+// reparentChildren moves the children of a removed ingredient to their new parent and
+// returns how many moved. The ancestry check runs in the caller's transaction under the
+// hierarchy lock, so the hierarchy cannot change between the check and the update.
+func reparentChildren(ctx context.Context, tx pgx.Tx, removed uint, replacement uint) (int64, error) {
+	if err := lockIngredientHierarchy(ctx, tx); err != nil {
+		return 0, err
 	}
-	_, err := i.PostgreSQL.Query(context.Background(), query)
+	var descendant bool
+	var removedParent *uint
+	err := tx.QueryRow(ctx,
+		`WITH RECURSIVE ancestor(id, parent_id) AS (
+			SELECT id, parent_id FROM ingredient WHERE id = $2
+			UNION
+			SELECT ing.id, ing.parent_id FROM ingredient ing JOIN ancestor a ON ing.id = a.parent_id
+		)
+		SELECT EXISTS (SELECT 1 FROM ancestor WHERE id = $1),
+			(SELECT parent_id FROM ingredient WHERE id = $1)`, removed, replacement).Scan(&descendant, &removedParent)
 	if err != nil {
-		config.Logger.Error("Error updating ingredient to database", zap.Error(err), zap.String("function", "UpdateIngredients"))
-		return err
+		return 0, err
 	}
-	return nil
+	tag, err := tx.Exec(ctx, "UPDATE ingredient SET parent_id = $2 WHERE parent_id = $1",
+		removed, childrenParent(removedParent, replacement, descendant))
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// childrenParent is the parent a removed ingredient's children move under. They normally
+// move under the replacement, but when the replacement descends from the removed
+// ingredient that would close a cycle, so they move up to the removed ingredient's own
+// parent instead, which takes the replacement along with them when it is a direct child.
+func childrenParent(removedParent *uint, replacement uint, descendant bool) *uint {
+	if descendant {
+		return removedParent
+	}
+	return &replacement
 }
 
-// DeleteIngredient deletes an existing ingredient from the database
-func (i *IngredientModel) DeleteIngredient(id uint) error {
-	client, err := config.FirebaseApp.Firestore(context.Background())
+// DeleteIngredient deletes an ingredient along with its translations and aliases. When
+// replacementID is nil it returns ErrIngredientInUse if recipes, ads, pantries or
+// shopping lists still reference the ingredient; otherwise those rows, translations and
+// aliases are reassigned to the replacement first. It returns pgx.ErrNoRows if the
+// ingredient does not exist and ErrReplacementNotFound if the replacement does not.
+func (i *IngredientModel) DeleteIngredient(ctx context.Context, id uint, replacementID *uint) error {
+	if replacementID != nil && *replacementID == id {
+		return ErrInvalidMerge
+	}
+	tx, err := i.PostgreSQL.Begin(ctx)
 	if err != nil {
-		config.Logger.Error("Error getting Firestore client", zap.Error(err), zap.String("function", "DeleteIngredient"))
+		config.Logger.Error("Error starting transaction", zap.Error(err), zap.String("function", "DeleteIngredient"))
 		return err
 	}
-	idStr := strconv.FormatUint(uint64(id), 10)
-	_, err = client.Collection("ingredients").Doc(idStr).Delete(context.Background())
-	if err != nil {
-		config.Logger.Error("Error deleting document", zap.Error(err), zap.String("function", "DeleteIngredient"))
+	defer tx.Rollback(ctx)
+
+	var locked uint
+	if err := tx.QueryRow(ctx, "SELECT id FROM ingredient WHERE id = $1 FOR UPDATE", id).Scan(&locked); err != nil {
+		return err
+	}
+
+	if replacementID == nil {
+		var inUse bool
+		err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM recipe_ingredient WHERE ingredient_id = $1)
+			OR EXISTS (SELECT 1 FROM ad_ingredient WHERE ingredient_id = $1)
+			OR EXISTS (SELECT 1 FROM pantry WHERE ingredient_id = $1)
+			OR EXISTS (SELECT 1 FROM shopping_list_item WHERE ingredient_id = $1)`, id).Scan(&inUse)
+		if err != nil {
+			config.Logger.Error("Error checking ingredient references", zap.Error(err), zap.String("function", "DeleteIngredient"))
+			return err
+		}
+		if inUse {
+			return ErrIngredientInUse
+		}
+		for _, query := range []string{
+			"DELETE FROM translation WHERE ingredient_id = $1",
+			"DELETE FROM ingredient_alias WHERE ingredient_id = $1",
+			"UPDATE ingredient SET parent_id = NULL WHERE parent_id = $1",
+		} {
+			if _, err := tx.Exec(ctx, query, id); err != nil {
+				config.Logger.Error("Error clearing ingredient references", zap.Error(err), zap.String("function", "DeleteIngredient"))
+				return err
+			}
+		}
+	} else {
+		err = tx.QueryRow(ctx, "SELECT id FROM ingredient WHERE id = $1 FOR UPDATE", *replacementID).Scan(&locked)
+		if err == pgx.ErrNoRows {
+			return ErrReplacementNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := reassignIngredient(ctx, tx, id, *replacementID); err != nil {
+			config.Logger.Error("Error reassigning ingredient references", zap.Error(err), zap.String("function", "DeleteIngredient"))
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM ingredient WHERE id = $1", id); err != nil {
+		config.Logger.Error("Error deleting ingredient", zap.Error(err), zap.String("function", "DeleteIngredient"))
 		return err
 	}
-	return nil
+	return tx.Commit(ctx)
 }
@@ -0,0 +1,220 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"backend/main/config"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// ErrInvalidMerge is returned when an ingredient is merged into itself
+var ErrInvalidMerge = errors.New("cannot merge an ingredient into itself")
+
+// IngredientAlias is another name for an ingredient, e.g. "scallions" for "green onions"
+type IngredientAlias struct {
+	IngredientID uint   `json:"ingredient_id"`
+	Alias        string `json:"alias"`
+}
+
+// IngredientMerge is the audit record of one duplicate ingredient merged into a canonical one
+type IngredientMerge struct {
+	ID                     *uint     `json:"id"`
+	DuplicateID            uint      `json:"duplicate_id"`
+	DuplicateName          string    `json:"duplicate_name"`
+	CanonicalID            uint      `json:"canonical_id"`
+	CanonicalName          string    `json:"canonical_name"`
+	TranslationsMoved      int64     `json:"translations_moved"`
+	RecipeIngredientsMoved int64     `json:"recipe_ingredients_moved"`
+	PantryItemsMoved       int64     `json:"pantry_items_moved"`
+	AdIngredientsMoved     int64     `json:"ad_ingredients_moved"`
+	AliasesMoved           int64     `json:"aliases_moved"`
+	MergedBy               string    `json:"merged_by"`
+	MergedAt               time.Time `json:"merged_at"`
+}
+
+// normalizeAlias lowercases and collapses whitespace so aliases compare like ingredient names
+func normalizeAlias(alias string) string {
+	return strings.Join(strings.Fields(strings.ToLower(alias)), " ")
+}
+
+// GetAliases lists the aliases of an ingredient
+func (i *IngredientModel) GetAliases(ctx context.Context, ingredientID uint) ([]string, error) {
+	rows, err := i.PostgreSQL.Query(ctx,
+		"SELECT alias FROM ingredient_alias WHERE ingredient_id = $1 ORDER BY alias", ingredientID)
+	if err != nil {
+		config.Logger.Error("Error getting ingredient aliases", zap.Error(err), zap.String("function", "GetAliases"))
+		return nil, err
+	}
+	aliases, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		config.Logger.Error("Error scanning ingredient aliases", zap.Error(err), zap.String("function", "GetAliases"))
+		return nil, err
+	}
+	return aliases, nil
+}
+
+// GetAllAliases maps every alias onto its ingredient ID
+func (i *IngredientModel) GetAllAliases(ctx context.Context) (map[string]uint, error) {
+	aliases := make(map[string]uint)
+	rows, err := i.PostgreSQL.Query(ctx, "SELECT alias, ingredient_id FROM ingredient_alias")
+	if err != nil {
+		config.Logger.Error("Error getting ingredient aliases", zap.Error(err), zap.String("function", "GetAllAliases"))
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var alias string
+		var id uint
+		if err := rows.Scan(&alias, &id); err != nil {
+			config.Logger.Error("Error scanning row", zap.Error(err), zap.String("function", "GetAllAliases"))
+			return nil, err
+		}
+		aliases[alias] = id
+	}
+	if err := rows.Err(); err != nil {
+		config.Logger.Error("Error processing rows", zap.Error(err), zap.String("function", "GetAllAliases"))
+		return nil, err
+	}
+	return aliases, nil
+}
+
+// AddAlias adds another name for an ingredient. Aliases are unique across all ingredients.
+func (i *IngredientModel) AddAlias(ctx context.Context, ingredientID uint, alias string) error {
+	_, err := i.PostgreSQL.Exec(ctx,
+		"INSERT INTO ingredient_alias (ingredient_id, alias) VALUES ($1, $2)", ingredientID, normalizeAlias(alias))
+	if err != nil {
+		config.Logger.Error("Error adding ingredient alias", zap.Error(err), zap.String("function", "AddAlias"))
+		return err
+	}
+	return nil
+}
+
+// DeleteAlias removes an alias from an ingredient, returning pgx.ErrNoRows if it does not exist
+func (i *IngredientModel) DeleteAlias(ctx context.Context, ingredientID uint, alias string) error {
+	tag, err := i.PostgreSQL.Exec(ctx,
+		"DELETE FROM ingredient_alias WHERE ingredient_id = $1 AND alias = $2", ingredientID, normalizeAlias(alias))
+	if err != nil {
+		config.Logger.Error("Error deleting ingredient alias", zap.Error(err), zap.String("function", "DeleteAlias"))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// ResolveIngredient finds an ingredient ID by name or alias
+func (i *IngredientModel) ResolveIngredient(ctx context.Context, name string) (id uint, ok bool, err error) {
+	err = i.PostgreSQL.QueryRow(ctx,
+		`SELECT id FROM ingredient WHERE lower(name) = $1
+		UNION ALL SELECT ingredient_id FROM ingredient_alias WHERE alias = $1
+		LIMIT 1`, normalizeAlias(name)).Scan(&id)
+	if err == pgx.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		config.Logger.Error("Error resolving ingredient", zap.Error(err), zap.String("function", "ResolveIngredient"))
+		return 0, false, err
+	}
+	return id, true, nil
+}
+
+// MergeIngredients repoints every row in ingredientReferences from the duplicate onto the
+// canonical ingredient, moves its children, keeps the duplicate's name as an alias, deletes
+// the duplicate and records the merge, all in one transaction
+func (i *IngredientModel) MergeIngredients(ctx context.Context, duplicateID uint, canonicalID uint, mergedBy string) (IngredientMerge, error) {
+	merge := IngredientMerge{DuplicateID: duplicateID, CanonicalID: canonicalID, MergedBy: mergedBy}
+	if duplicateID == canonicalID {
+		return merge, ErrInvalidMerge
+	}
+	tx, err := i.PostgreSQL.Begin(ctx)
+	if err != nil {
+		config.Logger.Error("Error starting transaction", zap.Error(err), zap.String("function", "MergeIngredients"))
+		return merge, err
+	}
+	defer tx.Rollback(ctx)
+
+	// lock both rows so concurrent merges of the same ingredients serialize
+	for _, target := range []struct {
+		id   uint
+		name *string
+	}{{duplicateID, &merge.DuplicateName}, {canonicalID, &merge.CanonicalName}} {
+		err = tx.QueryRow(ctx, "SELECT name FROM ingredient WHERE id = $1 FOR UPDATE", target.id).Scan(target.name)
+		if err != nil {
+			return merge, err
+		}
+	}
+
+	moved, err := reassignIngredient(ctx, tx, duplicateID, canonicalID)
+	if err != nil {
+		config.Logger.Error("Error repointing ingredient rows", zap.Error(err), zap.String("function", "MergeIngredients"))
+		return merge, err
+	}
+	merge.TranslationsMoved = moved["translation"]
+	merge.RecipeIngredientsMoved = moved["recipe_ingredient"]
+	merge.PantryItemsMoved = moved["pantry"]
+	merge.AdIngredientsMoved = moved["ad_ingredient"]
+	merge.AliasesMoved = moved["ingredient_alias"]
+
+	_, err = tx.Exec(ctx,
+		"INSERT INTO ingredient_alias (ingredient_id, alias) VALUES ($1, $2) ON CONFLICT (alias) DO NOTHING",
+		canonicalID, normalizeAlias(merge.DuplicateName))
+	if err != nil {
+		return merge, err
+	}
+	if _, err = tx.Exec(ctx, "DELETE FROM ingredient WHERE id = $1", duplicateID); err != nil {
+		config.Logger.Error("Error deleting duplicate ingredient", zap.Error(err), zap.String("function", "MergeIngredients"))
+		return merge, err
+	}
+
+	err = tx.QueryRow(ctx,
+		`INSERT INTO ingredient_merge (duplicate_id, duplicate_name, canonical_id, canonical_name, translations_moved,
+			recipe_ingredients_moved, pantry_items_moved, ad_ingredients_moved, aliases_moved, merged_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id, merged_at`,
+		merge.DuplicateID, merge.DuplicateName, merge.CanonicalID, merge.CanonicalName, merge.TranslationsMoved,
+		merge.RecipeIngredientsMoved, merge.PantryItemsMoved, merge.AdIngredientsMoved, merge.AliasesMoved,
+		merge.MergedBy).Scan(&merge.ID, &merge.MergedAt)
+	if err != nil {
+		config.Logger.Error("Error recording ingredient merge", zap.Error(err), zap.String("function", "MergeIngredients"))
+		return merge, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return merge, err
+	}
+	config.Logger.Info("Merged ingredients", zap.Uint("duplicate_id", duplicateID), zap.Uint("canonical_id", canonicalID))
+	return merge, nil
+}
+
+// GetIngredientMerges lists the merge audit trail, newest first
+func (i *IngredientModel) GetIngredientMerges(ctx context.Context) ([]IngredientMerge, error) {
+	rows, err := i.PostgreSQL.Query(ctx,
+		`SELECT id, duplicate_id, duplicate_name, canonical_id, canonical_name, translations_moved,
+			recipe_ingredients_moved, pantry_items_moved, ad_ingredients_moved, aliases_moved, merged_by, merged_at
+		FROM ingredient_merge ORDER BY merged_at DESC, id DESC`)
+	if err != nil {
+		config.Logger.Error("Error getting ingredient merges", zap.Error(err), zap.String("function", "GetIngredientMerges"))
+		return nil, err
+	}
+	defer rows.Close()
+	var merges []IngredientMerge
+	for rows.Next() {
+		var m IngredientMerge
+		err := rows.Scan(&m.ID, &m.DuplicateID, &m.DuplicateName, &m.CanonicalID, &m.CanonicalName, &m.TranslationsMoved,
+			&m.RecipeIngredientsMoved, &m.PantryItemsMoved, &m.AdIngredientsMoved, &m.AliasesMoved, &m.MergedBy, &m.MergedAt)
+		if err != nil {
+			config.Logger.Error("Error scanning row", zap.Error(err), zap.String("function", "GetIngredientMerges"))
+			return nil, err
+		}
+		merges = append(merges, m)
+	}
+	if err := rows.Err(); err != nil {
+		config.Logger.Error("Error processing rows", zap.Error(err), zap.String("function", "GetIngredientMerges"))
+		return nil, err
+	}
+	return merges, nil
+}
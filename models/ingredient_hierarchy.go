@@ -0,0 +1,93 @@
+package models
+
+import (
+	"context"
+	"errors"
+
+	"backend/main/config"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// ErrIngredientCycle is returned when setting a parent would make an ingredient its own ancestor
+var ErrIngredientCycle = errors.New("ingredient parent would create a cycle")
+
+// GetIngredientParents maps every ingredient that has a parent onto its parent ID
+func (i *IngredientModel) GetIngredientParents(ctx context.Context) (map[uint]uint, error) {
+	parents := make(map[uint]uint)
+	rows, err := i.PostgreSQL.Query(ctx,
+		"SELECT id, parent_id FROM ingredient WHERE parent_id IS NOT NULL")
+	if err != nil {
+		config.Logger.Error("Error getting ingredient parents", zap.Error(err), zap.String("function", "GetIngredientParents"))
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, parentID uint
+		if err := rows.Scan(&id, &parentID); err != nil {
+			config.Logger.Error("Error scanning row", zap.Error(err), zap.String("function", "GetIngredientParents"))
+			return nil, err
+		}
+		parents[id] = parentID
+	}
+	if err := rows.Err(); err != nil {
+		config.Logger.Error("Error processing rows", zap.Error(err), zap.String("function", "GetIngredientParents"))
+		return nil, err
+	}
+	return parents, nil
+}
+
+// lockIngredientHierarchy serializes changes to ingredient parents until tx ends, so an
+// ancestry check still holds when the parent it allowed is written
+func lockIngredientHierarchy(ctx context.Context, tx pgx.Tx) error {
+	_, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext('ingredient_hierarchy'))")
+	return err
+}
+
+// SetIngredientParent sets or, when parentID is nil, clears an ingredient's parent. It
+// returns ErrIngredientCycle if the ingredient is the parent or one of its ancestors and
+// pgx.ErrNoRows if the ingredient does not exist.
+func (i *IngredientModel) SetIngredientParent(ctx context.Context, id uint, parentID *uint) error {
+	tx, err := i.PostgreSQL.Begin(ctx)
+	if err != nil {
+		config.Logger.Error("Error starting transaction", zap.Error(err), zap.String("function", "SetIngredientParent"))
+		return err
+	}
+	defer tx.Rollback(ctx)
+	if err := lockIngredientHierarchy(ctx, tx); err != nil {
+		config.Logger.Error("Error locking ingredient hierarchy", zap.Error(err), zap.String("function", "SetIngredientParent"))
+		return err
+	}
+
+	if parentID != nil {
+		var cycle bool
+		err := tx.QueryRow(ctx,
+			`WITH RECURSIVE ancestor(id, parent_id) AS (
+				SELECT id, parent_id FROM ingredient WHERE id = $2
+				UNION
+				SELECT ing.id, ing.parent_id FROM ingredient ing JOIN ancestor a ON ing.id = a.parent_id
+			)
+			SELECT EXISTS (SELECT 1 FROM ancestor WHERE id = $1)`, id, *parentID).Scan(&cycle)
+		if err != nil {
+			config.Logger.Error("Error checking ingredient ancestry", zap.Error(err), zap.String("function", "SetIngredientParent"))
+			return err
+		}
+		if cycle {
+			return ErrIngredientCycle
+		}
+	}
+	tag, err := tx.Exec(ctx, "UPDATE ingredient SET parent_id = $2 WHERE id = $1", id, parentID)
+	if err != nil {
+		config.Logger.Error("Error setting ingredient parent", zap.Error(err), zap.String("function", "SetIngredientParent"))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	if err := tx.Commit(ctx); err != nil {
+		config.Logger.Error("Error committing ingredient parent", zap.Error(err), zap.String("function", "SetIngredientParent"))
+		return err
+	}
+	return nil
+}
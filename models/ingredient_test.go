@@ -0,0 +1,70 @@
+package models
+
+import "testing"
+
+// removeIngredient applies reparentChildren to an in-memory hierarchy of child to parent
+// and drops the removed ingredient
+func removeIngredient(parents map[uint]uint, removed uint, replacement uint) {
+	descendant := false
+	for id, ok := replacement, true; ok; id, ok = parents[id] {
+		if id == removed {
+			descendant = true
+			break
+		}
+	}
+	var removedParent *uint
+	if parent, ok := parents[removed]; ok {
+		removedParent = &parent
+	}
+	target := childrenParent(removedParent, replacement, descendant)
+	for child, parent := range parents {
+		if parent != removed {
+			continue
+		}
+		if target == nil {
+			delete(parents, child)
+		} else {
+			parents[child] = *target
+		}
+	}
+	delete(parents, removed)
+}
+
+func TestChildrenParentKeepsHierarchyAcyclic(t *testing.T) {
+	// 1 produce > 2 fruit > 3 citrus > 4 lemon, 2 fruit > 5 berry, 6 dairy
+	hierarchy := func() map[uint]uint { return map[uint]uint{2: 1, 3: 2, 4: 3, 5: 2} }
+	tests := []struct {
+		name        string
+		removed     uint
+		replacement uint
+		want        map[uint]uint
+	}{
+		{"unrelated replacement adopts the children", 2, 6, map[uint]uint{3: 6, 4: 3, 5: 6}},
+		{"direct child replacement moves up with its siblings", 2, 3, map[uint]uint{3: 1, 4: 3, 5: 1}},
+		{"grandchild replacement leaves the children with the old grandparent", 2, 4, map[uint]uint{3: 1, 4: 3, 5: 1}},
+		{"descendant of a root leaves the children as roots", 1, 4, map[uint]uint{3: 2, 4: 3, 5: 2}},
+		{"parent replacement adopts the children", 3, 2, map[uint]uint{2: 1, 4: 2, 5: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parents := hierarchy()
+			removeIngredient(parents, tt.removed, tt.replacement)
+			for id := range parents {
+				steps := 0
+				for at, ok := id, true; ok; at, ok = parents[at] {
+					if steps++; steps > len(parents)+1 {
+						t.Fatalf("ingredient %d is in a parent cycle: %v", id, parents)
+					}
+				}
+			}
+			if len(parents) != len(tt.want) {
+				t.Fatalf("parents = %v, want %v", parents, tt.want)
+			}
+			for child, parent := range tt.want {
+				if parents[child] != parent {
+					t.Errorf("parent of %d = %d, want %d (%v)", child, parents[child], parent, parents)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,613 @@
+// Package memory is an in-memory implementation of the model repository interfaces for
+// testing services without Postgres. IDs are assigned like serial columns, starting at
+// 1 per table, unless the inserted value already has one. Unique constraints are
+// enforced and reported as *pgconn.PgError with code 23505, as Postgres would, but
+// foreign keys are not, so tests can build rows that point at missing ingredients.
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"backend/main/geo"
+	"backend/main/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const dateLayout = "2006-01-02"
+
+// Repository holds every table in memory. It implements models.UserRepository,
+// models.StoreRepository, models.AdRepository, models.IngredientRepository and
+// models.ShoppingListRepository and is safe for concurrent use.
+type Repository struct {
+	// Now is the clock used for "today" in expiry and price history windows
+	Now func() time.Time
+
+	mu            sync.Mutex
+	users         map[uint]models.User
+	stores        map[uint]models.Store
+	subscriptions map[subscription]bool
+	ads           []models.Ad
+	ingredients   map[uint]models.Ingredient
+	shoppingLists map[uint]models.ShoppingList
+	lastID        map[string]uint
+}
+
+type subscription struct {
+	userID  uint
+	storeID uint
+}
+
+var (
+	_ models.UserRepository         = (*Repository)(nil)
+	_ models.StoreRepository        = (*Repository)(nil)
+	_ models.AdRepository           = (*Repository)(nil)
+	_ models.IngredientRepository   = (*Repository)(nil)
+	_ models.ShoppingListRepository = (*Repository)(nil)
+)
+
+// New is a constructor for an empty Repository
+func New() *Repository {
+	return &Repository{
+		Now:           time.Now,
+		users:         make(map[uint]models.User),
+		stores:        make(map[uint]models.Store),
+		subscriptions: make(map[subscription]bool),
+		ingredients:   make(map[uint]models.Ingredient),
+		shoppingLists: make(map[uint]models.ShoppingList),
+		lastID:        make(map[string]uint),
+	}
+}
+
+// assignID returns id when set, otherwise the table's next serial ID
+func (r *Repository) assignID(table string, id *uint) uint {
+	if id != nil {
+		r.lastID[table] = max(r.lastID[table], *id)
+		return *id
+	}
+	r.lastID[table]++
+	return r.lastID[table]
+}
+
+func uniqueViolation(constraint string) error {
+	return &pgconn.PgError{Code: "23505", Message: "duplicate key value violates unique constraint", ConstraintName: constraint}
+}
+
+func (r *Repository) today() time.Time {
+	now := r.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// parseDate reads the date part of an ad's sale_start or sale_end
+func parseDate(s string) time.Time {
+	if len(s) > len(dateLayout) {
+		s = s[:len(dateLayout)]
+	}
+	t, _ := time.Parse(dateLayout, s)
+	return t
+}
+
+// GetUserByID returns a user or pgx.ErrNoRows
+func (r *Repository) GetUserByID(ctx context.Context, id uint) (models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok {
+		return models.User{}, pgx.ErrNoRows
+	}
+	return user, nil
+}
+
+// CreateUser adds a user; emails are unique
+func (r *Repository) CreateUser(ctx context.Context, user models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.users {
+		if existing.Email == user.Email {
+			return uniqueViolation("grocery_user_email_key")
+		}
+	}
+	id := r.assignID("grocery_user", user.ID)
+	user.ID = &id
+	r.users[id] = user
+	return nil
+}
+
+// AddUserSubscriptionStore subscribes a user to a store
+func (r *Repository) AddUserSubscriptionStore(ctx context.Context, userID uint, storeID uint) error {
+	return r.SubscribeStore(ctx, userID, storeID)
+}
+
+// GetStoreByID returns a store or pgx.ErrNoRows
+func (r *Repository) GetStoreByID(ctx context.Context, id uint) (models.Store, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	store, ok := r.stores[id]
+	if !ok {
+		return models.Store{}, pgx.ErrNoRows
+	}
+	return store, nil
+}
+
+// CreateStore adds a store, defaulting its ad source to flipp
+func (r *Repository) CreateStore(ctx context.Context, store models.Store) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := r.assignID("store", store.ID)
+	store.ID = &id
+	if store.AdSource == "" {
+		store.AdSource = "flipp"
+	}
+	if store.Address.Country == "" {
+		store.Address.Country = "US"
+	}
+	store.Address.PostalCode = store.PostalCode()
+	r.stores[id] = store
+	return nil
+}
+
+// GetExpiredAdStores returns the stores due for new ads like StoreModel.GetExpiredAdStores
+func (r *Repository) GetExpiredAdStores(ctx context.Context) ([]models.Store, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	type window struct {
+		lastStart, lastEnd, lastEnded time.Time
+	}
+	windows := make(map[uint]window)
+	today := r.today()
+	for _, ad := range r.ads {
+		w := windows[ad.StoreID]
+		start, end := parseDate(ad.SaleStart), parseDate(ad.SaleEnd)
+		if start.After(w.lastStart) {
+			w.lastStart = start
+		}
+		if end.After(w.lastEnd) {
+			w.lastEnd = end
+		}
+		if end.Before(today) && end.After(w.lastEnded) {
+			w.lastEnded = end
+		}
+		windows[ad.StoreID] = w
+	}
+	var stores []models.Store
+	for storeID, store := range r.stores {
+		w, ok := windows[storeID]
+		if !ok || w.lastEnd.Before(today) || (!w.lastEnded.IsZero() && w.lastEnded.After(w.lastStart)) {
+			stores = append(stores, store)
+		}
+	}
+	slices.SortFunc(stores, byStoreID)
+	return stores, nil
+}
+
+// SubscribeStore subscribes a user to a store; a second subscription is a unique violation
+func (r *Repository) SubscribeStore(ctx context.Context, userID uint, storeID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := subscription{userID: userID, storeID: storeID}
+	if r.subscriptions[key] {
+		return uniqueViolation("store_subscription_pkey")
+	}
+	r.subscriptions[key] = true
+	return nil
+}
+
+// IsSubscribed reports whether a user is subscribed to a store
+func (r *Repository) IsSubscribed(ctx context.Context, userID uint, storeID uint) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.subscriptions[subscription{userID: userID, storeID: storeID}], nil
+}
+
+// UnsubscribeStore removes a subscription if there is one
+func (r *Repository) UnsubscribeStore(ctx context.Context, userID uint, storeID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.subscriptions, subscription{userID: userID, storeID: storeID})
+	return nil
+}
+
+// GetSubscribedStores returns the stores a user is subscribed to, ordered by ID
+func (r *Repository) GetSubscribedStores(ctx context.Context, userID uint) ([]models.Store, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var stores []models.Store
+	for key := range r.subscriptions {
+		if store, ok := r.stores[key.storeID]; ok && key.userID == userID {
+			stores = append(stores, store)
+		}
+	}
+	slices.SortFunc(stores, byStoreID)
+	return stores, nil
+}
+
+// GetStoresWithin returns the stores inside box, ordered by ID
+func (r *Repository) GetStoresWithin(ctx context.Context, box geo.Box) ([]models.Store, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var stores []models.Store
+	for _, store := range r.stores {
+		if point, ok := store.Point(); ok && box.Contains(point) {
+			stores = append(stores, store)
+		}
+	}
+	slices.SortFunc(stores, byStoreID)
+	return stores, nil
+}
+
+// GetUnplacedStores returns the stores without coordinates, ordered by ID
+func (r *Repository) GetUnplacedStores(ctx context.Context) ([]models.Store, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var stores []models.Store
+	for _, store := range r.stores {
+		if _, ok := store.Point(); !ok {
+			stores = append(stores, store)
+		}
+	}
+	slices.SortFunc(stores, byStoreID)
+	return stores, nil
+}
+
+// SetStoreCoordinates saves a store's coordinates, city and state
+func (r *Repository) SetStoreCoordinates(ctx context.Context, store models.Store) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.stores[*store.ID]
+	if !ok {
+		return pgx.ErrNoRows
+	}
+	stored.Latitude, stored.Longitude = store.Latitude, store.Longitude
+	stored.Address.City, stored.Address.State = store.Address.City, store.Address.State
+	r.stores[*store.ID] = stored
+	return nil
+}
+
+func byStoreID(a, b models.Store) int {
+	return cmp.Compare(*a.ID, *b.ID)
+}
+
+// CreateAd adds an ad, or reconciles the items of the ad already stored for the same
+// store, sale window and flyer, like AdModel.CreateAd
+func (r *Repository) CreateAd(ctx context.Context, ad models.Ad) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	existing := slices.IndexFunc(r.ads, func(stored models.Ad) bool {
+		return stored.StoreID == ad.StoreID && stored.SaleStart == ad.SaleStart &&
+			stored.SaleEnd == ad.SaleEnd && stored.SourceFlyerID == ad.SourceFlyerID
+	})
+	itemIDs := make(map[string]uint)
+	if existing >= 0 {
+		ad.ID = r.ads[existing].ID
+		for _, item := range r.ads[existing].Ingredient {
+			itemIDs[item.Name] = *item.ID
+		}
+	} else {
+		id := r.assignID("ad", ad.ID)
+		ad.ID = &id
+	}
+	// items are unique by name; a repeated name updates the earlier item
+	var items []models.AdIngredient
+	for _, item := range ad.Ingredient {
+		id, ok := itemIDs[item.Name]
+		if !ok {
+			id = r.assignID("ad_ingredient", item.ID)
+			itemIDs[item.Name] = id
+		}
+		item.ID = &id
+		if n := slices.IndexFunc(items, func(added models.AdIngredient) bool { return added.Name == item.Name }); n >= 0 {
+			items[n] = item
+		} else {
+			items = append(items, item)
+		}
+	}
+	slices.SortFunc(items, func(a, b models.AdIngredient) int {
+		return cmp.Compare(*a.ID, *b.ID)
+	})
+	ad.Ingredient = items
+	if existing >= 0 {
+		r.ads[existing] = ad
+	} else {
+		r.ads = append(r.ads, ad)
+	}
+	return nil
+}
+
+// GetRecentAd returns the store's ad with the latest sale_start or pgx.ErrNoRows
+func (r *Repository) GetRecentAd(ctx context.Context, storeID uint) (models.Ad, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var recent *models.Ad
+	for i, ad := range r.ads {
+		if ad.StoreID != storeID {
+			continue
+		}
+		if recent == nil || !parseDate(ad.SaleStart).Before(parseDate(recent.SaleStart)) {
+			recent = &r.ads[i]
+		}
+	}
+	if recent == nil {
+		return models.Ad{}, pgx.ErrNoRows
+	}
+	ad := *recent
+	ad.Ingredient = slices.Clone(recent.Ingredient)
+	return ad, nil
+}
+
+// GetActiveAds returns the store's ads whose sale window includes day, newest first
+func (r *Repository) GetActiveAds(ctx context.Context, storeID uint, day time.Time) ([]models.Ad, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	var ads []models.Ad
+	for _, ad := range r.ads {
+		if ad.StoreID == storeID && !parseDate(ad.SaleStart).After(day) && !parseDate(ad.SaleEnd).Before(day) {
+			ad.Ingredient = slices.Clone(ad.Ingredient)
+			ads = append(ads, ad)
+		}
+	}
+	slices.SortStableFunc(ads, func(a, b models.Ad) int {
+		if c := parseDate(b.SaleStart).Compare(parseDate(a.SaleStart)); c != 0 {
+			return c
+		}
+		return cmp.Compare(*b.ID, *a.ID)
+	})
+	return ads, nil
+}
+
+// GetPriceStats summarizes deal unit prices like AdModel.GetPriceStats
+func (r *Repository) GetPriceStats(ctx context.Context, storeID uint, ingredientIDs []uint, windowDays int, excludeAdID *uint) (map[models.PriceKey]models.PriceStats, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := make(map[models.PriceKey]models.PriceStats)
+	since := r.today().AddDate(0, 0, -windowDays)
+	sums := make(map[models.PriceKey]float32)
+	for _, ad := range r.ads {
+		if ad.StoreID != storeID || parseDate(ad.SaleStart).Before(since) || (excludeAdID != nil && *ad.ID == *excludeAdID) {
+			continue
+		}
+		for _, item := range ad.Ingredient {
+			if !slices.Contains(ingredientIDs, item.IngredientID) {
+				continue
+			}
+			price, unit := item.Deal.UnitPrice, item.Deal.Unit
+			if price == nil {
+				price = item.Price
+			}
+			if unit == "" {
+				unit = "each"
+			}
+			if price == nil {
+				continue
+			}
+			key := models.PriceKey{IngredientID: item.IngredientID, Unit: unit}
+			s, ok := stats[key]
+			if !ok {
+				s = models.PriceStats{IngredientID: item.IngredientID, Unit: unit, WindowDays: windowDays, Min: *price, Max: *price}
+			}
+			s.Min = min(s.Min, *price)
+			s.Max = max(s.Max, *price)
+			s.Count++
+			sums[key] += *price
+			stats[key] = s
+		}
+	}
+	for key, s := range stats {
+		s.Avg = sums[key] / float32(s.Count)
+		stats[key] = s
+	}
+	return stats, nil
+}
+
+// GetIngredientByID returns an ingredient or pgx.ErrNoRows
+func (r *Repository) GetIngredientByID(ctx context.Context, id uint) (models.Ingredient, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ingredient, ok := r.ingredients[id]
+	if !ok {
+		return models.Ingredient{}, pgx.ErrNoRows
+	}
+	return ingredient, nil
+}
+
+// GetAllIngredients returns every ingredient ordered by ID
+func (r *Repository) GetAllIngredients(ctx context.Context) ([]models.Ingredient, error) {
+	return r.ingredientsWhere(func(models.Ingredient) bool { return true }, func(a, b models.Ingredient) int {
+		return cmp.Compare(*a.ID, *b.ID)
+	}), nil
+}
+
+// GetAllIngredientsNameID maps every ingredient name onto its ID
+func (r *Repository) GetAllIngredientsNameID(ctx context.Context) (map[string]uint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	names := make(map[string]uint, len(r.ingredients))
+	for id, ingredient := range r.ingredients {
+		names[ingredient.Name] = id
+	}
+	return names, nil
+}
+
+// GetIngredientsInSeason returns the ingredients whose season includes month, ordered by name
+func (r *Repository) GetIngredientsInSeason(ctx context.Context, month int) ([]models.Ingredient, error) {
+	return r.ingredientsWhere(func(ingredient models.Ingredient) bool {
+		return ingredient.Season != nil && slices.Contains(*ingredient.Season, month)
+	}, func(a, b models.Ingredient) int {
+		return cmp.Compare(a.Name, b.Name)
+	}), nil
+}
+
+func (r *Repository) ingredientsWhere(keep func(models.Ingredient) bool, order func(a, b models.Ingredient) int) []models.Ingredient {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ingredients []models.Ingredient
+	for _, ingredient := range r.ingredients {
+		if keep(ingredient) {
+			ingredients = append(ingredients, ingredient)
+		}
+	}
+	slices.SortFunc(ingredients, order)
+	return ingredients
+}
+
+// GetIngredientParents maps every ingredient that has a parent onto its parent ID
+func (r *Repository) GetIngredientParents(ctx context.Context) (map[uint]uint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	parents := make(map[uint]uint)
+	for id, ingredient := range r.ingredients {
+		if ingredient.ParentID != nil {
+			parents[id] = *ingredient.ParentID
+		}
+	}
+	return parents, nil
+}
+
+// CreateIngredient adds an ingredient and returns its ID; names are unique
+func (r *Repository) CreateIngredient(ctx context.Context, ingredient models.Ingredient) (uint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.ingredients {
+		if existing.Name == ingredient.Name {
+			return 0, uniqueViolation("ingredient_name_key")
+		}
+	}
+	id := r.assignID("ingredient", ingredient.ID)
+	ingredient.ID = &id
+	if ingredient.Season == nil {
+		ingredient.Season = &[]int{}
+	}
+	r.ingredients[id] = ingredient
+	return id, nil
+}
+
+// CreateShoppingList adds a shopping list and its items and returns the list ID
+func (r *Repository) CreateShoppingList(ctx context.Context, list models.ShoppingList) (uint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := r.assignID("shopping_list", nil)
+	list.ID = &id
+	list.CreatedAt = r.Now().UTC().Format(time.RFC3339Nano)
+	items := list.Item
+	list.Item = nil
+	for _, item := range items {
+		list.Item = append(list.Item, r.newShoppingListItem(item))
+	}
+	r.shoppingLists[id] = list
+	return id, nil
+}
+
+// GetShoppingList returns a shopping list with its items, filling in their store names
+// and food types like ShoppingListModel.GetShoppingList
+func (r *Repository) GetShoppingList(ctx context.Context, id uint) (models.ShoppingList, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list, ok := r.shoppingLists[id]
+	if !ok {
+		return models.ShoppingList{}, pgx.ErrNoRows
+	}
+	list.Item = slices.Clone(list.Item)
+	for n, item := range list.Item {
+		if item.StoreID != nil {
+			if store, ok := r.stores[*item.StoreID]; ok {
+				list.Item[n].StoreName = store.Name
+			}
+		}
+		list.Item[n].Type = models.Other
+		if item.IngredientID != nil {
+			if ingredient, ok := r.ingredients[*item.IngredientID]; ok {
+				list.Item[n].Type = ingredient.Type
+			}
+		}
+	}
+	return list, nil
+}
+
+// GetShoppingLists returns a user's shopping lists without their items, newest first
+func (r *Repository) GetShoppingLists(ctx context.Context, userID uint) ([]models.ShoppingList, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var lists []models.ShoppingList
+	for _, list := range r.shoppingLists {
+		if list.UserID == userID {
+			list.Item = nil
+			lists = append(lists, list)
+		}
+	}
+	slices.SortFunc(lists, func(a, b models.ShoppingList) int {
+		return cmp.Or(cmp.Compare(b.CreatedAt, a.CreatedAt), cmp.Compare(*b.ID, *a.ID))
+	})
+	return lists, nil
+}
+
+// AddShoppingListItem adds an item to a shopping list and returns the item ID
+func (r *Repository) AddShoppingListItem(ctx context.Context, listID uint, item models.ShoppingListItem) (uint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list, ok := r.shoppingLists[listID]
+	if !ok {
+		return 0, &pgconn.PgError{Code: "23503", Message: "insert violates foreign key constraint", ConstraintName: "shopping_list_item_shopping_list_id_fkey"}
+	}
+	item = r.newShoppingListItem(item)
+	list.Item = append(list.Item, item)
+	r.shoppingLists[listID] = list
+	return *item.ID, nil
+}
+
+func (r *Repository) newShoppingListItem(item models.ShoppingListItem) models.ShoppingListItem {
+	id := r.assignID("shopping_list_item", nil)
+	item.ID = &id
+	// store names are read from the store, never stored
+	item.StoreName = nil
+	return item
+}
+
+// UpdateShoppingListItem sets the fields of an item that update gives and keeps the rest,
+// or returns pgx.ErrNoRows when the item is not on the list
+func (r *Repository) UpdateShoppingListItem(ctx context.Context, listID uint, update models.ShoppingListItemUpdate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list, ok := r.shoppingLists[listID]
+	if !ok {
+		return pgx.ErrNoRows
+	}
+	n := slices.IndexFunc(list.Item, func(item models.ShoppingListItem) bool { return *item.ID == update.ID })
+	if n < 0 {
+		return pgx.ErrNoRows
+	}
+	item := list.Item[n]
+	item.Amount = cmp.Or(update.Amount, item.Amount)
+	item.Unit = cmp.Or(update.Unit, item.Unit)
+	item.StoreID = cmp.Or(update.StoreID, item.StoreID)
+	item.Price = cmp.Or(update.Price, item.Price)
+	if update.Checked != nil {
+		item.Checked = *update.Checked
+	}
+	list.Item = slices.Clone(list.Item)
+	list.Item[n] = item
+	r.shoppingLists[listID] = list
+	return nil
+}
+
+// DeleteShoppingListItem removes an item from a shopping list, or returns pgx.ErrNoRows
+// when the item is not on the list
+func (r *Repository) DeleteShoppingListItem(ctx context.Context, listID uint, itemID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list, ok := r.shoppingLists[listID]
+	if !ok {
+		return pgx.ErrNoRows
+	}
+	n := slices.IndexFunc(list.Item, func(item models.ShoppingListItem) bool { return *item.ID == itemID })
+	if n < 0 {
+		return pgx.ErrNoRows
+	}
+	list.Item = slices.Delete(slices.Clone(list.Item), n, n+1)
+	r.shoppingLists[listID] = list
+	return nil
+}
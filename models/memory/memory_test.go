@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"backend/main/models"
+)
+
+func TestCreateAdReconcilesReingestedFlyer(t *testing.T) {
+	ctx := context.Background()
+	repo := New()
+	flyer := func(names ...string) models.Ad {
+		ad := models.Ad{StoreID: 1, SaleStart: "2025-06-04", SaleEnd: "2025-06-10", SourceFlyerID: "6721843"}
+		for _, name := range names {
+			ad.Ingredient = append(ad.Ingredient, models.AdIngredient{IngredientID: 1, Name: name})
+		}
+		return ad
+	}
+	if err := repo.CreateAd(ctx, flyer("Gala Apples", "Whole Milk")); err != nil {
+		t.Fatal(err)
+	}
+	first, err := repo.GetRecentAd(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.CreateAd(ctx, flyer("Whole Milk", "Sharp Cheddar", "Sharp Cheddar")); err != nil {
+		t.Fatal(err)
+	}
+	ad, err := repo.GetRecentAd(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.ads) != 1 || *ad.ID != *first.ID {
+		t.Fatalf("re-ingesting the flyer stored %d ads, want the original ad updated", len(repo.ads))
+	}
+	if len(ad.Ingredient) != 2 || ad.Ingredient[0].Name != "Whole Milk" || ad.Ingredient[1].Name != "Sharp Cheddar" {
+		t.Fatalf("items = %+v, want Whole Milk kept, Sharp Cheddar added once and Gala Apples removed", ad.Ingredient)
+	}
+	if *ad.Ingredient[0].ID != *first.Ingredient[1].ID {
+		t.Errorf("kept item ID = %d, want %d", *ad.Ingredient[0].ID, *first.Ingredient[1].ID)
+	}
+
+	// another flyer for the same window is a separate ad
+	other := flyer("Bananas")
+	other.SourceFlyerID = "6722010"
+	if err := repo.CreateAd(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.ads) != 2 {
+		t.Errorf("stored %d ads, want 2", len(repo.ads))
+	}
+}
+
+func TestGetExpiredAdStores(t *testing.T) {
+	ctx := context.Background()
+	repo := New()
+	repo.Now = func() time.Time { return time.Date(2025, time.June, 11, 9, 0, 0, 0, time.UTC) }
+	flyers := map[uint][][2]string{
+		// a single flyer that has ended
+		1: {{"2025-06-04", "2025-06-10"}},
+		// the weekly flyer ended while the monthly one runs on
+		2: {{"2025-06-01", "2025-06-30"}, {"2025-06-04", "2025-06-10"}},
+		// the next weekly flyer has already been ingested
+		3: {{"2025-06-01", "2025-06-30"}, {"2025-06-04", "2025-06-10"}, {"2025-06-11", "2025-06-17"}},
+		// nothing has ended
+		4: {{"2025-06-09", "2025-06-15"}},
+		// never had an ad
+		5: nil,
+	}
+	for id, windows := range flyers {
+		if err := repo.CreateStore(ctx, models.Store{ID: &id}); err != nil {
+			t.Fatal(err)
+		}
+		for _, window := range windows {
+			if err := repo.CreateAd(ctx, models.Ad{StoreID: id, SaleStart: window[0], SaleEnd: window[1]}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	stores, err := repo.GetExpiredAdStores(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []uint
+	for _, store := range stores {
+		got = append(got, *store.ID)
+	}
+	if want := []uint{1, 2, 5}; !slices.Equal(got, want) {
+		t.Errorf("GetExpiredAdStores() = %v, want %v", got, want)
+	}
+}
+
+func TestCreateStorePostalCode(t *testing.T) {
+	ctx := context.Background()
+	repo := New()
+	for _, store := range []models.Store{
+		{Location: "1 Market St, San Francisco, CA 94105"},
+		{Location: "1 Market St, San Francisco, CA 94105 ", Address: models.Address{PostalCode: "94107"}},
+		{Location: "1 Market St, San Francisco"},
+	} {
+		if err := repo.CreateStore(ctx, store); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for id, want := range map[uint]string{1: "94105", 2: "94107", 3: ""} {
+		store, err := repo.GetStoreByID(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if store.Address.PostalCode != want {
+			t.Errorf("store %d postal code = %q, want %q", id, store.Address.PostalCode, want)
+		}
+	}
+}
@@ -3,14 +3,12 @@ package models
 import (
 	"context"
 
-
-	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
 // PantryModel defines a struct for pantry service
 type PantryModel struct {
-	PostgreSQL *pgx.Conn
+	PostgreSQL DB
 	Logger     zap.Logger
 }
 
@@ -27,7 +25,7 @@ type PantryIngredient struct {
 	Unit		   *string `json:"unit"`
 }
 
-func NewPantryModel(PostgreSQL *pgx.Conn, logger zap.Logger) *PantryModel {
+func NewPantryModel(PostgreSQL DB, logger zap.Logger) *PantryModel {
 	return &PantryModel{
 		PostgreSQL: PostgreSQL,
 		Logger:     logger,
@@ -53,10 +51,10 @@ func NewPantryIngredient(id *uint, quantity *string, unit *string) *PantryIngred
 }
 
 // GetPantry gets all pantry ingredients given user id
-func (i *PantryModel) GetPantryItems(userID uint) ([]PantryIngredient, error) {
+func (i *PantryModel) GetPantryItems(ctx context.Context, userID uint) ([]PantryIngredient, error) {
 	var pantryIngredients []PantryIngredient
-	rows, err := i.PostgreSQL.Query(context.Background(), 
-		"SELECT pi.id, pi.ingredient_id, pi.amount, pi.unit, pi.name FROM Pantry_ingredient pi INNER JOIN Pantry p ON pi.Pantry_id = p.id WHERE p.user_id = $1", userID)
+	rows, err := i.PostgreSQL.Query(ctx, 
+		"SELECT ingredient_id, quantity, unit FROM pantry WHERE user_id = $1", userID)
 	if err != nil {
 		i.Logger.Error("Error getting pantry ingredients", zap.Error(err))
 		return nil, err
@@ -72,18 +70,22 @@ func (i *PantryModel) GetPantryItems(userID uint) ([]PantryIngredient, error) {
 		}
 		pantryIngredients = append(pantryIngredients, pantryIngredient)
 	}
+	if err := rows.Err(); err != nil {
+		i.Logger.Error("Error processing pantry ingredients", zap.Error(err))
+		return nil, err
+	}
 
 	return pantryIngredients, nil
 }
 
-// CreatePantrys adds Pantrys to the database. This adds to the Pantry and Pantry_ingredient tables
-func (i *PantryModel) AddPantryIngredients(pantry Pantry) error {
+// AddPantryIngredients adds a user's ingredients to the pantry table
+func (i *PantryModel) AddPantryIngredients(ctx context.Context, pantry Pantry) error {
 	
 	for _, ingredient := range pantry.Ingredient {
 		var PantryID int
-		err := i.PostgreSQL.QueryRow(context.Background(),
-			"INSERT INTO Pantry (user_id, ingredient_id, quantity, unit) VALUES ($1, $2, $3) RETURNING id;",
-			pantry.UserID, ingredient.IngredientID, ingredient.Quantity, ingredient.Unit).Scan(&PantryID)
+		err := i.PostgreSQL.QueryRow(ctx,
+			"INSERT INTO pantry (user_id, ingredient_id, quantity, unit) VALUES ($1, $2, $3, $4) RETURNING id;",
+			pantry.UserID, ingredient.IngredientID, ingredient.Quantity, normalizeUnit(ingredient.Unit)).Scan(&PantryID)
 		if err != nil {
 			i.Logger.Error("Error adding pantry ingredients to database", zap.Error(err))
 			return err
@@ -93,4 +95,4 @@ func (i *PantryModel) AddPantryIngredients(pantry Pantry) error {
 	i.Logger.Info("Successfully added Pantrys to database")
 	return nil
 
-}
\ No newline at end of file
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// PriceKey identifies a price series: deal unit prices are only comparable within a unit
+type PriceKey struct {
+	IngredientID uint
+	Unit         string
+}
+
+// PriceStats summarizes an ingredient's historical deal unit price at a store
+type PriceStats struct {
+	IngredientID uint    `json:"ingredient_id"`
+	Unit         string  `json:"unit"`
+	WindowDays   int     `json:"window_days"`
+	Min          float32 `json:"min"`
+	Avg          float32 `json:"avg"`
+	Max          float32 `json:"max"`
+	Count        int     `json:"count"`
+}
+
+// GetPriceStats returns min/avg/max deal unit prices of the given ingredients at a store over
+// ads starting in the last windowDays days. excludeAdID leaves an ad (usually the current one)
+// out of the history. Rows written before deals were parsed fall back to price and "each".
+func (i *AdModel) GetPriceStats(ctx context.Context, storeID uint, ingredientIDs []uint, windowDays int, excludeAdID *uint) (map[PriceKey]PriceStats, error) {
+	stats := make(map[PriceKey]PriceStats)
+	if len(ingredientIDs) == 0 {
+		return stats, nil
+	}
+	rows, err := i.PostgreSQL.Query(ctx,
+		`SELECT ai.ingredient_id, COALESCE(ai.deal_unit, 'each') AS unit,
+			min(COALESCE(ai.deal_unit_price, ai.price)), avg(COALESCE(ai.deal_unit_price, ai.price))::real,
+			max(COALESCE(ai.deal_unit_price, ai.price)), count(*)
+		FROM ad_ingredient ai INNER JOIN ad a ON ai.ad_id = a.id
+		WHERE a.store_id = $1 AND ai.ingredient_id = ANY($2)
+			AND a.sale_start >= current_date - $3::int
+			AND ($4::int IS NULL OR a.id <> $4)
+			AND COALESCE(ai.deal_unit_price, ai.price) IS NOT NULL
+		GROUP BY ai.ingredient_id, unit`,
+		storeID, ingredientIDs, windowDays, excludeAdID)
+	if err != nil {
+		i.Logger.Error("Error getting price history", zap.Error(err), zap.String("function", "GetPriceStats"))
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		s := PriceStats{WindowDays: windowDays}
+		err := rows.Scan(&s.IngredientID, &s.Unit, &s.Min, &s.Avg, &s.Max, &s.Count)
+		if err != nil {
+			i.Logger.Error("Error scanning row", zap.Error(err), zap.String("function", "GetPriceStats"))
+			return nil, err
+		}
+		stats[PriceKey{IngredientID: s.IngredientID, Unit: s.Unit}] = s
+	}
+	if err := rows.Err(); err != nil {
+		i.Logger.Error("Error processing rows", zap.Error(err), zap.String("function", "GetPriceStats"))
+		return nil, err
+	}
+	return stats, nil
+}
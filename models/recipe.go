@@ -10,7 +10,7 @@ import (
 
 // RecipeModel defines a struct for Recipe service
 type RecipeModel struct {
-	PostgreSQL *pgx.Conn
+	PostgreSQL DB
 	Logger     zap.Logger
 }
 
@@ -30,7 +30,7 @@ type RecipeIngredient struct {
 	Name 		 string `json:"name"`
 }
 
-func NewRecipeModel(PostgreSQL *pgx.Conn, logger zap.Logger) *RecipeModel {
+func NewRecipeModel(PostgreSQL DB, logger zap.Logger) *RecipeModel {
 	return &RecipeModel{
 		PostgreSQL: PostgreSQL,
 		Logger:     logger,
@@ -59,11 +59,11 @@ func NewRecipeIngredient(id *uint, name string, amount *string, unit *string) *R
 }
 
 // CreateRecipes adds recipes to the database. This adds to the recipe and recipe_ingredient tables
-func (i *RecipeModel) CreateRecipes(recipes []Recipe) error {
+func (i *RecipeModel) CreateRecipes(ctx context.Context, recipes []Recipe) error {
 	
 	for _, recipe := range recipes {
 		var recipeID int
-		err := i.PostgreSQL.QueryRow(context.Background(),
+		err := i.PostgreSQL.QueryRow(ctx,
 			"INSERT INTO recipe (title, link, author) VALUES ($1, $2, $3) RETURNING id;",
 			recipe.Title, recipe.Link, recipe.Author).Scan(&recipeID)
 		if err != nil {
@@ -74,12 +74,12 @@ func (i *RecipeModel) CreateRecipes(recipes []Recipe) error {
 		rows := make([][]interface{}, len(recipe.Ingredient))
 		ingredient_ids := make([]int, len(recipe.Ingredient))
 		for i, ingredient := range recipe.Ingredient {
-			rows[i] = []interface{}{recipeID, ingredient.IngredientID, ingredient.Amount, ingredient.Unit, ingredient.Name}
+			rows[i] = []interface{}{recipeID, ingredient.IngredientID, ingredient.Amount, normalizeUnit(ingredient.Unit), ingredient.Name}
 			ingredient_ids[i] = int(*ingredient.IngredientID)
 		}
 
 		_, err = i.PostgreSQL.CopyFrom(
-			context.Background(),
+			ctx,
 			pgx.Identifier{"recipe_ingredient"},
 			[]string{"recipe_id", "ingredient_id", "amount", "unit", "name"},
 			pgx.CopyFromRows(rows),
@@ -97,9 +97,9 @@ func (i *RecipeModel) CreateRecipes(recipes []Recipe) error {
 }
 
 // GetAllRecipes returns all recipes from the database
-func (i *RecipeModel) GetAllRecipes() ([]Recipe, error) {
+func (i *RecipeModel) GetAllRecipes(ctx context.Context) ([]Recipe, error) {
 	var recipes []Recipe
-	rows, err := i.PostgreSQL.Query(context.Background(),
+	rows, err := i.PostgreSQL.Query(ctx,
 		"SELECT id, title, link, author FROM recipe")
 	if err != nil {
 		i.Logger.Error("Error getting all recipes", zap.Error(err))
@@ -121,4 +121,73 @@ func (i *RecipeModel) GetAllRecipes() ([]Recipe, error) {
 		return nil, err
 	}
 	return recipes, nil
-}
\ No newline at end of file
+}
+
+// GetAllRecipesWithIngredients returns all recipes with their recipe_ingredient rows attached
+func (i *RecipeModel) GetAllRecipesWithIngredients(ctx context.Context) ([]Recipe, error) {
+	recipes, err := i.GetAllRecipes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[uint]int, len(recipes))
+	for n, recipe := range recipes {
+		index[*recipe.ID] = n
+	}
+	rows, err := i.PostgreSQL.Query(ctx,
+		"SELECT recipe_id, ingredient_id, amount, unit, name FROM recipe_ingredient")
+	if err != nil {
+		i.Logger.Error("Error getting recipe ingredients", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var recipeID uint
+		var ri RecipeIngredient
+		err := rows.Scan(&recipeID, &ri.IngredientID, &ri.Amount, &ri.Unit, &ri.Name)
+		if err != nil {
+			i.Logger.Error("Error scanning row", zap.Error(err))
+			return nil, err
+		}
+		if n, ok := index[recipeID]; ok {
+			recipes[n].Ingredient = append(recipes[n].Ingredient, ri)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		i.Logger.Error("Error processing rows", zap.Error(err))
+		return nil, err
+	}
+	return recipes, nil
+}
+
+// GetRecipeByID returns a recipe and its recipe_ingredient rows
+func (i *RecipeModel) GetRecipeByID(ctx context.Context, id uint) (Recipe, error) {
+	var recipe Recipe
+	err := i.PostgreSQL.QueryRow(ctx,
+		"SELECT id, title, link, author FROM recipe WHERE id = $1", id).Scan(&recipe.ID, &recipe.Title, &recipe.Link, &recipe.Author)
+	if err != nil {
+		i.Logger.Error("Error getting recipe by ID", zap.Error(err))
+		return Recipe{}, err
+	}
+	rows, err := i.PostgreSQL.Query(ctx,
+		"SELECT ingredient_id, amount, unit, name FROM recipe_ingredient WHERE recipe_id = $1", id)
+	if err != nil {
+		i.Logger.Error("Error getting recipe ingredients", zap.Error(err))
+		return Recipe{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var ri RecipeIngredient
+		err := rows.Scan(&ri.IngredientID, &ri.Amount, &ri.Unit, &ri.Name)
+		if err != nil {
+			i.Logger.Error("Error scanning row", zap.Error(err))
+			return Recipe{}, err
+		}
+		recipe.Ingredient = append(recipe.Ingredient, ri)
+	}
+	if err := rows.Err(); err != nil {
+		i.Logger.Error("Error processing rows", zap.Error(err))
+		return Recipe{}, err
+	}
+	return recipe, nil
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"backend/main/geo"
+)
+
+// The repository interfaces describe what services need from each model, so a service
+// can run against the Postgres models or against the in-memory implementation in
+// models/memory. Missing rows are reported as pgx.ErrNoRows by every implementation.
+
+// UserRepository is implemented by *UserModel
+type UserRepository interface {
+	GetUserByID(ctx context.Context, id uint) (User, error)
+	CreateUser(ctx context.Context, user User) error
+	AddUserSubscriptionStore(ctx context.Context, userID uint, storeID uint) error
+}
+
+// StoreRepository is implemented by *StoreModel
+type StoreRepository interface {
+	GetStoreByID(ctx context.Context, id uint) (Store, error)
+	CreateStore(ctx context.Context, store Store) error
+	GetExpiredAdStores(ctx context.Context) ([]Store, error)
+	SubscribeStore(ctx context.Context, userID uint, storeID uint) error
+	IsSubscribed(ctx context.Context, userID uint, storeID uint) (bool, error)
+	UnsubscribeStore(ctx context.Context, userID uint, storeID uint) error
+	GetSubscribedStores(ctx context.Context, userID uint) ([]Store, error)
+	GetStoresWithin(ctx context.Context, box geo.Box) ([]Store, error)
+	GetUnplacedStores(ctx context.Context) ([]Store, error)
+	SetStoreCoordinates(ctx context.Context, store Store) error
+}
+
+// AdRepository is implemented by *AdModel
+type AdRepository interface {
+	CreateAd(ctx context.Context, ad Ad) error
+	GetRecentAd(ctx context.Context, storeID uint) (Ad, error)
+	GetActiveAds(ctx context.Context, storeID uint, day time.Time) ([]Ad, error)
+	GetPriceStats(ctx context.Context, storeID uint, ingredientIDs []uint, windowDays int, excludeAdID *uint) (map[PriceKey]PriceStats, error)
+}
+
+// IngredientRepository is implemented by *IngredientModel
+type IngredientRepository interface {
+	GetIngredientByID(ctx context.Context, id uint) (Ingredient, error)
+	GetAllIngredients(ctx context.Context) ([]Ingredient, error)
+	GetAllIngredientsNameID(ctx context.Context) (map[string]uint, error)
+	GetIngredientsInSeason(ctx context.Context, month int) ([]Ingredient, error)
+	GetIngredientParents(ctx context.Context) (map[uint]uint, error)
+	CreateIngredient(ctx context.Context, ingredient Ingredient) (uint, error)
+}
+
+// ShoppingListRepository is implemented by *ShoppingListModel
+type ShoppingListRepository interface {
+	CreateShoppingList(ctx context.Context, list ShoppingList) (uint, error)
+	GetShoppingList(ctx context.Context, id uint) (ShoppingList, error)
+	GetShoppingLists(ctx context.Context, userID uint) ([]ShoppingList, error)
+	AddShoppingListItem(ctx context.Context, listID uint, item ShoppingListItem) (uint, error)
+	UpdateShoppingListItem(ctx context.Context, listID uint, update ShoppingListItemUpdate) error
+	DeleteShoppingListItem(ctx context.Context, listID uint, itemID uint) error
+}
+
+var (
+	_ UserRepository         = (*UserModel)(nil)
+	_ StoreRepository        = (*StoreModel)(nil)
+	_ AdRepository           = (*AdModel)(nil)
+	_ IngredientRepository   = (*IngredientModel)(nil)
+	_ ShoppingListRepository = (*ShoppingListModel)(nil)
+)
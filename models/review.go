@@ -0,0 +1,296 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// ReviewStatus is where an LLM-created translation or ingredient is in human review
+type ReviewStatus string
+
+const (
+	ReviewPending  ReviewStatus = "pending"
+	ReviewApproved ReviewStatus = "approved"
+	ReviewRejected ReviewStatus = "rejected"
+)
+
+// ReviewKind is what an LLM created
+type ReviewKind string
+
+const (
+	// ReviewTranslation is an ad item name mapped onto an ingredient
+	ReviewTranslation ReviewKind = "translation"
+	// ReviewIngredient is a new ingredient with an LLM-chosen type and season
+	ReviewIngredient ReviewKind = "ingredient"
+)
+
+var (
+	// ErrReviewNotPending is returned when approving, editing or rejecting an item that was already reviewed
+	ErrReviewNotPending = errors.New("review item is not pending")
+	// ErrInvalidReviewEdit is returned when an edit does not apply to the item's kind
+	ErrInvalidReviewEdit = errors.New("invalid edit for review item")
+	// ErrIngredientInUse is returned when a rejected or deleted ingredient is still used by
+	// recipes or pantries and no replacement was given
+	ErrIngredientInUse = errors.New("ingredient is still referenced")
+)
+
+// ReviewModel defines a struct for the LLM review queue
+type ReviewModel struct {
+	PostgreSQL DB
+	Logger     zap.Logger
+}
+
+// ReviewItem is one LLM-created translation or ingredient awaiting review.
+// IngredientName is read from the joined ingredient row and is not written.
+type ReviewItem struct {
+	ID     *uint        `json:"id"`
+	Kind   ReviewKind   `json:"kind"`
+	Status ReviewStatus `json:"status"`
+	// Name is the raw ad item name for translations and the ingredient name for ingredients
+	Name           string     `json:"name"`
+	IngredientID   uint       `json:"ingredient_id"`
+	IngredientName *string    `json:"ingredient_name"`
+	Confidence     float64    `json:"confidence"`
+	Prompt         string     `json:"prompt"`
+	Response       string     `json:"response"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ReviewedAt     *time.Time `json:"reviewed_at"`
+}
+
+// ReviewEdit corrects a review item before approving it. Translations take IngredientID;
+// ingredients take Name, Type and Season.
+type ReviewEdit struct {
+	IngredientID *uint   `json:"ingredient_id"`
+	Name         *string `json:"name"`
+	Type         *string `json:"type"`
+	Season       *[]int  `json:"season"`
+}
+
+func NewReviewModel(PostgreSQL DB, logger zap.Logger) *ReviewModel {
+	return &ReviewModel{
+		PostgreSQL: PostgreSQL,
+		Logger:     logger,
+	}
+}
+
+// Constructor for a pending ReviewItem
+func NewReviewItem(kind ReviewKind, name string, ingredientID uint, confidence float64, prompt string, response string) *ReviewItem {
+	return &ReviewItem{
+		Kind:         kind,
+		Status:       ReviewPending,
+		Name:         name,
+		IngredientID: ingredientID,
+		Confidence:   confidence,
+		Prompt:       prompt,
+		Response:     response,
+	}
+}
+
+// CreateReviewItems adds items to the review queue
+func (i *ReviewModel) CreateReviewItems(ctx context.Context, items []ReviewItem) error {
+	rows := [][]interface{}{}
+	for _, item := range items {
+		status := item.Status
+		if status == "" {
+			status = ReviewPending
+		}
+		rows = append(rows, []interface{}{string(item.Kind), string(status), item.Name, item.IngredientID, item.Confidence, item.Prompt, item.Response})
+	}
+	copyCount, err := i.PostgreSQL.CopyFrom(
+		ctx,
+		pgx.Identifier{"review_item"},
+		[]string{"kind", "status", "name", "ingredient_id", "confidence", "prompt", "response"},
+		pgx.CopyFromRows(rows),
+	)
+	if err != nil {
+		i.Logger.Error("Error creating review items in database", zap.Error(err), zap.String("function", "CreateReviewItems"))
+		return err
+	}
+	i.Logger.Info("Review items added to database", zap.Int64("rows_added", copyCount))
+	return nil
+}
+
+const reviewItemColumns = `r.id, r.kind, r.status, r.name, r.ingredient_id, ing.name, r.confidence, r.prompt, r.response, r.created_at, r.reviewed_at
+	FROM review_item r LEFT JOIN ingredient ing ON ing.id = r.ingredient_id`
+
+func scanReviewItem(row pgx.Row) (ReviewItem, error) {
+	var item ReviewItem
+	err := row.Scan(&item.ID, &item.Kind, &item.Status, &item.Name, &item.IngredientID, &item.IngredientName,
+		&item.Confidence, &item.Prompt, &item.Response, &item.CreatedAt, &item.ReviewedAt)
+	return item, err
+}
+
+// GetReviewItems lists review items oldest first. An empty status or kind matches every item.
+func (i *ReviewModel) GetReviewItems(ctx context.Context, status ReviewStatus, kind ReviewKind, limit int) ([]ReviewItem, error) {
+	rows, err := i.PostgreSQL.Query(ctx,
+		"SELECT "+reviewItemColumns+` WHERE ($1 = '' OR r.status = $1) AND ($2 = '' OR r.kind = $2)
+		ORDER BY r.created_at, r.id LIMIT $3`, string(status), string(kind), limit)
+	if err != nil {
+		i.Logger.Error("Error getting review items", zap.Error(err), zap.String("function", "GetReviewItems"))
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReviewItem
+	for rows.Next() {
+		item, err := scanReviewItem(rows)
+		if err != nil {
+			i.Logger.Error("Error scanning review item", zap.Error(err), zap.String("function", "GetReviewItems"))
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		i.Logger.Error("Error processing rows", zap.Error(err), zap.String("function", "GetReviewItems"))
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetReviewItemByID gets one review item
+func (i *ReviewModel) GetReviewItemByID(ctx context.Context, id uint) (ReviewItem, error) {
+	item, err := scanReviewItem(i.PostgreSQL.QueryRow(ctx,
+		"SELECT "+reviewItemColumns+" WHERE r.id = $1", id))
+	if err != nil {
+		i.Logger.Error("Error getting review item by ID", zap.Error(err))
+		return ReviewItem{}, err
+	}
+	return item, nil
+}
+
+// ApproveReviewItem accepts a pending item as it is
+func (i *ReviewModel) ApproveReviewItem(ctx context.Context, id uint) error {
+	return i.review(ctx, id, "ApproveReviewItem", func(ctx context.Context, tx pgx.Tx, item ReviewItem) (ReviewStatus, error) {
+		return ReviewApproved, nil
+	})
+}
+
+// EditReviewItem corrects a pending item and approves it. Remapping a translation also
+// remaps the ad_ingredient rows created through it, and returns ErrReplacementNotFound if
+// the new ingredient does not exist.
+func (i *ReviewModel) EditReviewItem(ctx context.Context, id uint, edit ReviewEdit) error {
+	return i.review(ctx, id, "EditReviewItem", func(ctx context.Context, tx pgx.Tx, item ReviewItem) (ReviewStatus, error) {
+		switch item.Kind {
+		case ReviewTranslation:
+			if edit.IngredientID == nil || edit.Name != nil || edit.Type != nil || edit.Season != nil {
+				return "", ErrInvalidReviewEdit
+			}
+			if err := lockReplacement(ctx, tx, *edit.IngredientID); err != nil {
+				return "", err
+			}
+			return ReviewApproved, remapTranslation(ctx, tx, item, *edit.IngredientID)
+		case ReviewIngredient:
+			if edit.IngredientID != nil {
+				return "", ErrInvalidReviewEdit
+			}
+			var foodType *string
+			if edit.Type != nil {
+				name := ParseFoodType(*edit.Type).String()
+				foodType = &name
+			}
+			_, err := tx.Exec(ctx,
+				`UPDATE ingredient SET name = COALESCE($2, name), type = COALESCE($3, type), season = COALESCE($4, season)
+				WHERE id = $1`, item.IngredientID, edit.Name, foodType, edit.Season)
+			if err != nil {
+				return "", err
+			}
+			if edit.Name != nil {
+				_, err = tx.Exec(ctx, "UPDATE review_item SET name = $2 WHERE id = $1", item.ID, *edit.Name)
+			}
+			return ReviewApproved, err
+		}
+		return "", ErrInvalidReviewEdit
+	})
+}
+
+// RejectReviewItem rejects a pending item and remaps the rows created through the rejected
+// translation or ingredient onto replacementID, so live ads keep their items. It returns
+// ErrReplacementNotFound if the replacement does not exist.
+func (i *ReviewModel) RejectReviewItem(ctx context.Context, id uint, replacementID uint) error {
+	return i.review(ctx, id, "RejectReviewItem", func(ctx context.Context, tx pgx.Tx, item ReviewItem) (ReviewStatus, error) {
+		if item.Kind == ReviewIngredient && replacementID == item.IngredientID {
+			return "", ErrInvalidReviewEdit
+		}
+		if err := lockReplacement(ctx, tx, replacementID); err != nil {
+			return "", err
+		}
+		switch item.Kind {
+		case ReviewTranslation:
+			return ReviewRejected, remapTranslation(ctx, tx, item, replacementID)
+		case ReviewIngredient:
+			return ReviewRejected, rejectIngredient(ctx, tx, item, replacementID)
+		}
+		return "", ErrInvalidReviewEdit
+	})
+}
+
+// review locks a pending item, applies fn and records the status fn returns
+func (i *ReviewModel) review(ctx context.Context, id uint, function string, fn func(ctx context.Context, tx pgx.Tx, item ReviewItem) (ReviewStatus, error)) error {
+	tx, err := i.PostgreSQL.Begin(ctx)
+	if err != nil {
+		i.Logger.Error("Error starting transaction", zap.Error(err), zap.String("function", function))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	item, err := scanReviewItem(tx.QueryRow(ctx, "SELECT "+reviewItemColumns+" WHERE r.id = $1 FOR UPDATE OF r", id))
+	if err != nil {
+		return err
+	}
+	if item.Status != ReviewPending {
+		return ErrReviewNotPending
+	}
+	status, err := fn(ctx, tx, item)
+	if err != nil {
+		i.Logger.Error("Error reviewing item", zap.Error(err), zap.String("function", function))
+		return err
+	}
+	_, err = tx.Exec(ctx, "UPDATE review_item SET status = $2, reviewed_at = now() WHERE id = $1", id, string(status))
+	if err != nil {
+		i.Logger.Error("Error updating review item status", zap.Error(err), zap.String("function", function))
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// lockReplacement locks the ingredient rows are being moved onto, so it cannot be deleted
+// before the transaction commits, and returns ErrReplacementNotFound if it does not exist
+func lockReplacement(ctx context.Context, tx pgx.Tx, ingredientID uint) error {
+	var locked uint
+	err := tx.QueryRow(ctx, "SELECT id FROM ingredient WHERE id = $1 FOR UPDATE", ingredientID).Scan(&locked)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrReplacementNotFound
+	}
+	return err
+}
+
+// remapTranslation points a translation and the ad_ingredient rows created through it at another ingredient
+func remapTranslation(ctx context.Context, tx pgx.Tx, item ReviewItem, ingredientID uint) error {
+	if _, err := tx.Exec(ctx, "UPDATE translation SET ingredient_id = $2 WHERE name = $1", item.Name, ingredientID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, "UPDATE ad_ingredient SET ingredient_id = $3 WHERE name = $1 AND ingredient_id = $2",
+		item.Name, item.IngredientID, ingredientID); err != nil {
+		return err
+	}
+	_, err := tx.Exec(ctx, "UPDATE review_item SET ingredient_id = $2 WHERE id = $1", item.ID, ingredientID)
+	return err
+}
+
+// rejectIngredient moves every reference to a rejected ingredient onto replacementID, then deletes it
+func rejectIngredient(ctx context.Context, tx pgx.Tx, item ReviewItem, replacementID uint) error {
+	rejected := item.IngredientID
+	if _, err := reassignIngredient(ctx, tx, rejected, replacementID); err != nil {
+		return err
+	}
+	_, err := tx.Exec(ctx, "DELETE FROM ingredient WHERE id = $1", rejected)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return ErrIngredientInUse
+	}
+	return err
+}
@@ -0,0 +1,225 @@
+package models
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// ShoppingListModel defines a struct for shopping list service
+type ShoppingListModel struct {
+	PostgreSQL DB
+	Logger     zap.Logger
+}
+
+// ShoppingList defines a struct for shopping list data
+type ShoppingList struct {
+	ID        *uint  `json:"id"`
+	UserID    uint   `json:"user_id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+	Item      []ShoppingListItem
+}
+
+// ShoppingListItem is one ingredient to buy, optionally assigned to the store with the cheapest sale.
+// StoreName and Type are read from the joined store and ingredient rows and are not written.
+type ShoppingListItem struct {
+	ID           *uint    `json:"id"`
+	IngredientID *uint    `json:"ingredient_id"`
+	Name         string   `json:"name"`
+	Amount       *string  `json:"amount"`
+	Unit         *string  `json:"unit"`
+	StoreID      *uint    `json:"store_id"`
+	StoreName    *string  `json:"store_name"`
+	Price        *float32 `json:"price"`
+	Type         FoodType `json:"type"`
+	Checked      bool     `json:"checked"`
+}
+
+// ShoppingListItemUpdate changes some fields of a shopping list item; nil fields keep their
+// current value
+type ShoppingListItemUpdate struct {
+	ID      uint
+	Amount  *string
+	Unit    *string
+	StoreID *uint
+	Price   *float32
+	Checked *bool
+}
+
+func NewShoppingListModel(PostgreSQL DB, logger zap.Logger) *ShoppingListModel {
+	return &ShoppingListModel{
+		PostgreSQL: PostgreSQL,
+		Logger:     logger,
+	}
+}
+
+// Constructor for ShoppingList
+func NewShoppingList(id *uint, userID uint, name string, items []ShoppingListItem) *ShoppingList {
+	return &ShoppingList{
+		ID:     id,
+		UserID: userID,
+		Name:   name,
+		Item:   items,
+	}
+}
+
+// Constructor for ShoppingListItem
+func NewShoppingListItem(id *uint, ingredientID *uint, name string, amount *string, unit *string) *ShoppingListItem {
+	return &ShoppingListItem{
+		ID:           id,
+		IngredientID: ingredientID,
+		Name:         name,
+		Amount:       amount,
+		Unit:         unit,
+	}
+}
+
+// CreateShoppingList adds a shopping list and its items in one transaction and returns the list ID
+func (i *ShoppingListModel) CreateShoppingList(ctx context.Context, list ShoppingList) (id uint, err error) {
+	tx, err := i.PostgreSQL.Begin(ctx)
+	if err != nil {
+		i.Logger.Error("Error starting transaction", zap.Error(err), zap.String("function", "CreateShoppingList"))
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx,
+		"INSERT INTO shopping_list (user_id, name) VALUES ($1, $2) RETURNING id",
+		list.UserID, list.Name).Scan(&id)
+	if err != nil {
+		i.Logger.Error("Error adding shopping list to database", zap.Error(err))
+		return 0, err
+	}
+
+	rows := make([][]interface{}, len(list.Item))
+	for n, item := range list.Item {
+		rows[n] = []interface{}{id, item.IngredientID, item.Name, item.Amount, item.Unit, item.StoreID, item.Price, item.Checked}
+	}
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"shopping_list_item"},
+		[]string{"shopping_list_id", "ingredient_id", "name", "amount", "unit", "store_id", "price", "checked"},
+		pgx.CopyFromRows(rows),
+	)
+	if err != nil {
+		i.Logger.Error("Error adding shopping_list_items to database", zap.Error(err), zap.Uint("shopping_list", id))
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		i.Logger.Error("Error committing shopping list", zap.Error(err))
+		return 0, err
+	}
+	return id, nil
+}
+
+// GetShoppingList returns a shopping list with its items
+func (i *ShoppingListModel) GetShoppingList(ctx context.Context, id uint) (ShoppingList, error) {
+	var list ShoppingList
+	err := i.PostgreSQL.QueryRow(ctx,
+		"SELECT id, user_id, name, created_at::text FROM shopping_list WHERE id = $1", id).Scan(&list.ID, &list.UserID, &list.Name, &list.CreatedAt)
+	if err != nil {
+		i.Logger.Error("Error getting shopping list by ID", zap.Error(err))
+		return ShoppingList{}, err
+	}
+	rows, err := i.PostgreSQL.Query(ctx,
+		`SELECT sli.id, sli.ingredient_id, sli.name, sli.amount, sli.unit, sli.store_id, s.name, sli.price, COALESCE(ing.type, ''), sli.checked
+		FROM shopping_list_item sli
+		LEFT JOIN store s ON sli.store_id = s.id
+		LEFT JOIN ingredient ing ON sli.ingredient_id = ing.id
+		WHERE sli.shopping_list_id = $1 ORDER BY sli.id`, id)
+	if err != nil {
+		i.Logger.Error("Error getting shopping list items", zap.Error(err))
+		return ShoppingList{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var item ShoppingListItem
+		var typeStr string
+		err := rows.Scan(&item.ID, &item.IngredientID, &item.Name, &item.Amount, &item.Unit, &item.StoreID, &item.StoreName, &item.Price, &typeStr, &item.Checked)
+		if err != nil {
+			i.Logger.Error("Error scanning row", zap.Error(err), zap.String("function", "GetShoppingList"))
+			return ShoppingList{}, err
+		}
+		item.Type = ParseFoodType(typeStr)
+		list.Item = append(list.Item, item)
+	}
+	if err := rows.Err(); err != nil {
+		i.Logger.Error("Error processing rows", zap.Error(err), zap.String("function", "GetShoppingList"))
+		return ShoppingList{}, err
+	}
+	return list, nil
+}
+
+// GetShoppingLists returns a user's shopping lists without their items, newest first
+func (i *ShoppingListModel) GetShoppingLists(ctx context.Context, userID uint) ([]ShoppingList, error) {
+	var lists []ShoppingList
+	rows, err := i.PostgreSQL.Query(ctx,
+		"SELECT id, user_id, name, created_at::text FROM shopping_list WHERE user_id = $1 ORDER BY created_at DESC", userID)
+	if err != nil {
+		i.Logger.Error("Error getting shopping lists", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var list ShoppingList
+		err := rows.Scan(&list.ID, &list.UserID, &list.Name, &list.CreatedAt)
+		if err != nil {
+			i.Logger.Error("Error scanning row", zap.Error(err), zap.String("function", "GetShoppingLists"))
+			return nil, err
+		}
+		lists = append(lists, list)
+	}
+	if err := rows.Err(); err != nil {
+		i.Logger.Error("Error processing rows", zap.Error(err), zap.String("function", "GetShoppingLists"))
+		return nil, err
+	}
+	return lists, nil
+}
+
+// AddShoppingListItem adds an item to an existing shopping list and returns the item ID
+func (i *ShoppingListModel) AddShoppingListItem(ctx context.Context, listID uint, item ShoppingListItem) (id uint, err error) {
+	err = i.PostgreSQL.QueryRow(ctx,
+		`INSERT INTO shopping_list_item (shopping_list_id, ingredient_id, name, amount, unit, store_id, price, checked)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
+		listID, item.IngredientID, item.Name, item.Amount, item.Unit, item.StoreID, item.Price, item.Checked).Scan(&id)
+	if err != nil {
+		i.Logger.Error("Error adding shopping list item", zap.Error(err))
+		return 0, err
+	}
+	return id, nil
+}
+
+// UpdateShoppingListItem sets the fields of an item that update gives and keeps the rest.
+// It returns pgx.ErrNoRows when the item is not on the list.
+func (i *ShoppingListModel) UpdateShoppingListItem(ctx context.Context, listID uint, update ShoppingListItemUpdate) error {
+	tag, err := i.PostgreSQL.Exec(ctx,
+		`UPDATE shopping_list_item SET amount = COALESCE($1, amount), unit = COALESCE($2, unit),
+		store_id = COALESCE($3, store_id), price = COALESCE($4, price), checked = COALESCE($5, checked)
+		WHERE id = $6 AND shopping_list_id = $7`,
+		update.Amount, update.Unit, update.StoreID, update.Price, update.Checked, update.ID, listID)
+	if err != nil {
+		i.Logger.Error("Error updating shopping list item", zap.Error(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteShoppingListItem removes an item from a shopping list.
+// It returns pgx.ErrNoRows when the item is not on the list.
+func (i *ShoppingListModel) DeleteShoppingListItem(ctx context.Context, listID uint, itemID uint) error {
+	tag, err := i.PostgreSQL.Exec(ctx,
+		"DELETE FROM shopping_list_item WHERE id = $1 AND shopping_list_id = $2", itemID, listID)
+	if err != nil {
+		i.Logger.Error("Error deleting shopping list item", zap.Error(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
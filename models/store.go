@@ -2,6 +2,9 @@ package models
 
 import (
 	"context"
+	"regexp"
+
+	"backend/main/geo"
 
 	"go.uber.org/zap"
 	"github.com/jackc/pgx/v5"
@@ -10,7 +13,7 @@ import (
 
 // StoreModel defines a struct for Store service
 type StoreModel struct {
-	PostgreSQL *pgx.Conn
+	PostgreSQL DB
 	Logger    zap.Logger
 }
 
@@ -20,9 +23,69 @@ type Store struct {
 	Name     *string    `json:"name"`
 	Location string `json:"location"`
 	FlippMerchantName string `json:"flipp_merchant_name"`
+	// AdSource selects where the store's ads are read from: "flipp" (default) or "file"
+	AdSource string `json:"ad_source"`
+	// AdSourcePath is the circular file or drop directory for the "file" source
+	AdSourcePath *string `json:"ad_source_path"`
+	Address Address `json:"address"`
+	// Latitude and Longitude are both set or both nil
+	Latitude *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+}
+
+// Address is a store's structured street address
+type Address struct {
+	Street     string `json:"street"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+}
+
+// trailingZIP is a US ZIP code ending a one-line address, as migration 0010 backfills it
+var trailingZIP = regexp.MustCompile(`(\d{5})\s*$`)
+
+// PostalCode returns the store's postal code, falling back to a ZIP code at the end of
+// Location for stores created with only a one-line address
+func (s Store) PostalCode() string {
+	if s.Address.PostalCode != "" {
+		return s.Address.PostalCode
+	}
+	if match := trailingZIP.FindStringSubmatch(s.Location); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// Point returns the store's coordinates, if it has them
+func (s Store) Point() (geo.Point, bool) {
+	if s.Latitude == nil || s.Longitude == nil {
+		return geo.Point{}, false
+	}
+	return geo.Point{Latitude: *s.Latitude, Longitude: *s.Longitude}, true
 }
 
-func NewStoreModel(PostgreSQL *pgx.Conn, logger zap.Logger) *StoreModel {
+// Geocode fills in missing coordinates, city and state from the store's postal code and
+// reports whether the store has coordinates afterwards
+func (s *Store) Geocode(table *geo.Table) bool {
+	if _, ok := s.Point(); ok {
+		return true
+	}
+	place, ok := table.Lookup(s.Address.Country, s.PostalCode())
+	if !ok {
+		return false
+	}
+	s.Latitude, s.Longitude = &place.Latitude, &place.Longitude
+	if s.Address.City == "" {
+		s.Address.City = place.City
+	}
+	if s.Address.State == "" {
+		s.Address.State = place.State
+	}
+	return true
+}
+
+func NewStoreModel(PostgreSQL DB, logger zap.Logger) *StoreModel {
 	return &StoreModel{
 		PostgreSQL: PostgreSQL,
 		Logger: logger,
@@ -38,11 +101,19 @@ func NewStore(id *uint, name *string, location string) *Store {
 	}
 }
 
+const storeColumns = "s.id, s.name, s.location, s.street, s.city, s.state, s.postal_code, s.country, s.latitude, s.longitude"
+
+// scanStore reads a row selected with storeColumns
+func scanStore(row pgx.Row) (store Store, err error) {
+	err = row.Scan(&store.ID, &store.Name, &store.Location, &store.Address.Street, &store.Address.City,
+		&store.Address.State, &store.Address.PostalCode, &store.Address.Country, &store.Latitude, &store.Longitude)
+	return store, err
+}
+
 // GetStoreByID to find Store by ID from database
-func (i *StoreModel) GetStoreByID(id uint) (Store, error) {
-	var store Store
-	err := i.PostgreSQL.QueryRow(context.Background(), 
-		"SELECT id, name, location FROM store WHERE id = $1", id).Scan(&store.ID, &store.Name, &store.Location)
+func (i *StoreModel) GetStoreByID(ctx context.Context, id uint) (Store, error) {
+	store, err := scanStore(i.PostgreSQL.QueryRow(ctx, 
+		"SELECT "+storeColumns+" FROM store s WHERE s.id = $1", id))
 	if err != nil {
 		i.Logger.Error("Error getting store by ID", zap.Error(err))
 		return Store{}, err
@@ -51,13 +122,26 @@ func (i *StoreModel) GetStoreByID(id uint) (Store, error) {
 }
 
 // CreateStore to add Store to database
-func (i *StoreModel) CreateStore(store Store) error {
-	query := `INSERT INTO store (name, location) VALUES (@StoreName, @StoreLocation)`
+func (i *StoreModel) CreateStore(ctx context.Context, store Store) error {
+	query := `INSERT INTO store (name, location, flipp_merchant, ad_source, ad_source_path,
+		street, city, state, postal_code, country, latitude, longitude)
+		VALUES (@StoreName, @StoreLocation, @FlippMerchant, COALESCE(NULLIF(@AdSource, ''), 'flipp'), @AdSourcePath,
+		@Street, @City, @State, @PostalCode, COALESCE(NULLIF(@Country, ''), 'US'), @Latitude, @Longitude)`
 	args := pgx.NamedArgs{
 		"StoreName": store.Name,
 		"StoreLocation": store.Location,
+		"FlippMerchant": store.FlippMerchantName,
+		"AdSource": store.AdSource,
+		"AdSourcePath": store.AdSourcePath,
+		"Street": store.Address.Street,
+		"City": store.Address.City,
+		"State": store.Address.State,
+		"PostalCode": store.PostalCode(),
+		"Country": store.Address.Country,
+		"Latitude": store.Latitude,
+		"Longitude": store.Longitude,
 	  }
-	_, err := i.PostgreSQL.Exec(context.Background(), query, args)
+	_, err := i.PostgreSQL.Exec(ctx, query, args)
 	if err != nil {
 		i.Logger.Error("Error adding store to database", zap.Error(err))
 		return err
@@ -65,9 +149,19 @@ func (i *StoreModel) CreateStore(store Store) error {
 	return nil
 }
 
-func (i *StoreModel) GetExpiredAdStores() (stores []Store, err error) {
-	query := "select store_id, max(sale_end), flipp_merchant, location from ad join store on store_id = store.id group by store_id, flipp_merchant, location having max(sale_end) < current_date;"
-	rows, err := i.PostgreSQL.Query(context.Background(),
+// GetExpiredAdStores returns the stores due for new ads: the store has never had an ad,
+// every ad has ended, or one of several concurrent flyers has ended since the newest flyer
+// was ingested
+func (i *StoreModel) GetExpiredAdStores(ctx context.Context) (stores []Store, err error) {
+	query := `SELECT store.id, store.location, store.flipp_merchant, COALESCE(store.ad_source, 'flipp'), store.ad_source_path,
+		store.postal_code, store.country
+		FROM store LEFT JOIN ad ON ad.store_id = store.id
+		GROUP BY store.id
+		HAVING count(ad.id) = 0
+			OR max(ad.sale_end) < current_date
+			OR max(ad.sale_end) FILTER (WHERE ad.sale_end < current_date) > max(ad.sale_start)
+		ORDER BY store.id`
+	rows, err := i.PostgreSQL.Query(ctx,
 	query)
 	if err != nil {
 		i.Logger.Error("Error getting stores with expired ads", zap.Error(err), zap.String("function", "GetExpiredAdStores"))
@@ -76,7 +170,8 @@ func (i *StoreModel) GetExpiredAdStores() (stores []Store, err error) {
 	defer rows.Close()
 	for rows.Next() {
 		var s Store
-		err := rows.Scan(&s.ID, &s.Location, &s.FlippMerchantName)
+		err := rows.Scan(&s.ID, &s.Location, &s.FlippMerchantName, &s.AdSource, &s.AdSourcePath,
+			&s.Address.PostalCode, &s.Address.Country)
 		if err != nil {
 			i.Logger.Error("Error scanning row", zap.Error(err), zap.String("function", "GetExpiredAdStores"))
 			return nil, err
@@ -91,9 +186,9 @@ func (i *StoreModel) GetExpiredAdStores() (stores []Store, err error) {
 }
 
 // SubscribeStore to add to store_subscription
-func (i *StoreModel) SubscribeStore(userID uint, storeID uint) error {
+func (i *StoreModel) SubscribeStore(ctx context.Context, userID uint, storeID uint) error {
 	query := `INSERT INTO store_subscription (user_id, store_id) VALUES ($1, $2)`
-	_, err := i.PostgreSQL.Exec(context.Background(), query, userID, storeID)
+	_, err := i.PostgreSQL.Exec(ctx, query, userID, storeID)
 	if err != nil {
 		i.Logger.Error("Error subscribing to store", zap.Error(err))
 		return err
@@ -101,10 +196,22 @@ func (i *StoreModel) SubscribeStore(userID uint, storeID uint) error {
 	return nil
 }
 
+// IsSubscribed to check whether a user is subscribed to a store
+func (i *StoreModel) IsSubscribed(ctx context.Context, userID uint, storeID uint) (bool, error) {
+	var subscribed bool
+	err := i.PostgreSQL.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM store_subscription WHERE user_id = $1 AND store_id = $2)", userID, storeID).Scan(&subscribed)
+	if err != nil {
+		i.Logger.Error("Error checking store subscription", zap.Error(err))
+		return false, err
+	}
+	return subscribed, nil
+}
+
 // UnsubscribeStore to remove from store_subscription
-func (i *StoreModel) UnsubscribeStore(userID uint, storeID uint) error {
+func (i *StoreModel) UnsubscribeStore(ctx context.Context, userID uint, storeID uint) error {
 	query := `DELETE FROM store_subscription WHERE user_id = $1 AND store_id = $2`
-	_, err := i.PostgreSQL.Exec(context.Background(), query, userID, storeID)
+	_, err := i.PostgreSQL.Exec(ctx, query, userID, storeID)
 	if err != nil {
 		i.Logger.Error("Error unsubscribing from store", zap.Error(err))
 		return err
@@ -113,27 +220,66 @@ func (i *StoreModel) UnsubscribeStore(userID uint, storeID uint) error {
 }
 
 // GetSubscribedStores to get all stores subscribed by user given userID
-func (i *StoreModel) GetSubscribedStores(userID uint) ([]Store, error) {
-	var stores []Store
-	rows, err := i.PostgreSQL.Query(context.Background(),
-		"SELECT s.id, s.name, s.location FROM store_subscription ss INNER JOIN store s ON ss.store_id = s.id WHERE ss.user_id = $1", userID)
+func (i *StoreModel) GetSubscribedStores(ctx context.Context, userID uint) ([]Store, error) {
+	rows, err := i.PostgreSQL.Query(ctx,
+		"SELECT "+storeColumns+" FROM store_subscription ss INNER JOIN store s ON ss.store_id = s.id WHERE ss.user_id = $1 ORDER BY s.id", userID)
 	if err != nil {
 		i.Logger.Error("Error getting subscribed stores", zap.Error(err))
 		return nil, err
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var store Store
-		err := rows.Scan(&store.ID, &store.Name, &store.Location)
-		if err != nil {
-			i.Logger.Error("Error scanning subscribed store(s)", zap.Error(err))
-			return nil, err
-		}
-		stores = append(stores, store)
+	stores, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Store, error) { return scanStore(row) })
+	if err != nil {
+		i.Logger.Error("Error scanning subscribed store(s)", zap.Error(err))
+		return nil, err
 	}
+	return stores, nil
+}
 
+// GetStoresWithin returns the stores whose coordinates fall inside box, ordered by ID.
+// The box is matched through store_coordinates_idx; stores without coordinates are left
+// out, so they need placing with SetStoreCoordinates first.
+func (i *StoreModel) GetStoresWithin(ctx context.Context, box geo.Box) ([]Store, error) {
+	rows, err := i.PostgreSQL.Query(ctx, "SELECT "+storeColumns+` FROM store s
+		WHERE s.latitude BETWEEN $1 AND $2 AND s.longitude BETWEEN $3 AND $4
+		ORDER BY s.id`, box.Min.Latitude, box.Max.Latitude, box.Min.Longitude, box.Max.Longitude)
+	if err != nil {
+		i.Logger.Error("Error getting stores", zap.Error(err))
+		return nil, err
+	}
+	stores, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Store, error) { return scanStore(row) })
+	if err != nil {
+		i.Logger.Error("Error scanning stores", zap.Error(err))
+		return nil, err
+	}
 	return stores, nil
 }
 
+// GetUnplacedStores returns the stores without coordinates, ordered by ID
+func (i *StoreModel) GetUnplacedStores(ctx context.Context) ([]Store, error) {
+	rows, err := i.PostgreSQL.Query(ctx, "SELECT "+storeColumns+" FROM store s WHERE s.latitude IS NULL ORDER BY s.id")
+	if err != nil {
+		i.Logger.Error("Error getting unplaced stores", zap.Error(err))
+		return nil, err
+	}
+	stores, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Store, error) { return scanStore(row) })
+	if err != nil {
+		i.Logger.Error("Error scanning unplaced stores", zap.Error(err))
+		return nil, err
+	}
+	return stores, nil
+}
 
+// SetStoreCoordinates saves a geocoded store's coordinates, city and state
+func (i *StoreModel) SetStoreCoordinates(ctx context.Context, store Store) error {
+	tag, err := i.PostgreSQL.Exec(ctx,
+		"UPDATE store SET latitude = $2, longitude = $3, city = $4, state = $5 WHERE id = $1",
+		store.ID, store.Latitude, store.Longitude, store.Address.City, store.Address.State)
+	if err != nil {
+		i.Logger.Error("Error saving store coordinates", zap.Error(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
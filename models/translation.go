@@ -2,7 +2,6 @@ package models
 
 import (
 	"context"
-	"fmt"
 
 	"go.uber.org/zap"
 	"github.com/jackc/pgx/v5"
@@ -11,7 +10,7 @@ import (
 
 // TranslationModel defines a struct for Translation service
 type TranslationModel struct {
-	PostgreSQL *pgx.Conn
+	PostgreSQL DB
 	Logger    zap.Logger
 }
 
@@ -21,7 +20,7 @@ type Translation struct {
 	IngredientID uint `json:"ingredient_id"`
 }
 
-func NewTranslationModel(PostgreSQL *pgx.Conn, logger zap.Logger) *TranslationModel {
+func NewTranslationModel(PostgreSQL DB, logger zap.Logger) *TranslationModel {
 	return &TranslationModel{
 		PostgreSQL: PostgreSQL,
 		Logger: logger,
@@ -37,9 +36,9 @@ func NewTranslation(name string, ingredient_id uint) *Translation {
 }
 
 // GetTranslationByName to find ingredient_id by name from database
-func (i *TranslationModel) GetTranslationByName(name string) (int, error) {
+func (i *TranslationModel) GetTranslationByName(ctx context.Context, name string) (int, error) {
 	var ingredientID int
-	err := i.PostgreSQL.QueryRow(context.Background(), 
+	err := i.PostgreSQL.QueryRow(ctx, 
 		"SELECT ingredient_id FROM translation WHERE name = $1", name).Scan(&ingredientID)
 	if err == pgx.ErrNoRows {
 		return -1, nil
@@ -51,25 +50,55 @@ func (i *TranslationModel) GetTranslationByName(name string) (int, error) {
 	return ingredientID, nil
 }
 
-// CreateTranslations to add Translations to database
-func (i *TranslationModel) CreateTranslations(translations []Translation) error {
-	fmt.Println(translations)
-	rows := [][]interface{}{}
-	for _, translation := range translations {
-		rows = append(rows, []interface{}{translation.Name, translation.IngredientID})
+// CreateTranslations adds the translations whose names are not translated yet. It returns
+// the ingredient every name is translated to afterwards and which names it added; a name
+// another caller translated first keeps that translation, so concurrent callers can add
+// overlapping names.
+func (i *TranslationModel) CreateTranslations(ctx context.Context, translations []Translation) (stored map[string]uint, added map[string]bool, err error) {
+	names := make([]string, len(translations))
+	ingredientIDs := make([]uint, len(translations))
+	for n, translation := range translations {
+		names[n], ingredientIDs[n] = translation.Name, translation.IngredientID
 	}
 
-	copyCount, err := i.PostgreSQL.CopyFrom(
-		context.Background(),
-		pgx.Identifier{"translation"},
-		[]string{"name", "ingredient_id"},
-		pgx.CopyFromRows(rows),
-	)
+	rows, err := i.PostgreSQL.Query(ctx,
+		`INSERT INTO translation (name, ingredient_id)
+		SELECT * FROM unnest($1::text[], $2::int[])
+		ON CONFLICT (name) DO NOTHING RETURNING name`, names, ingredientIDs)
 	if err != nil {
 		i.Logger.Error("Error creating translations in database", zap.Error(err), zap.String("function", "CreateTranslations"))
-		return err
+		return nil, nil, err
 	}
+	addedNames, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		i.Logger.Error("Error creating translations in database", zap.Error(err), zap.String("function", "CreateTranslations"))
+		return nil, nil, err
+	}
+	added = make(map[string]bool, len(addedNames))
+	for _, name := range addedNames {
+		added[name] = true
+	}
+	i.Logger.Info("Translation added to database", zap.Int("rows_added", len(addedNames)))
 
-	i.Logger.Info("Translation added to database", zap.Int64("rows_added", copyCount))
-	return nil
-}
\ No newline at end of file
+	rows, err = i.PostgreSQL.Query(ctx, "SELECT name, ingredient_id FROM translation WHERE name = ANY($1)", names)
+	if err != nil {
+		i.Logger.Error("Error getting translations", zap.Error(err), zap.String("function", "CreateTranslations"))
+		return nil, nil, err
+	}
+	defer rows.Close()
+	stored = make(map[string]uint, len(names))
+	for rows.Next() {
+		var name string
+		var ingredientID uint
+		if err := rows.Scan(&name, &ingredientID); err != nil {
+			i.Logger.Error("Error scanning row", zap.Error(err), zap.String("function", "CreateTranslations"))
+			return nil, nil, err
+		}
+		stored[name] = ingredientID
+	}
+	if err := rows.Err(); err != nil {
+		i.Logger.Error("Error processing rows", zap.Error(err), zap.String("function", "CreateTranslations"))
+		return nil, nil, err
+	}
+	return stored, added, nil
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"backend/main/units"
+)
+
+
+// normalizeUnit stores unit aliases such as "Tablespoons" under their canonical name
+func normalizeUnit(unit *string) *string {
+	if unit == nil || *unit == "" {
+		return unit
+	}
+	normalized := units.NormalizeUnit(*unit)
+	return &normalized
+}
@@ -10,7 +10,7 @@ import (
 
 // UserModel defines a struct for user service
 type UserModel struct {
-	PostgreSQL *pgx.Conn
+	PostgreSQL DB
 	Logger    zap.Logger
 }
 
@@ -21,7 +21,7 @@ type User struct {
 	Email string `json:"email"`
 }
 
-func NewUserModel(PostgreSQL *pgx.Conn, logger zap.Logger) *UserModel {
+func NewUserModel(PostgreSQL DB, logger zap.Logger) *UserModel {
 	return &UserModel{
 		PostgreSQL: PostgreSQL,
 		Logger: logger,
@@ -38,9 +38,9 @@ func NewUser(id *uint, name string, email string) *User {
 }
 
 // GetUserByID to find user by ID from database
-func (i *UserModel) GetUserByID(id uint) (User, error) {
+func (i *UserModel) GetUserByID(ctx context.Context, id uint) (User, error) {
 	var user User
-	err := i.PostgreSQL.QueryRow(context.Background(), 
+	err := i.PostgreSQL.QueryRow(ctx, 
 		"SELECT id, name, email FROM grocery_user WHERE id = $1", id).Scan(&user.ID, &user.Name, &user.Email)
 	if err != nil {
 		i.Logger.Error("Error getting user by ID", zap.Error(err))
@@ -50,13 +50,13 @@ func (i *UserModel) GetUserByID(id uint) (User, error) {
 }
 
 // CreateUser to add user to database
-func (i *UserModel) CreateUser(user User) error {
+func (i *UserModel) CreateUser(ctx context.Context, user User) error {
 	query := `INSERT INTO grocery_user (name, email) VALUES (@userName, @userEmail)`
 	args := pgx.NamedArgs{
 		"userName": user.Name,
 		"userEmail": user.Email,
 	  }
-	_, err := i.PostgreSQL.Exec(context.Background(), query, args)
+	_, err := i.PostgreSQL.Exec(ctx, query, args)
 	if err != nil {
 		i.Logger.Error("Error adding user to database", zap.Error(err))
 		return err
@@ -65,8 +65,8 @@ func (i *UserModel) CreateUser(user User) error {
 }
 
 // AddUserSubscriptionStore to add a user's subscription to a grocery store
-func (i *UserModel) AddUserSubscriptionStore(userID uint, storeID uint) error {
-	_, err := i.PostgreSQL.Exec(context.Background(), 
+func (i *UserModel) AddUserSubscriptionStore(ctx context.Context, userID uint, storeID uint) error {
+	_, err := i.PostgreSQL.Exec(ctx, 
 		"INSERT INTO store_subscription (user_id, store_id) VALUES ($1, $2)", userID, storeID)
 	if err != nil {
 		i.Logger.Error("Error adding user subscription to store", zap.Error(err))
@@ -0,0 +1,332 @@
+// Package normalize maps raw ad item names such as "Fresh Antibiotic Free Family Pack
+// Chicken Breast, 2 lb" onto existing ingredient names without calling an LLM.
+// Names it cannot resolve are left for the LLM translation step.
+package normalize
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MatchKind records how a name was resolved
+type MatchKind int
+
+const (
+	// NoMatch means the name is left for the LLM
+	NoMatch MatchKind = iota
+	// ExactMatch means the cleaned name, or its singular/plural form, is an ingredient name
+	ExactMatch
+	// FuzzyMatch means the cleaned name is within edit distance of an ingredient name
+	FuzzyMatch
+)
+
+var matchKindName = map[MatchKind]string{
+	NoMatch:    "none",
+	ExactMatch: "exact",
+	FuzzyMatch: "fuzzy",
+}
+
+func (k MatchKind) String() string {
+	return matchKindName[k]
+}
+
+// DefaultMinSimilarity is the lowest similarity, 1 - distance/length, accepted as a fuzzy match
+const DefaultMinSimilarity = 0.85
+
+// MarketingPhrases are stripped from ad item names. Longer phrases are removed first so
+// "antibiotic free" goes before "free".
+var MarketingPhrases = []string{
+	"fresh", "family pack", "value pack", "family size", "party size", "club pack", "jumbo pack",
+	"antibiotic free", "no antibiotics ever", "raised without antibiotics", "hormone free",
+	"no hormones added", "cage free", "free range", "grass fed", "pasture raised",
+	"organic", "all natural", "natural", "non-gmo", "non gmo", "gluten free", "gmo free",
+	"premium", "select", "selected", "choice", "usda choice", "usda prime", "usda", "grade a",
+	"wild caught", "farm raised", "locally grown", "local", "new", "assorted", "assorted varieties",
+	"varieties", "variety", "original", "classic", "homestyle", "extra large", "large", "medium",
+	"small", "jumbo", "mini", "boneless", "skinless", "bone-in", "thin sliced", "thick sliced",
+	"thin cut", "thick cut", "sliced", "whole", "ripe", "ready to eat", "ready-to-eat", "bulk",
+	"loose", "bagged", "bag", "package", "pkg", "per lb", "per pound", "each", "ea",
+}
+
+// Brands are stripped from ad item names
+var Brands = []string{
+	"365", "365 by whole foods market", "signature select", "signature selects", "signature farms",
+	"o organics", "open nature", "lucerne", "waterfront bistro", "great value", "kirkland signature",
+	"kirkland", "trader joe's", "good & gather", "market pantry", "simple truth", "simple truth organic",
+	"kroger", "private selection", "nature's promise", "wegmans", "h-e-b", "heb", "publix",
+	"marketside", "member's mark", "dole", "chiquita", "del monte", "driscoll's", "cal-organic",
+	"earthbound farm", "taylor farms", "fresh express", "tyson", "perdue", "foster farms",
+	"oscar mayer", "hillshire farm", "hormel", "smithfield", "johnsonville", "ball park",
+	"kraft", "sargento", "tillamook", "land o lakes", "land o'lakes", "cabot", "philadelphia",
+	"chobani", "yoplait", "dannon", "fage", "horizon organic", "horizon", "fairlife",
+	"barilla", "ronzoni", "heinz", "hunt's", "campbell's", "progresso", "general mills",
+	"kellogg's", "post", "quaker", "nabisco", "pepperidge farm", "sara lee", "nature's own",
+	"dave's killer bread", "bimbo", "oroweat", "ben & jerry's", "haagen-dazs", "breyers",
+}
+
+// Qualifiers are leading words dropped when the full name is not an ingredient, so
+// "green bell peppers" resolves to "bell peppers" but "peanut butter" never becomes "butter"
+var Qualifiers = map[string]bool{
+	"green": true, "red": true, "yellow": true, "orange": true, "white": true, "purple": true,
+	"gold": true, "golden": true, "black": true, "baby": true, "petite": true, "hass": true,
+	"navel": true, "seedless": true, "roma": true, "vine": true, "on-the-vine": true,
+	"russet": true, "honeycrisp": true, "gala": true, "fuji": true, "granny": true, "smith": true,
+	"cut": true, "chopped": true, "diced": true, "shredded": true, "crumbled": true,
+	"frozen": true, "raw": true, "cooked": true,
+}
+
+// Uncountable nouns keep the same form in the singular and plural
+var Uncountable = map[string]bool{
+	"rice": true, "corn": true, "garlic": true, "broccoli": true, "spinach": true, "kale": true,
+	"lettuce": true, "celery": true, "asparagus": true, "cauliflower": true, "bok choy": true,
+	"fish": true, "salmon": true, "tuna": true, "cod": true, "tilapia": true, "shrimp": true,
+	"beef": true, "pork": true, "chicken": true, "turkey": true, "lamb": true, "bacon": true,
+	"milk": true, "cheese": true, "butter": true, "yogurt": true, "cream": true, "bread": true,
+	"pasta": true, "flour": true, "sugar": true, "salt": true, "juice": true, "water": true,
+	"hummus": true, "couscous": true, "molasses": true, "swiss": true,
+}
+
+// irregularPlurals maps singular nouns onto plurals the suffix rules get wrong
+var irregularPlurals = map[string]string{
+	"potato": "potatoes", "tomato": "tomatoes", "mango": "mangoes", "avocado": "avocados",
+	"leaf": "leaves", "loaf": "loaves", "knife": "knives", "half": "halves", "radish": "radishes",
+	"peach": "peaches", "squash": "squashes", "goose": "geese",
+}
+
+var irregularSingulars = func() map[string]string {
+	m := make(map[string]string, len(irregularPlurals))
+	for singular, plural := range irregularPlurals {
+		m[plural] = singular
+	}
+	return m
+}()
+
+var (
+	// sizePattern matches package sizes like "16 oz", "2-lb", "12 ct", "6 pk", "1.5 l", "x 2"
+	sizePattern = regexp.MustCompile(`\b\d+(?:[.,/]\d+)?\s*-?\s*(?:fl\.?\s*oz|oz|ounces?|lbs?|pounds?|g|grams?|kg|ml|l|liters?|litres?|ct|count|pk|pack|packs|pc|pcs|pieces?|dozen|doz|qt|quarts?|gal|gallons?|pt|pints?)\b\.?|\bx\s*\d+\b|\b\d+(?:\.\d+)?\b`)
+	// parenthetical matches "(approx. 2 lb)"-style notes
+	parenthetical = regexp.MustCompile(`\([^)]*\)|\[[^\]]*\]`)
+	// punctuation is everything but letters, digits, spaces, apostrophes, ampersands, slashes and hyphens
+	punctuation = regexp.MustCompile(`[^a-z0-9\s'&/-]+`)
+	// multiItem matches names that list more than one food, which the LLM generalizes
+	multiItem = regexp.MustCompile(`\band\b|&|\bor\b|/`)
+)
+
+// Match is the result of normalizing one ad item name
+type Match struct {
+	// Cleaned is the name after marketing words, sizes and brands are stripped
+	Cleaned      string
+	Ingredient   string
+	IngredientID uint
+	Kind         MatchKind
+	// Similarity is 1 for exact matches and the edit-distance similarity for fuzzy ones
+	Similarity float64
+}
+
+// Stats counts how many names were resolved without the LLM in one run
+type Stats struct {
+	Total int
+	Exact int
+	Fuzzy int
+	LLM   int
+}
+
+// Add records one match
+func (s *Stats) Add(m Match) {
+	s.Total++
+	switch m.Kind {
+	case ExactMatch:
+		s.Exact++
+	case FuzzyMatch:
+		s.Fuzzy++
+	default:
+		s.LLM++
+	}
+}
+
+// HitRate is the fraction of names resolved without the LLM
+func (s Stats) HitRate() float64 {
+	if s.Total == 0 {
+		return 0
+	}
+	return float64(s.Exact+s.Fuzzy) / float64(s.Total)
+}
+
+// Normalizer resolves ad item names against a fixed set of ingredient names
+type Normalizer struct {
+	ingredients   map[string]uint
+	names         []string
+	phrases       []string
+	MinSimilarity float64
+}
+
+// New returns a Normalizer over an ingredient name to ID map, as returned by
+// IngredientModel.GetAllIngredientsNameID, stripping MarketingPhrases and Brands
+func New(ingredients map[string]uint) *Normalizer {
+	n := &Normalizer{
+		ingredients:   make(map[string]uint, len(ingredients)),
+		MinSimilarity: DefaultMinSimilarity,
+	}
+	for name, id := range ingredients {
+		key := strings.ToLower(strings.TrimSpace(name))
+		n.ingredients[key] = id
+		n.names = append(n.names, key)
+	}
+	sort.Strings(n.names)
+	n.phrases = append(append(n.phrases, MarketingPhrases...), Brands...)
+	// strip the longest phrases first so "antibiotic free" is not left as "antibiotic"
+	sort.SliceStable(n.phrases, func(a, b int) bool {
+		return len(n.phrases[a]) > len(n.phrases[b])
+	})
+	return n
+}
+
+// Clean lowercases an ad item name and strips sizes, brands and marketing words
+func (n *Normalizer) Clean(name string) string {
+	s := strings.ToLower(name)
+	s = strings.NewReplacer("’", "'", "®", " ", "™", " ", ",", " ").Replace(s)
+	s = parenthetical.ReplaceAllString(s, " ")
+	s = sizePattern.ReplaceAllString(s, " ")
+	s = punctuation.ReplaceAllString(s, " ")
+	s = " " + strings.Join(strings.Fields(s), " ") + " "
+	for _, phrase := range n.phrases {
+		s = strings.ReplaceAll(s, " "+phrase+" ", " ")
+	}
+	s = strings.Trim(strings.Join(strings.Fields(s), " "), "-'&/ ")
+	return s
+}
+
+// Match resolves an ad item name. It tries the cleaned name, then its singular and plural
+// forms, since fruit and vegetable ingredients are stored plural and everything else
+// singular, then drops leading qualifiers ("green bell peppers" -> "bell peppers"), and
+// finally falls back to a fuzzy match. Names listing several foods are never matched.
+func (n *Normalizer) Match(name string) Match {
+	cleaned := n.Clean(name)
+	m := Match{Cleaned: cleaned}
+	if cleaned == "" || multiItem.MatchString(cleaned) {
+		return m
+	}
+	words := strings.Fields(cleaned)
+	for start := range words {
+		if start > 0 && !Qualifiers[words[start-1]] {
+			break
+		}
+		candidate := strings.Join(words[start:], " ")
+		for _, form := range forms(candidate) {
+			if id, ok := n.ingredients[form]; ok {
+				m.Ingredient, m.IngredientID, m.Kind, m.Similarity = form, id, ExactMatch, 1
+				return m
+			}
+		}
+	}
+	best, bestSimilarity := "", 0.0
+	for _, form := range forms(cleaned) {
+		for _, ingredient := range n.names {
+			if similarity := Similarity(form, ingredient); similarity > bestSimilarity {
+				best, bestSimilarity = ingredient, similarity
+			}
+		}
+	}
+	if best != "" && bestSimilarity >= n.MinSimilarity {
+		m.Ingredient, m.IngredientID, m.Kind, m.Similarity = best, n.ingredients[best], FuzzyMatch, bestSimilarity
+	}
+	return m
+}
+
+// forms returns the name followed by its singular and plural forms
+func forms(name string) []string {
+	result := []string{name}
+	for _, form := range []string{Singular(name), Plural(name)} {
+		if form != name && form != result[len(result)-1] {
+			result = append(result, form)
+		}
+	}
+	return result
+}
+
+// Plural pluralizes the last word of a name
+func Plural(name string) string {
+	head, last := splitLast(name)
+	if Uncountable[name] || Uncountable[last] {
+		return name
+	}
+	if _, ok := irregularSingulars[last]; ok {
+		return name
+	}
+	if plural, ok := irregularPlurals[last]; ok {
+		return head + plural
+	}
+	switch {
+	case strings.HasSuffix(last, "ss"), strings.HasSuffix(last, "sh"), strings.HasSuffix(last, "ch"),
+		strings.HasSuffix(last, "x"), strings.HasSuffix(last, "z"):
+		return head + last + "es"
+	case strings.HasSuffix(last, "s"):
+		return name
+	case strings.HasSuffix(last, "y") && len(last) > 1 && !isVowel(last[len(last)-2]):
+		return head + last[:len(last)-1] + "ies"
+	}
+	return head + last + "s"
+}
+
+// Singular singularizes the last word of a name
+func Singular(name string) string {
+	head, last := splitLast(name)
+	if Uncountable[name] || Uncountable[last] {
+		return name
+	}
+	if singular, ok := irregularSingulars[last]; ok {
+		return head + singular
+	}
+	switch {
+	case strings.HasSuffix(last, "ies") && len(last) > 3:
+		return head + last[:len(last)-3] + "y"
+	case strings.HasSuffix(last, "sses"), strings.HasSuffix(last, "shes"), strings.HasSuffix(last, "ches"),
+		strings.HasSuffix(last, "xes"), strings.HasSuffix(last, "zes"):
+		return head + last[:len(last)-2]
+	case strings.HasSuffix(last, "ss"), strings.HasSuffix(last, "us"):
+		return name
+	case strings.HasSuffix(last, "s") && len(last) > 1:
+		return head + last[:len(last)-1]
+	}
+	return name
+}
+
+func splitLast(name string) (head string, last string) {
+	i := strings.LastIndex(name, " ")
+	return name[:i+1], name[i+1:]
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("aeiou", c) >= 0
+}
+
+// Similarity returns 1 - levenshtein(a, b) / max(len(a), len(b))
+func Similarity(a string, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a []rune, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
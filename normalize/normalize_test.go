@@ -0,0 +1,115 @@
+package normalize
+
+import (
+	"testing"
+)
+
+var testIngredients = map[string]uint{
+	"bell peppers":   1,
+	"chicken breast": 2,
+	"avocados":       3,
+	"ground beef":    4,
+	"strawberries":   5,
+	"milk":           6,
+	"butter":         7,
+	"tomatoes":       8,
+	"salmon":         9,
+	"cheddar cheese": 10,
+}
+
+func TestClean(t *testing.T) {
+	n := New(testIngredients)
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Fresh Green Bell Pepper", "green bell pepper"},
+		{"Fresh Antibiotic Free Family Pack Thin Sliced Chicken Breast", "chicken breast"},
+		{"Signature Farms Ground Beef 80% Lean, 1 lb", "ground beef lean"},
+		{"Driscoll's Strawberries 16 oz", "strawberries"},
+		{"Lucerne Milk (1 Gallon)", "milk"},
+		{"Organic Hass Avocados, 4 ct", "hass avocados"},
+		{"Wild Caught Salmon Fillets", "salmon fillets"},
+		{"Tillamook® Cheddar Cheese 2-lb", "cheddar cheese"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := n.Clean(tt.name); got != tt.want {
+				t.Errorf("Clean(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	n := New(testIngredients)
+	tests := []struct {
+		name     string
+		wantID   uint
+		wantKind MatchKind
+	}{
+		{"Fresh Green Bell Pepper", 1, ExactMatch},
+		{"Fresh Antibiotic Free Family Pack Thin Sliced Chicken Breast", 2, ExactMatch},
+		{"Boneless Skinless Chicken Breasts", 2, ExactMatch},
+		{"Organic Hass Avocados, 4 ct", 3, ExactMatch},
+		{"Fresh Avocado", 3, ExactMatch},
+		{"Driscoll's Strawberry", 5, ExactMatch},
+		{"Roma Tomato", 8, ExactMatch},
+		{"Lucerne Milk (1 Gallon)", 6, ExactMatch},
+		{"Ground Beeff", 4, FuzzyMatch},
+		{"Cheddar Chese", 10, FuzzyMatch},
+		{"Peanut Butter", 0, NoMatch},
+		{"Green Peppers and Cucumbers", 0, NoMatch},
+		{"Salmon & Ocean Perch", 0, NoMatch},
+		{"Family Pack", 0, NoMatch},
+		{"Paper Towels", 0, NoMatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := n.Match(tt.name)
+			if got.Kind != tt.wantKind || got.IngredientID != tt.wantID {
+				t.Errorf("Match(%q) = %+v, want id %d kind %v", tt.name, got, tt.wantID, tt.wantKind)
+			}
+		})
+	}
+}
+
+func TestPluralSingular(t *testing.T) {
+	tests := []struct {
+		singular string
+		plural   string
+	}{
+		{"bell pepper", "bell peppers"},
+		{"strawberry", "strawberries"},
+		{"tomato", "tomatoes"},
+		{"avocado", "avocados"},
+		{"peach", "peaches"},
+		{"radish", "radishes"},
+		{"box", "boxes"},
+		{"spinach", "spinach"},
+		{"ground beef", "ground beef"},
+		{"chicken thigh", "chicken thighs"},
+	}
+	for _, tt := range tests {
+		if got := Plural(tt.singular); got != tt.plural {
+			t.Errorf("Plural(%q) = %q, want %q", tt.singular, got, tt.plural)
+		}
+		if got := Singular(tt.plural); got != tt.singular {
+			t.Errorf("Singular(%q) = %q, want %q", tt.plural, got, tt.singular)
+		}
+	}
+}
+
+func TestStats(t *testing.T) {
+	n := New(testIngredients)
+	var stats Stats
+	for _, name := range []string{"Fresh Avocados", "Ground Beeff", "Paper Towels", "Salmon"} {
+		stats.Add(n.Match(name))
+	}
+	if stats.Total != 4 || stats.Exact != 2 || stats.Fuzzy != 1 || stats.LLM != 1 {
+		t.Fatalf("stats = %+v", stats)
+	}
+	if got := stats.HitRate(); got != 0.75 {
+		t.Errorf("HitRate() = %v, want 0.75", got)
+	}
+}
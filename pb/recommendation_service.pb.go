@@ -0,0 +1,1063 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.20.3
+// source: proto/recommendation_service.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetRecipeRecommendationsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Maximum number of recipes to return, 0 returns every recipe with a match
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Levels up or down the ingredient hierarchy a sale may substitute for a recipe
+	// ingredient, e.g. 1 lets "chicken" match "chicken breast". Defaults to 1, 0 is exact.
+	SubstitutionDepth *int32 `protobuf:"varint,3,opt,name=substitution_depth,json=substitutionDepth,proto3,oneof" json:"substitution_depth,omitempty"`
+	// Rank recipes with in-season matches higher, an in-season match counting as 1.5
+	SeasonalBoost bool `protobuf:"varint,4,opt,name=seasonal_boost,json=seasonalBoost,proto3" json:"seasonal_boost,omitempty"`
+	// Month from 1 to 12 used for in_season, defaults to the current month
+	Month int32 `protobuf:"varint,5,opt,name=month,proto3" json:"month,omitempty"`
+	// "north" (default) or "south"
+	Hemisphere    string `protobuf:"bytes,6,opt,name=hemisphere,proto3" json:"hemisphere,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecipeRecommendationsRequest) Reset() {
+	*x = GetRecipeRecommendationsRequest{}
+	mi := &file_proto_recommendation_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecipeRecommendationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecipeRecommendationsRequest) ProtoMessage() {}
+
+func (x *GetRecipeRecommendationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recommendation_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecipeRecommendationsRequest.ProtoReflect.Descriptor instead.
+func (*GetRecipeRecommendationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_recommendation_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetRecipeRecommendationsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetRecipeRecommendationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetRecipeRecommendationsRequest) GetSubstitutionDepth() int32 {
+	if x != nil && x.SubstitutionDepth != nil {
+		return *x.SubstitutionDepth
+	}
+	return 0
+}
+
+func (x *GetRecipeRecommendationsRequest) GetSeasonalBoost() bool {
+	if x != nil {
+		return x.SeasonalBoost
+	}
+	return false
+}
+
+func (x *GetRecipeRecommendationsRequest) GetMonth() int32 {
+	if x != nil {
+		return x.Month
+	}
+	return 0
+}
+
+func (x *GetRecipeRecommendationsRequest) GetHemisphere() string {
+	if x != nil {
+		return x.Hemisphere
+	}
+	return ""
+}
+
+type GetRecipeRecommendationsResponse struct {
+	state           protoimpl.MessageState  `protogen:"open.v1"`
+	Recommendations []*RecipeRecommendation `protobuf:"bytes,1,rep,name=recommendations,proto3" json:"recommendations,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetRecipeRecommendationsResponse) Reset() {
+	*x = GetRecipeRecommendationsResponse{}
+	mi := &file_proto_recommendation_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecipeRecommendationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecipeRecommendationsResponse) ProtoMessage() {}
+
+func (x *GetRecipeRecommendationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recommendation_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecipeRecommendationsResponse.ProtoReflect.Descriptor instead.
+func (*GetRecipeRecommendationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_recommendation_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetRecipeRecommendationsResponse) GetRecommendations() []*RecipeRecommendation {
+	if x != nil {
+		return x.Recommendations
+	}
+	return nil
+}
+
+type RecipeRecommendation struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RecipeId        int32                  `protobuf:"varint,1,opt,name=recipe_id,json=recipeId,proto3" json:"recipe_id,omitempty"`
+	Title           string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Link            *string                `protobuf:"bytes,3,opt,name=link,proto3,oneof" json:"link,omitempty"`
+	IngredientCount int32                  `protobuf:"varint,4,opt,name=ingredient_count,json=ingredientCount,proto3" json:"ingredient_count,omitempty"`
+	MatchedCount    int32                  `protobuf:"varint,5,opt,name=matched_count,json=matchedCount,proto3" json:"matched_count,omitempty"`
+	// Sum of original_price - price over matched ingredients that report both
+	Savings            float32              `protobuf:"fixed32,6,opt,name=savings,proto3" json:"savings,omitempty"`
+	Score              float32              `protobuf:"fixed32,7,opt,name=score,proto3" json:"score,omitempty"`
+	MatchedIngredients []*MatchedIngredient `protobuf:"bytes,8,rep,name=matched_ingredients,json=matchedIngredients,proto3" json:"matched_ingredients,omitempty"`
+	InSeasonCount      int32                `protobuf:"varint,9,opt,name=in_season_count,json=inSeasonCount,proto3" json:"in_season_count,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RecipeRecommendation) Reset() {
+	*x = RecipeRecommendation{}
+	mi := &file_proto_recommendation_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeRecommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeRecommendation) ProtoMessage() {}
+
+func (x *RecipeRecommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recommendation_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeRecommendation.ProtoReflect.Descriptor instead.
+func (*RecipeRecommendation) Descriptor() ([]byte, []int) {
+	return file_proto_recommendation_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RecipeRecommendation) GetRecipeId() int32 {
+	if x != nil {
+		return x.RecipeId
+	}
+	return 0
+}
+
+func (x *RecipeRecommendation) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *RecipeRecommendation) GetLink() string {
+	if x != nil && x.Link != nil {
+		return *x.Link
+	}
+	return ""
+}
+
+func (x *RecipeRecommendation) GetIngredientCount() int32 {
+	if x != nil {
+		return x.IngredientCount
+	}
+	return 0
+}
+
+func (x *RecipeRecommendation) GetMatchedCount() int32 {
+	if x != nil {
+		return x.MatchedCount
+	}
+	return 0
+}
+
+func (x *RecipeRecommendation) GetSavings() float32 {
+	if x != nil {
+		return x.Savings
+	}
+	return 0
+}
+
+func (x *RecipeRecommendation) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *RecipeRecommendation) GetMatchedIngredients() []*MatchedIngredient {
+	if x != nil {
+		return x.MatchedIngredients
+	}
+	return nil
+}
+
+func (x *RecipeRecommendation) GetInSeasonCount() int32 {
+	if x != nil {
+		return x.InSeasonCount
+	}
+	return 0
+}
+
+type MatchedIngredient struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	IngredientId     int32                  `protobuf:"varint,1,opt,name=ingredient_id,json=ingredientId,proto3" json:"ingredient_id,omitempty"`
+	RecipeIngredient string                 `protobuf:"bytes,2,opt,name=recipe_ingredient,json=recipeIngredient,proto3" json:"recipe_ingredient,omitempty"`
+	AdItemName       string                 `protobuf:"bytes,3,opt,name=ad_item_name,json=adItemName,proto3" json:"ad_item_name,omitempty"`
+	StoreId          int32                  `protobuf:"varint,4,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"`
+	StoreName        string                 `protobuf:"bytes,5,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Price            *float32               `protobuf:"fixed32,6,opt,name=price,proto3,oneof" json:"price,omitempty"`
+	OriginalPrice    *float32               `protobuf:"fixed32,7,opt,name=original_price,json=originalPrice,proto3,oneof" json:"original_price,omitempty"`
+	Sale             *string                `protobuf:"bytes,8,opt,name=sale,proto3,oneof" json:"sale,omitempty"`
+	// Levels between the recipe ingredient and the ingredient on sale, 0 for an exact match
+	SubstitutionDistance int32 `protobuf:"varint,9,opt,name=substitution_distance,json=substitutionDistance,proto3" json:"substitution_distance,omitempty"`
+	InSeason             bool  `protobuf:"varint,10,opt,name=in_season,json=inSeason,proto3" json:"in_season,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *MatchedIngredient) Reset() {
+	*x = MatchedIngredient{}
+	mi := &file_proto_recommendation_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchedIngredient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchedIngredient) ProtoMessage() {}
+
+func (x *MatchedIngredient) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recommendation_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchedIngredient.ProtoReflect.Descriptor instead.
+func (*MatchedIngredient) Descriptor() ([]byte, []int) {
+	return file_proto_recommendation_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MatchedIngredient) GetIngredientId() int32 {
+	if x != nil {
+		return x.IngredientId
+	}
+	return 0
+}
+
+func (x *MatchedIngredient) GetRecipeIngredient() string {
+	if x != nil {
+		return x.RecipeIngredient
+	}
+	return ""
+}
+
+func (x *MatchedIngredient) GetAdItemName() string {
+	if x != nil {
+		return x.AdItemName
+	}
+	return ""
+}
+
+func (x *MatchedIngredient) GetStoreId() int32 {
+	if x != nil {
+		return x.StoreId
+	}
+	return 0
+}
+
+func (x *MatchedIngredient) GetStoreName() string {
+	if x != nil {
+		return x.StoreName
+	}
+	return ""
+}
+
+func (x *MatchedIngredient) GetPrice() float32 {
+	if x != nil && x.Price != nil {
+		return *x.Price
+	}
+	return 0
+}
+
+func (x *MatchedIngredient) GetOriginalPrice() float32 {
+	if x != nil && x.OriginalPrice != nil {
+		return *x.OriginalPrice
+	}
+	return 0
+}
+
+func (x *MatchedIngredient) GetSale() string {
+	if x != nil && x.Sale != nil {
+		return *x.Sale
+	}
+	return ""
+}
+
+func (x *MatchedIngredient) GetSubstitutionDistance() int32 {
+	if x != nil {
+		return x.SubstitutionDistance
+	}
+	return 0
+}
+
+func (x *MatchedIngredient) GetInSeason() bool {
+	if x != nil {
+		return x.InSeason
+	}
+	return false
+}
+
+type SearchPantryRecipesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Drop recipes missing more than this many ingredients
+	MaxMissing *int32 `protobuf:"varint,2,opt,name=max_missing,json=maxMissing,proto3,oneof" json:"max_missing,omitempty"`
+	// Only return recipes using at least one ingredient of these food types (e.g. "Seafood")
+	FoodTypes []string `protobuf:"bytes,3,rep,name=food_types,json=foodTypes,proto3" json:"food_types,omitempty"`
+	Limit     int32    `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Levels up or down the ingredient hierarchy a pantry item or sale may substitute for a
+	// recipe ingredient. Defaults to 1, 0 is exact.
+	SubstitutionDepth *int32 `protobuf:"varint,5,opt,name=substitution_depth,json=substitutionDepth,proto3,oneof" json:"substitution_depth,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SearchPantryRecipesRequest) Reset() {
+	*x = SearchPantryRecipesRequest{}
+	mi := &file_proto_recommendation_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchPantryRecipesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPantryRecipesRequest) ProtoMessage() {}
+
+func (x *SearchPantryRecipesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recommendation_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPantryRecipesRequest.ProtoReflect.Descriptor instead.
+func (*SearchPantryRecipesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_recommendation_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SearchPantryRecipesRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SearchPantryRecipesRequest) GetMaxMissing() int32 {
+	if x != nil && x.MaxMissing != nil {
+		return *x.MaxMissing
+	}
+	return 0
+}
+
+func (x *SearchPantryRecipesRequest) GetFoodTypes() []string {
+	if x != nil {
+		return x.FoodTypes
+	}
+	return nil
+}
+
+func (x *SearchPantryRecipesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchPantryRecipesRequest) GetSubstitutionDepth() int32 {
+	if x != nil && x.SubstitutionDepth != nil {
+		return *x.SubstitutionDepth
+	}
+	return 0
+}
+
+type SearchPantryRecipesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recipes       []*PantryRecipe        `protobuf:"bytes,1,rep,name=recipes,proto3" json:"recipes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchPantryRecipesResponse) Reset() {
+	*x = SearchPantryRecipesResponse{}
+	mi := &file_proto_recommendation_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchPantryRecipesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPantryRecipesResponse) ProtoMessage() {}
+
+func (x *SearchPantryRecipesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recommendation_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPantryRecipesResponse.ProtoReflect.Descriptor instead.
+func (*SearchPantryRecipesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_recommendation_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SearchPantryRecipesResponse) GetRecipes() []*PantryRecipe {
+	if x != nil {
+		return x.Recipes
+	}
+	return nil
+}
+
+type PantryRecipe struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RecipeId        int32                  `protobuf:"varint,1,opt,name=recipe_id,json=recipeId,proto3" json:"recipe_id,omitempty"`
+	Title           string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Link            *string                `protobuf:"bytes,3,opt,name=link,proto3,oneof" json:"link,omitempty"`
+	IngredientCount int32                  `protobuf:"varint,4,opt,name=ingredient_count,json=ingredientCount,proto3" json:"ingredient_count,omitempty"`
+	InPantryCount   int32                  `protobuf:"varint,5,opt,name=in_pantry_count,json=inPantryCount,proto3" json:"in_pantry_count,omitempty"`
+	// Fraction of the recipe's ingredients already in the pantry
+	PantryFraction     float32              `protobuf:"fixed32,6,opt,name=pantry_fraction,json=pantryFraction,proto3" json:"pantry_fraction,omitempty"`
+	MissingIngredients []*MissingIngredient `protobuf:"bytes,7,rep,name=missing_ingredients,json=missingIngredients,proto3" json:"missing_ingredients,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *PantryRecipe) Reset() {
+	*x = PantryRecipe{}
+	mi := &file_proto_recommendation_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PantryRecipe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PantryRecipe) ProtoMessage() {}
+
+func (x *PantryRecipe) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recommendation_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PantryRecipe.ProtoReflect.Descriptor instead.
+func (*PantryRecipe) Descriptor() ([]byte, []int) {
+	return file_proto_recommendation_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PantryRecipe) GetRecipeId() int32 {
+	if x != nil {
+		return x.RecipeId
+	}
+	return 0
+}
+
+func (x *PantryRecipe) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PantryRecipe) GetLink() string {
+	if x != nil && x.Link != nil {
+		return *x.Link
+	}
+	return ""
+}
+
+func (x *PantryRecipe) GetIngredientCount() int32 {
+	if x != nil {
+		return x.IngredientCount
+	}
+	return 0
+}
+
+func (x *PantryRecipe) GetInPantryCount() int32 {
+	if x != nil {
+		return x.InPantryCount
+	}
+	return 0
+}
+
+func (x *PantryRecipe) GetPantryFraction() float32 {
+	if x != nil {
+		return x.PantryFraction
+	}
+	return 0
+}
+
+func (x *PantryRecipe) GetMissingIngredients() []*MissingIngredient {
+	if x != nil {
+		return x.MissingIngredients
+	}
+	return nil
+}
+
+type MissingIngredient struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	IngredientId *int32                 `protobuf:"varint,1,opt,name=ingredient_id,json=ingredientId,proto3,oneof" json:"ingredient_id,omitempty"`
+	Name         string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Amount       *string                `protobuf:"bytes,3,opt,name=amount,proto3,oneof" json:"amount,omitempty"`
+	Unit         *string                `protobuf:"bytes,4,opt,name=unit,proto3,oneof" json:"unit,omitempty"`
+	OnSale       bool                   `protobuf:"varint,5,opt,name=on_sale,json=onSale,proto3" json:"on_sale,omitempty"`
+	// Cheapest sale at a subscribed store, set when on_sale is true
+	Sale *MatchedIngredient `protobuf:"bytes,6,opt,name=sale,proto3" json:"sale,omitempty"`
+	// How much more is needed when the pantry holds some of it, e.g. "0.5 cup"
+	Shortfall     *string `protobuf:"bytes,7,opt,name=shortfall,proto3,oneof" json:"shortfall,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MissingIngredient) Reset() {
+	*x = MissingIngredient{}
+	mi := &file_proto_recommendation_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MissingIngredient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MissingIngredient) ProtoMessage() {}
+
+func (x *MissingIngredient) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recommendation_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MissingIngredient.ProtoReflect.Descriptor instead.
+func (*MissingIngredient) Descriptor() ([]byte, []int) {
+	return file_proto_recommendation_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MissingIngredient) GetIngredientId() int32 {
+	if x != nil && x.IngredientId != nil {
+		return *x.IngredientId
+	}
+	return 0
+}
+
+func (x *MissingIngredient) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MissingIngredient) GetAmount() string {
+	if x != nil && x.Amount != nil {
+		return *x.Amount
+	}
+	return ""
+}
+
+func (x *MissingIngredient) GetUnit() string {
+	if x != nil && x.Unit != nil {
+		return *x.Unit
+	}
+	return ""
+}
+
+func (x *MissingIngredient) GetOnSale() bool {
+	if x != nil {
+		return x.OnSale
+	}
+	return false
+}
+
+func (x *MissingIngredient) GetSale() *MatchedIngredient {
+	if x != nil {
+		return x.Sale
+	}
+	return nil
+}
+
+func (x *MissingIngredient) GetShortfall() string {
+	if x != nil && x.Shortfall != nil {
+		return *x.Shortfall
+	}
+	return ""
+}
+
+type GetSeasonalIngredientsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Month from 1 to 12, defaults to the current month
+	Month int32 `protobuf:"varint,1,opt,name=month,proto3" json:"month,omitempty"`
+	// "north" (default) or "south"
+	Hemisphere string `protobuf:"bytes,2,opt,name=hemisphere,proto3" json:"hemisphere,omitempty"`
+	// Only return ingredients of these food types (e.g. "Fruit") when set
+	FoodTypes     []string `protobuf:"bytes,3,rep,name=food_types,json=foodTypes,proto3" json:"food_types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSeasonalIngredientsRequest) Reset() {
+	*x = GetSeasonalIngredientsRequest{}
+	mi := &file_proto_recommendation_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSeasonalIngredientsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSeasonalIngredientsRequest) ProtoMessage() {}
+
+func (x *GetSeasonalIngredientsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recommendation_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSeasonalIngredientsRequest.ProtoReflect.Descriptor instead.
+func (*GetSeasonalIngredientsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_recommendation_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetSeasonalIngredientsRequest) GetMonth() int32 {
+	if x != nil {
+		return x.Month
+	}
+	return 0
+}
+
+func (x *GetSeasonalIngredientsRequest) GetHemisphere() string {
+	if x != nil {
+		return x.Hemisphere
+	}
+	return ""
+}
+
+func (x *GetSeasonalIngredientsRequest) GetFoodTypes() []string {
+	if x != nil {
+		return x.FoodTypes
+	}
+	return nil
+}
+
+type SeasonalIngredient struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type  string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// Months the ingredient is in season, in the requested hemisphere
+	Season        []int32 `protobuf:"varint,4,rep,packed,name=season,proto3" json:"season,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeasonalIngredient) Reset() {
+	*x = SeasonalIngredient{}
+	mi := &file_proto_recommendation_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeasonalIngredient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeasonalIngredient) ProtoMessage() {}
+
+func (x *SeasonalIngredient) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recommendation_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeasonalIngredient.ProtoReflect.Descriptor instead.
+func (*SeasonalIngredient) Descriptor() ([]byte, []int) {
+	return file_proto_recommendation_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SeasonalIngredient) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SeasonalIngredient) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SeasonalIngredient) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SeasonalIngredient) GetSeason() []int32 {
+	if x != nil {
+		return x.Season
+	}
+	return nil
+}
+
+type GetSeasonalIngredientsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Month         int32                  `protobuf:"varint,1,opt,name=month,proto3" json:"month,omitempty"`
+	Hemisphere    string                 `protobuf:"bytes,2,opt,name=hemisphere,proto3" json:"hemisphere,omitempty"`
+	Ingredients   []*SeasonalIngredient  `protobuf:"bytes,3,rep,name=ingredients,proto3" json:"ingredients,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSeasonalIngredientsResponse) Reset() {
+	*x = GetSeasonalIngredientsResponse{}
+	mi := &file_proto_recommendation_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSeasonalIngredientsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSeasonalIngredientsResponse) ProtoMessage() {}
+
+func (x *GetSeasonalIngredientsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recommendation_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSeasonalIngredientsResponse.ProtoReflect.Descriptor instead.
+func (*GetSeasonalIngredientsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_recommendation_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetSeasonalIngredientsResponse) GetMonth() int32 {
+	if x != nil {
+		return x.Month
+	}
+	return 0
+}
+
+func (x *GetSeasonalIngredientsResponse) GetHemisphere() string {
+	if x != nil {
+		return x.Hemisphere
+	}
+	return ""
+}
+
+func (x *GetSeasonalIngredientsResponse) GetIngredients() []*SeasonalIngredient {
+	if x != nil {
+		return x.Ingredients
+	}
+	return nil
+}
+
+var File_proto_recommendation_service_proto protoreflect.FileDescriptor
+
+const file_proto_recommendation_service_proto_rawDesc = "" +
+	"\n" +
+	"\"proto/recommendation_service.proto\x12\x02pb\"\xf8\x01\n" +
+	"\x1fGetRecipeRecommendationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x122\n" +
+	"\x12substitution_depth\x18\x03 \x01(\x05H\x00R\x11substitutionDepth\x88\x01\x01\x12%\n" +
+	"\x0eseasonal_boost\x18\x04 \x01(\bR\rseasonalBoost\x12\x14\n" +
+	"\x05month\x18\x05 \x01(\x05R\x05month\x12\x1e\n" +
+	"\n" +
+	"hemisphere\x18\x06 \x01(\tR\n" +
+	"hemisphereB\x15\n" +
+	"\x13_substitution_depth\"f\n" +
+	" GetRecipeRecommendationsResponse\x12B\n" +
+	"\x0frecommendations\x18\x01 \x03(\v2\x18.pb.RecipeRecommendationR\x0frecommendations\"\xdb\x02\n" +
+	"\x14RecipeRecommendation\x12\x1b\n" +
+	"\trecipe_id\x18\x01 \x01(\x05R\brecipeId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x17\n" +
+	"\x04link\x18\x03 \x01(\tH\x00R\x04link\x88\x01\x01\x12)\n" +
+	"\x10ingredient_count\x18\x04 \x01(\x05R\x0fingredientCount\x12#\n" +
+	"\rmatched_count\x18\x05 \x01(\x05R\fmatchedCount\x12\x18\n" +
+	"\asavings\x18\x06 \x01(\x02R\asavings\x12\x14\n" +
+	"\x05score\x18\a \x01(\x02R\x05score\x12F\n" +
+	"\x13matched_ingredients\x18\b \x03(\v2\x15.pb.MatchedIngredientR\x12matchedIngredients\x12&\n" +
+	"\x0fin_season_count\x18\t \x01(\x05R\rinSeasonCountB\a\n" +
+	"\x05_link\"\x99\x03\n" +
+	"\x11MatchedIngredient\x12#\n" +
+	"\ringredient_id\x18\x01 \x01(\x05R\fingredientId\x12+\n" +
+	"\x11recipe_ingredient\x18\x02 \x01(\tR\x10recipeIngredient\x12 \n" +
+	"\fad_item_name\x18\x03 \x01(\tR\n" +
+	"adItemName\x12\x19\n" +
+	"\bstore_id\x18\x04 \x01(\x05R\astoreId\x12\x1d\n" +
+	"\n" +
+	"store_name\x18\x05 \x01(\tR\tstoreName\x12\x19\n" +
+	"\x05price\x18\x06 \x01(\x02H\x00R\x05price\x88\x01\x01\x12*\n" +
+	"\x0eoriginal_price\x18\a \x01(\x02H\x01R\roriginalPrice\x88\x01\x01\x12\x17\n" +
+	"\x04sale\x18\b \x01(\tH\x02R\x04sale\x88\x01\x01\x123\n" +
+	"\x15substitution_distance\x18\t \x01(\x05R\x14substitutionDistance\x12\x1b\n" +
+	"\tin_season\x18\n" +
+	" \x01(\bR\binSeasonB\b\n" +
+	"\x06_priceB\x11\n" +
+	"\x0f_original_priceB\a\n" +
+	"\x05_sale\"\xeb\x01\n" +
+	"\x1aSearchPantryRecipesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12$\n" +
+	"\vmax_missing\x18\x02 \x01(\x05H\x00R\n" +
+	"maxMissing\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"food_types\x18\x03 \x03(\tR\tfoodTypes\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x122\n" +
+	"\x12substitution_depth\x18\x05 \x01(\x05H\x01R\x11substitutionDepth\x88\x01\x01B\x0e\n" +
+	"\f_max_missingB\x15\n" +
+	"\x13_substitution_depth\"I\n" +
+	"\x1bSearchPantryRecipesResponse\x12*\n" +
+	"\arecipes\x18\x01 \x03(\v2\x10.pb.PantryRecipeR\arecipes\"\xa7\x02\n" +
+	"\fPantryRecipe\x12\x1b\n" +
+	"\trecipe_id\x18\x01 \x01(\x05R\brecipeId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x17\n" +
+	"\x04link\x18\x03 \x01(\tH\x00R\x04link\x88\x01\x01\x12)\n" +
+	"\x10ingredient_count\x18\x04 \x01(\x05R\x0fingredientCount\x12&\n" +
+	"\x0fin_pantry_count\x18\x05 \x01(\x05R\rinPantryCount\x12'\n" +
+	"\x0fpantry_fraction\x18\x06 \x01(\x02R\x0epantryFraction\x12F\n" +
+	"\x13missing_ingredients\x18\a \x03(\v2\x15.pb.MissingIngredientR\x12missingIngredientsB\a\n" +
+	"\x05_link\"\xa2\x02\n" +
+	"\x11MissingIngredient\x12(\n" +
+	"\ringredient_id\x18\x01 \x01(\x05H\x00R\fingredientId\x88\x01\x01\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\x06amount\x18\x03 \x01(\tH\x01R\x06amount\x88\x01\x01\x12\x17\n" +
+	"\x04unit\x18\x04 \x01(\tH\x02R\x04unit\x88\x01\x01\x12\x17\n" +
+	"\aon_sale\x18\x05 \x01(\bR\x06onSale\x12)\n" +
+	"\x04sale\x18\x06 \x01(\v2\x15.pb.MatchedIngredientR\x04sale\x12!\n" +
+	"\tshortfall\x18\a \x01(\tH\x03R\tshortfall\x88\x01\x01B\x10\n" +
+	"\x0e_ingredient_idB\t\n" +
+	"\a_amountB\a\n" +
+	"\x05_unitB\f\n" +
+	"\n" +
+	"_shortfall\"t\n" +
+	"\x1dGetSeasonalIngredientsRequest\x12\x14\n" +
+	"\x05month\x18\x01 \x01(\x05R\x05month\x12\x1e\n" +
+	"\n" +
+	"hemisphere\x18\x02 \x01(\tR\n" +
+	"hemisphere\x12\x1d\n" +
+	"\n" +
+	"food_types\x18\x03 \x03(\tR\tfoodTypes\"d\n" +
+	"\x12SeasonalIngredient\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x16\n" +
+	"\x06season\x18\x04 \x03(\x05R\x06season\"\x90\x01\n" +
+	"\x1eGetSeasonalIngredientsResponse\x12\x14\n" +
+	"\x05month\x18\x01 \x01(\x05R\x05month\x12\x1e\n" +
+	"\n" +
+	"hemisphere\x18\x02 \x01(\tR\n" +
+	"hemisphere\x128\n" +
+	"\vingredients\x18\x03 \x03(\v2\x16.pb.SeasonalIngredientR\vingredients2\xb7\x02\n" +
+	"\x15RecommendationService\x12e\n" +
+	"\x18GetRecipeRecommendations\x12#.pb.GetRecipeRecommendationsRequest\x1a$.pb.GetRecipeRecommendationsResponse\x12V\n" +
+	"\x13SearchPantryRecipes\x12\x1e.pb.SearchPantryRecipesRequest\x1a\x1f.pb.SearchPantryRecipesResponse\x12_\n" +
+	"\x16GetSeasonalIngredients\x12!.pb.GetSeasonalIngredientsRequest\x1a\".pb.GetSeasonalIngredientsResponseB\x06Z\x04./pbb\x06proto3"
+
+var (
+	file_proto_recommendation_service_proto_rawDescOnce sync.Once
+	file_proto_recommendation_service_proto_rawDescData []byte
+)
+
+func file_proto_recommendation_service_proto_rawDescGZIP() []byte {
+	file_proto_recommendation_service_proto_rawDescOnce.Do(func() {
+		file_proto_recommendation_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_recommendation_service_proto_rawDesc), len(file_proto_recommendation_service_proto_rawDesc)))
+	})
+	return file_proto_recommendation_service_proto_rawDescData
+}
+
+var file_proto_recommendation_service_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_recommendation_service_proto_goTypes = []any{
+	(*GetRecipeRecommendationsRequest)(nil),  // 0: pb.GetRecipeRecommendationsRequest
+	(*GetRecipeRecommendationsResponse)(nil), // 1: pb.GetRecipeRecommendationsResponse
+	(*RecipeRecommendation)(nil),             // 2: pb.RecipeRecommendation
+	(*MatchedIngredient)(nil),                // 3: pb.MatchedIngredient
+	(*SearchPantryRecipesRequest)(nil),       // 4: pb.SearchPantryRecipesRequest
+	(*SearchPantryRecipesResponse)(nil),      // 5: pb.SearchPantryRecipesResponse
+	(*PantryRecipe)(nil),                     // 6: pb.PantryRecipe
+	(*MissingIngredient)(nil),                // 7: pb.MissingIngredient
+	(*GetSeasonalIngredientsRequest)(nil),    // 8: pb.GetSeasonalIngredientsRequest
+	(*SeasonalIngredient)(nil),               // 9: pb.SeasonalIngredient
+	(*GetSeasonalIngredientsResponse)(nil),   // 10: pb.GetSeasonalIngredientsResponse
+}
+var file_proto_recommendation_service_proto_depIdxs = []int32{
+	2,  // 0: pb.GetRecipeRecommendationsResponse.recommendations:type_name -> pb.RecipeRecommendation
+	3,  // 1: pb.RecipeRecommendation.matched_ingredients:type_name -> pb.MatchedIngredient
+	6,  // 2: pb.SearchPantryRecipesResponse.recipes:type_name -> pb.PantryRecipe
+	7,  // 3: pb.PantryRecipe.missing_ingredients:type_name -> pb.MissingIngredient
+	3,  // 4: pb.MissingIngredient.sale:type_name -> pb.MatchedIngredient
+	9,  // 5: pb.GetSeasonalIngredientsResponse.ingredients:type_name -> pb.SeasonalIngredient
+	0,  // 6: pb.RecommendationService.GetRecipeRecommendations:input_type -> pb.GetRecipeRecommendationsRequest
+	4,  // 7: pb.RecommendationService.SearchPantryRecipes:input_type -> pb.SearchPantryRecipesRequest
+	8,  // 8: pb.RecommendationService.GetSeasonalIngredients:input_type -> pb.GetSeasonalIngredientsRequest
+	1,  // 9: pb.RecommendationService.GetRecipeRecommendations:output_type -> pb.GetRecipeRecommendationsResponse
+	5,  // 10: pb.RecommendationService.SearchPantryRecipes:output_type -> pb.SearchPantryRecipesResponse
+	10, // 11: pb.RecommendationService.GetSeasonalIngredients:output_type -> pb.GetSeasonalIngredientsResponse
+	9,  // [9:12] is the sub-list for method output_type
+	6,  // [6:9] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_proto_recommendation_service_proto_init() }
+func file_proto_recommendation_service_proto_init() {
+	if File_proto_recommendation_service_proto != nil {
+		return
+	}
+	file_proto_recommendation_service_proto_msgTypes[0].OneofWrappers = []any{}
+	file_proto_recommendation_service_proto_msgTypes[2].OneofWrappers = []any{}
+	file_proto_recommendation_service_proto_msgTypes[3].OneofWrappers = []any{}
+	file_proto_recommendation_service_proto_msgTypes[4].OneofWrappers = []any{}
+	file_proto_recommendation_service_proto_msgTypes[6].OneofWrappers = []any{}
+	file_proto_recommendation_service_proto_msgTypes[7].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_recommendation_service_proto_rawDesc), len(file_proto_recommendation_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_recommendation_service_proto_goTypes,
+		DependencyIndexes: file_proto_recommendation_service_proto_depIdxs,
+		MessageInfos:      file_proto_recommendation_service_proto_msgTypes,
+	}.Build()
+	File_proto_recommendation_service_proto = out.File
+	file_proto_recommendation_service_proto_goTypes = nil
+	file_proto_recommendation_service_proto_depIdxs = nil
+}
@@ -0,0 +1,197 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.20.3
+// source: proto/recommendation_service.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RecommendationService_GetRecipeRecommendations_FullMethodName = "/pb.RecommendationService/GetRecipeRecommendations"
+	RecommendationService_SearchPantryRecipes_FullMethodName      = "/pb.RecommendationService/SearchPantryRecipes"
+	RecommendationService_GetSeasonalIngredients_FullMethodName   = "/pb.RecommendationService/GetSeasonalIngredients"
+)
+
+// RecommendationServiceClient is the client API for RecommendationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RecommendationServiceClient interface {
+	GetRecipeRecommendations(ctx context.Context, in *GetRecipeRecommendationsRequest, opts ...grpc.CallOption) (*GetRecipeRecommendationsResponse, error)
+	SearchPantryRecipes(ctx context.Context, in *SearchPantryRecipesRequest, opts ...grpc.CallOption) (*SearchPantryRecipesResponse, error)
+	GetSeasonalIngredients(ctx context.Context, in *GetSeasonalIngredientsRequest, opts ...grpc.CallOption) (*GetSeasonalIngredientsResponse, error)
+}
+
+type recommendationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRecommendationServiceClient(cc grpc.ClientConnInterface) RecommendationServiceClient {
+	return &recommendationServiceClient{cc}
+}
+
+func (c *recommendationServiceClient) GetRecipeRecommendations(ctx context.Context, in *GetRecipeRecommendationsRequest, opts ...grpc.CallOption) (*GetRecipeRecommendationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRecipeRecommendationsResponse)
+	err := c.cc.Invoke(ctx, RecommendationService_GetRecipeRecommendations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommendationServiceClient) SearchPantryRecipes(ctx context.Context, in *SearchPantryRecipesRequest, opts ...grpc.CallOption) (*SearchPantryRecipesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchPantryRecipesResponse)
+	err := c.cc.Invoke(ctx, RecommendationService_SearchPantryRecipes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recommendationServiceClient) GetSeasonalIngredients(ctx context.Context, in *GetSeasonalIngredientsRequest, opts ...grpc.CallOption) (*GetSeasonalIngredientsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSeasonalIngredientsResponse)
+	err := c.cc.Invoke(ctx, RecommendationService_GetSeasonalIngredients_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RecommendationServiceServer is the server API for RecommendationService service.
+// All implementations must embed UnimplementedRecommendationServiceServer
+// for forward compatibility.
+type RecommendationServiceServer interface {
+	GetRecipeRecommendations(context.Context, *GetRecipeRecommendationsRequest) (*GetRecipeRecommendationsResponse, error)
+	SearchPantryRecipes(context.Context, *SearchPantryRecipesRequest) (*SearchPantryRecipesResponse, error)
+	GetSeasonalIngredients(context.Context, *GetSeasonalIngredientsRequest) (*GetSeasonalIngredientsResponse, error)
+	mustEmbedUnimplementedRecommendationServiceServer()
+}
+
+// UnimplementedRecommendationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRecommendationServiceServer struct{}
+
+func (UnimplementedRecommendationServiceServer) GetRecipeRecommendations(context.Context, *GetRecipeRecommendationsRequest) (*GetRecipeRecommendationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecipeRecommendations not implemented")
+}
+func (UnimplementedRecommendationServiceServer) SearchPantryRecipes(context.Context, *SearchPantryRecipesRequest) (*SearchPantryRecipesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchPantryRecipes not implemented")
+}
+func (UnimplementedRecommendationServiceServer) GetSeasonalIngredients(context.Context, *GetSeasonalIngredientsRequest) (*GetSeasonalIngredientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSeasonalIngredients not implemented")
+}
+func (UnimplementedRecommendationServiceServer) mustEmbedUnimplementedRecommendationServiceServer() {}
+func (UnimplementedRecommendationServiceServer) testEmbeddedByValue()                               {}
+
+// UnsafeRecommendationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RecommendationServiceServer will
+// result in compilation errors.
+type UnsafeRecommendationServiceServer interface {
+	mustEmbedUnimplementedRecommendationServiceServer()
+}
+
+func RegisterRecommendationServiceServer(s grpc.ServiceRegistrar, srv RecommendationServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRecommendationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RecommendationService_ServiceDesc, srv)
+}
+
+func _RecommendationService_GetRecipeRecommendations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecipeRecommendationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServiceServer).GetRecipeRecommendations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecommendationService_GetRecipeRecommendations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServiceServer).GetRecipeRecommendations(ctx, req.(*GetRecipeRecommendationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecommendationService_SearchPantryRecipes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchPantryRecipesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServiceServer).SearchPantryRecipes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecommendationService_SearchPantryRecipes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServiceServer).SearchPantryRecipes(ctx, req.(*SearchPantryRecipesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecommendationService_GetSeasonalIngredients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSeasonalIngredientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecommendationServiceServer).GetSeasonalIngredients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecommendationService_GetSeasonalIngredients_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecommendationServiceServer).GetSeasonalIngredients(ctx, req.(*GetSeasonalIngredientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RecommendationService_ServiceDesc is the grpc.ServiceDesc for RecommendationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RecommendationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.RecommendationService",
+	HandlerType: (*RecommendationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetRecipeRecommendations",
+			Handler:    _RecommendationService_GetRecipeRecommendations_Handler,
+		},
+		{
+			MethodName: "SearchPantryRecipes",
+			Handler:    _RecommendationService_SearchPantryRecipes_Handler,
+		},
+		{
+			MethodName: "GetSeasonalIngredients",
+			Handler:    _RecommendationService_GetSeasonalIngredients_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/recommendation_service.proto",
+}
@@ -0,0 +1,816 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.20.3
+// source: proto/shopping_list_service.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ShoppingListItem struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	IngredientId *int32                 `protobuf:"varint,2,opt,name=ingredient_id,json=ingredientId,proto3,oneof" json:"ingredient_id,omitempty"`
+	Name         string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Amount       *string                `protobuf:"bytes,4,opt,name=amount,proto3,oneof" json:"amount,omitempty"`
+	Unit         *string                `protobuf:"bytes,5,opt,name=unit,proto3,oneof" json:"unit,omitempty"`
+	StoreId      *int32                 `protobuf:"varint,6,opt,name=store_id,json=storeId,proto3,oneof" json:"store_id,omitempty"`
+	Price        *float32               `protobuf:"fixed32,7,opt,name=price,proto3,oneof" json:"price,omitempty"`
+	FoodType     string                 `protobuf:"bytes,8,opt,name=food_type,json=foodType,proto3" json:"food_type,omitempty"`
+	// Always set on responses; unset in an update keeps the item's checked state
+	Checked       *bool `protobuf:"varint,9,opt,name=checked,proto3,oneof" json:"checked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShoppingListItem) Reset() {
+	*x = ShoppingListItem{}
+	mi := &file_proto_shopping_list_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShoppingListItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShoppingListItem) ProtoMessage() {}
+
+func (x *ShoppingListItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shopping_list_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShoppingListItem.ProtoReflect.Descriptor instead.
+func (*ShoppingListItem) Descriptor() ([]byte, []int) {
+	return file_proto_shopping_list_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ShoppingListItem) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ShoppingListItem) GetIngredientId() int32 {
+	if x != nil && x.IngredientId != nil {
+		return *x.IngredientId
+	}
+	return 0
+}
+
+func (x *ShoppingListItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ShoppingListItem) GetAmount() string {
+	if x != nil && x.Amount != nil {
+		return *x.Amount
+	}
+	return ""
+}
+
+func (x *ShoppingListItem) GetUnit() string {
+	if x != nil && x.Unit != nil {
+		return *x.Unit
+	}
+	return ""
+}
+
+func (x *ShoppingListItem) GetStoreId() int32 {
+	if x != nil && x.StoreId != nil {
+		return *x.StoreId
+	}
+	return 0
+}
+
+func (x *ShoppingListItem) GetPrice() float32 {
+	if x != nil && x.Price != nil {
+		return *x.Price
+	}
+	return 0
+}
+
+func (x *ShoppingListItem) GetFoodType() string {
+	if x != nil {
+		return x.FoodType
+	}
+	return ""
+}
+
+func (x *ShoppingListItem) GetChecked() bool {
+	if x != nil && x.Checked != nil {
+		return *x.Checked
+	}
+	return false
+}
+
+// Items sharing a FoodType, e.g. the "Vegetable" aisle
+type ShoppingListAisle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FoodType      string                 `protobuf:"bytes,1,opt,name=food_type,json=foodType,proto3" json:"food_type,omitempty"`
+	Items         []*ShoppingListItem    `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShoppingListAisle) Reset() {
+	*x = ShoppingListAisle{}
+	mi := &file_proto_shopping_list_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShoppingListAisle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShoppingListAisle) ProtoMessage() {}
+
+func (x *ShoppingListAisle) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shopping_list_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShoppingListAisle.ProtoReflect.Descriptor instead.
+func (*ShoppingListAisle) Descriptor() ([]byte, []int) {
+	return file_proto_shopping_list_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ShoppingListAisle) GetFoodType() string {
+	if x != nil {
+		return x.FoodType
+	}
+	return ""
+}
+
+func (x *ShoppingListAisle) GetItems() []*ShoppingListItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// Items assigned to one store; store_id is unset for items not on sale anywhere
+type ShoppingListStore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StoreId       *int32                 `protobuf:"varint,1,opt,name=store_id,json=storeId,proto3,oneof" json:"store_id,omitempty"`
+	StoreName     string                 `protobuf:"bytes,2,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	Aisles        []*ShoppingListAisle   `protobuf:"bytes,3,rep,name=aisles,proto3" json:"aisles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShoppingListStore) Reset() {
+	*x = ShoppingListStore{}
+	mi := &file_proto_shopping_list_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShoppingListStore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShoppingListStore) ProtoMessage() {}
+
+func (x *ShoppingListStore) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shopping_list_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShoppingListStore.ProtoReflect.Descriptor instead.
+func (*ShoppingListStore) Descriptor() ([]byte, []int) {
+	return file_proto_shopping_list_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ShoppingListStore) GetStoreId() int32 {
+	if x != nil && x.StoreId != nil {
+		return *x.StoreId
+	}
+	return 0
+}
+
+func (x *ShoppingListStore) GetStoreName() string {
+	if x != nil {
+		return x.StoreName
+	}
+	return ""
+}
+
+func (x *ShoppingListStore) GetAisles() []*ShoppingListAisle {
+	if x != nil {
+		return x.Aisles
+	}
+	return nil
+}
+
+type ShoppingList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Stores        []*ShoppingListStore   `protobuf:"bytes,5,rep,name=stores,proto3" json:"stores,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShoppingList) Reset() {
+	*x = ShoppingList{}
+	mi := &file_proto_shopping_list_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShoppingList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShoppingList) ProtoMessage() {}
+
+func (x *ShoppingList) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shopping_list_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShoppingList.ProtoReflect.Descriptor instead.
+func (*ShoppingList) Descriptor() ([]byte, []int) {
+	return file_proto_shopping_list_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ShoppingList) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ShoppingList) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ShoppingList) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ShoppingList) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *ShoppingList) GetStores() []*ShoppingListStore {
+	if x != nil {
+		return x.Stores
+	}
+	return nil
+}
+
+type CreateShoppingListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	RecipeIds     []int32                `protobuf:"varint,3,rep,packed,name=recipe_ids,json=recipeIds,proto3" json:"recipe_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShoppingListRequest) Reset() {
+	*x = CreateShoppingListRequest{}
+	mi := &file_proto_shopping_list_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShoppingListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShoppingListRequest) ProtoMessage() {}
+
+func (x *CreateShoppingListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shopping_list_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShoppingListRequest.ProtoReflect.Descriptor instead.
+func (*CreateShoppingListRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shopping_list_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateShoppingListRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreateShoppingListRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateShoppingListRequest) GetRecipeIds() []int32 {
+	if x != nil {
+		return x.RecipeIds
+	}
+	return nil
+}
+
+type GetShoppingListRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ShoppingListId int32                  `protobuf:"varint,1,opt,name=shopping_list_id,json=shoppingListId,proto3" json:"shopping_list_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetShoppingListRequest) Reset() {
+	*x = GetShoppingListRequest{}
+	mi := &file_proto_shopping_list_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShoppingListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShoppingListRequest) ProtoMessage() {}
+
+func (x *GetShoppingListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shopping_list_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShoppingListRequest.ProtoReflect.Descriptor instead.
+func (*GetShoppingListRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shopping_list_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetShoppingListRequest) GetShoppingListId() int32 {
+	if x != nil {
+		return x.ShoppingListId
+	}
+	return 0
+}
+
+type ListShoppingListsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShoppingListsRequest) Reset() {
+	*x = ListShoppingListsRequest{}
+	mi := &file_proto_shopping_list_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShoppingListsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShoppingListsRequest) ProtoMessage() {}
+
+func (x *ListShoppingListsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shopping_list_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShoppingListsRequest.ProtoReflect.Descriptor instead.
+func (*ListShoppingListsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shopping_list_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListShoppingListsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ListShoppingListsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Lists are returned without their items
+	ShoppingLists []*ShoppingList `protobuf:"bytes,1,rep,name=shopping_lists,json=shoppingLists,proto3" json:"shopping_lists,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShoppingListsResponse) Reset() {
+	*x = ListShoppingListsResponse{}
+	mi := &file_proto_shopping_list_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShoppingListsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShoppingListsResponse) ProtoMessage() {}
+
+func (x *ListShoppingListsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shopping_list_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShoppingListsResponse.ProtoReflect.Descriptor instead.
+func (*ListShoppingListsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shopping_list_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListShoppingListsResponse) GetShoppingLists() []*ShoppingList {
+	if x != nil {
+		return x.ShoppingLists
+	}
+	return nil
+}
+
+type AddShoppingListItemRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ShoppingListId int32                  `protobuf:"varint,1,opt,name=shopping_list_id,json=shoppingListId,proto3" json:"shopping_list_id,omitempty"`
+	Item           *ShoppingListItem      `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AddShoppingListItemRequest) Reset() {
+	*x = AddShoppingListItemRequest{}
+	mi := &file_proto_shopping_list_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddShoppingListItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddShoppingListItemRequest) ProtoMessage() {}
+
+func (x *AddShoppingListItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shopping_list_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddShoppingListItemRequest.ProtoReflect.Descriptor instead.
+func (*AddShoppingListItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shopping_list_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AddShoppingListItemRequest) GetShoppingListId() int32 {
+	if x != nil {
+		return x.ShoppingListId
+	}
+	return 0
+}
+
+func (x *AddShoppingListItemRequest) GetItem() *ShoppingListItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+// Only the item's amount, unit, store_id, price and checked are updated, and only those
+// that are set; the rest keep their current values
+type UpdateShoppingListItemRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ShoppingListId int32                  `protobuf:"varint,1,opt,name=shopping_list_id,json=shoppingListId,proto3" json:"shopping_list_id,omitempty"`
+	Item           *ShoppingListItem      `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateShoppingListItemRequest) Reset() {
+	*x = UpdateShoppingListItemRequest{}
+	mi := &file_proto_shopping_list_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateShoppingListItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateShoppingListItemRequest) ProtoMessage() {}
+
+func (x *UpdateShoppingListItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shopping_list_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateShoppingListItemRequest.ProtoReflect.Descriptor instead.
+func (*UpdateShoppingListItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shopping_list_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateShoppingListItemRequest) GetShoppingListId() int32 {
+	if x != nil {
+		return x.ShoppingListId
+	}
+	return 0
+}
+
+func (x *UpdateShoppingListItemRequest) GetItem() *ShoppingListItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+type DeleteShoppingListItemRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ShoppingListId int32                  `protobuf:"varint,1,opt,name=shopping_list_id,json=shoppingListId,proto3" json:"shopping_list_id,omitempty"`
+	ItemId         int32                  `protobuf:"varint,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeleteShoppingListItemRequest) Reset() {
+	*x = DeleteShoppingListItemRequest{}
+	mi := &file_proto_shopping_list_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteShoppingListItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteShoppingListItemRequest) ProtoMessage() {}
+
+func (x *DeleteShoppingListItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shopping_list_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteShoppingListItemRequest.ProtoReflect.Descriptor instead.
+func (*DeleteShoppingListItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shopping_list_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteShoppingListItemRequest) GetShoppingListId() int32 {
+	if x != nil {
+		return x.ShoppingListId
+	}
+	return 0
+}
+
+func (x *DeleteShoppingListItemRequest) GetItemId() int32 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+var File_proto_shopping_list_service_proto protoreflect.FileDescriptor
+
+const file_proto_shopping_list_service_proto_rawDesc = "" +
+	"\n" +
+	"!proto/shopping_list_service.proto\x12\x02pb\"\xd6\x02\n" +
+	"\x10ShoppingListItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12(\n" +
+	"\ringredient_id\x18\x02 \x01(\x05H\x00R\fingredientId\x88\x01\x01\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1b\n" +
+	"\x06amount\x18\x04 \x01(\tH\x01R\x06amount\x88\x01\x01\x12\x17\n" +
+	"\x04unit\x18\x05 \x01(\tH\x02R\x04unit\x88\x01\x01\x12\x1e\n" +
+	"\bstore_id\x18\x06 \x01(\x05H\x03R\astoreId\x88\x01\x01\x12\x19\n" +
+	"\x05price\x18\a \x01(\x02H\x04R\x05price\x88\x01\x01\x12\x1b\n" +
+	"\tfood_type\x18\b \x01(\tR\bfoodType\x12\x1d\n" +
+	"\achecked\x18\t \x01(\bH\x05R\achecked\x88\x01\x01B\x10\n" +
+	"\x0e_ingredient_idB\t\n" +
+	"\a_amountB\a\n" +
+	"\x05_unitB\v\n" +
+	"\t_store_idB\b\n" +
+	"\x06_priceB\n" +
+	"\n" +
+	"\b_checked\"\\\n" +
+	"\x11ShoppingListAisle\x12\x1b\n" +
+	"\tfood_type\x18\x01 \x01(\tR\bfoodType\x12*\n" +
+	"\x05items\x18\x02 \x03(\v2\x14.pb.ShoppingListItemR\x05items\"\x8e\x01\n" +
+	"\x11ShoppingListStore\x12\x1e\n" +
+	"\bstore_id\x18\x01 \x01(\x05H\x00R\astoreId\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"store_name\x18\x02 \x01(\tR\tstoreName\x12-\n" +
+	"\x06aisles\x18\x03 \x03(\v2\x15.pb.ShoppingListAisleR\x06aislesB\v\n" +
+	"\t_store_id\"\x99\x01\n" +
+	"\fShoppingList\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12-\n" +
+	"\x06stores\x18\x05 \x03(\v2\x15.pb.ShoppingListStoreR\x06stores\"g\n" +
+	"\x19CreateShoppingListRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"recipe_ids\x18\x03 \x03(\x05R\trecipeIds\"B\n" +
+	"\x16GetShoppingListRequest\x12(\n" +
+	"\x10shopping_list_id\x18\x01 \x01(\x05R\x0eshoppingListId\"3\n" +
+	"\x18ListShoppingListsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"T\n" +
+	"\x19ListShoppingListsResponse\x127\n" +
+	"\x0eshopping_lists\x18\x01 \x03(\v2\x10.pb.ShoppingListR\rshoppingLists\"p\n" +
+	"\x1aAddShoppingListItemRequest\x12(\n" +
+	"\x10shopping_list_id\x18\x01 \x01(\x05R\x0eshoppingListId\x12(\n" +
+	"\x04item\x18\x02 \x01(\v2\x14.pb.ShoppingListItemR\x04item\"s\n" +
+	"\x1dUpdateShoppingListItemRequest\x12(\n" +
+	"\x10shopping_list_id\x18\x01 \x01(\x05R\x0eshoppingListId\x12(\n" +
+	"\x04item\x18\x02 \x01(\v2\x14.pb.ShoppingListItemR\x04item\"b\n" +
+	"\x1dDeleteShoppingListItemRequest\x12(\n" +
+	"\x10shopping_list_id\x18\x01 \x01(\x05R\x0eshoppingListId\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\x05R\x06itemId2\xd6\x03\n" +
+	"\x13ShoppingListService\x12E\n" +
+	"\x12CreateShoppingList\x12\x1d.pb.CreateShoppingListRequest\x1a\x10.pb.ShoppingList\x12?\n" +
+	"\x0fGetShoppingList\x12\x1a.pb.GetShoppingListRequest\x1a\x10.pb.ShoppingList\x12P\n" +
+	"\x11ListShoppingLists\x12\x1c.pb.ListShoppingListsRequest\x1a\x1d.pb.ListShoppingListsResponse\x12G\n" +
+	"\x13AddShoppingListItem\x12\x1e.pb.AddShoppingListItemRequest\x1a\x10.pb.ShoppingList\x12M\n" +
+	"\x16UpdateShoppingListItem\x12!.pb.UpdateShoppingListItemRequest\x1a\x10.pb.ShoppingList\x12M\n" +
+	"\x16DeleteShoppingListItem\x12!.pb.DeleteShoppingListItemRequest\x1a\x10.pb.ShoppingListB\x06Z\x04./pbb\x06proto3"
+
+var (
+	file_proto_shopping_list_service_proto_rawDescOnce sync.Once
+	file_proto_shopping_list_service_proto_rawDescData []byte
+)
+
+func file_proto_shopping_list_service_proto_rawDescGZIP() []byte {
+	file_proto_shopping_list_service_proto_rawDescOnce.Do(func() {
+		file_proto_shopping_list_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_shopping_list_service_proto_rawDesc), len(file_proto_shopping_list_service_proto_rawDesc)))
+	})
+	return file_proto_shopping_list_service_proto_rawDescData
+}
+
+var file_proto_shopping_list_service_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_shopping_list_service_proto_goTypes = []any{
+	(*ShoppingListItem)(nil),              // 0: pb.ShoppingListItem
+	(*ShoppingListAisle)(nil),             // 1: pb.ShoppingListAisle
+	(*ShoppingListStore)(nil),             // 2: pb.ShoppingListStore
+	(*ShoppingList)(nil),                  // 3: pb.ShoppingList
+	(*CreateShoppingListRequest)(nil),     // 4: pb.CreateShoppingListRequest
+	(*GetShoppingListRequest)(nil),        // 5: pb.GetShoppingListRequest
+	(*ListShoppingListsRequest)(nil),      // 6: pb.ListShoppingListsRequest
+	(*ListShoppingListsResponse)(nil),     // 7: pb.ListShoppingListsResponse
+	(*AddShoppingListItemRequest)(nil),    // 8: pb.AddShoppingListItemRequest
+	(*UpdateShoppingListItemRequest)(nil), // 9: pb.UpdateShoppingListItemRequest
+	(*DeleteShoppingListItemRequest)(nil), // 10: pb.DeleteShoppingListItemRequest
+}
+var file_proto_shopping_list_service_proto_depIdxs = []int32{
+	0,  // 0: pb.ShoppingListAisle.items:type_name -> pb.ShoppingListItem
+	1,  // 1: pb.ShoppingListStore.aisles:type_name -> pb.ShoppingListAisle
+	2,  // 2: pb.ShoppingList.stores:type_name -> pb.ShoppingListStore
+	3,  // 3: pb.ListShoppingListsResponse.shopping_lists:type_name -> pb.ShoppingList
+	0,  // 4: pb.AddShoppingListItemRequest.item:type_name -> pb.ShoppingListItem
+	0,  // 5: pb.UpdateShoppingListItemRequest.item:type_name -> pb.ShoppingListItem
+	4,  // 6: pb.ShoppingListService.CreateShoppingList:input_type -> pb.CreateShoppingListRequest
+	5,  // 7: pb.ShoppingListService.GetShoppingList:input_type -> pb.GetShoppingListRequest
+	6,  // 8: pb.ShoppingListService.ListShoppingLists:input_type -> pb.ListShoppingListsRequest
+	8,  // 9: pb.ShoppingListService.AddShoppingListItem:input_type -> pb.AddShoppingListItemRequest
+	9,  // 10: pb.ShoppingListService.UpdateShoppingListItem:input_type -> pb.UpdateShoppingListItemRequest
+	10, // 11: pb.ShoppingListService.DeleteShoppingListItem:input_type -> pb.DeleteShoppingListItemRequest
+	3,  // 12: pb.ShoppingListService.CreateShoppingList:output_type -> pb.ShoppingList
+	3,  // 13: pb.ShoppingListService.GetShoppingList:output_type -> pb.ShoppingList
+	7,  // 14: pb.ShoppingListService.ListShoppingLists:output_type -> pb.ListShoppingListsResponse
+	3,  // 15: pb.ShoppingListService.AddShoppingListItem:output_type -> pb.ShoppingList
+	3,  // 16: pb.ShoppingListService.UpdateShoppingListItem:output_type -> pb.ShoppingList
+	3,  // 17: pb.ShoppingListService.DeleteShoppingListItem:output_type -> pb.ShoppingList
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_proto_shopping_list_service_proto_init() }
+func file_proto_shopping_list_service_proto_init() {
+	if File_proto_shopping_list_service_proto != nil {
+		return
+	}
+	file_proto_shopping_list_service_proto_msgTypes[0].OneofWrappers = []any{}
+	file_proto_shopping_list_service_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_shopping_list_service_proto_rawDesc), len(file_proto_shopping_list_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_shopping_list_service_proto_goTypes,
+		DependencyIndexes: file_proto_shopping_list_service_proto_depIdxs,
+		MessageInfos:      file_proto_shopping_list_service_proto_msgTypes,
+	}.Build()
+	File_proto_shopping_list_service_proto = out.File
+	file_proto_shopping_list_service_proto_goTypes = nil
+	file_proto_shopping_list_service_proto_depIdxs = nil
+}
@@ -0,0 +1,311 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.20.3
+// source: proto/shopping_list_service.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ShoppingListService_CreateShoppingList_FullMethodName     = "/pb.ShoppingListService/CreateShoppingList"
+	ShoppingListService_GetShoppingList_FullMethodName        = "/pb.ShoppingListService/GetShoppingList"
+	ShoppingListService_ListShoppingLists_FullMethodName      = "/pb.ShoppingListService/ListShoppingLists"
+	ShoppingListService_AddShoppingListItem_FullMethodName    = "/pb.ShoppingListService/AddShoppingListItem"
+	ShoppingListService_UpdateShoppingListItem_FullMethodName = "/pb.ShoppingListService/UpdateShoppingListItem"
+	ShoppingListService_DeleteShoppingListItem_FullMethodName = "/pb.ShoppingListService/DeleteShoppingListItem"
+)
+
+// ShoppingListServiceClient is the client API for ShoppingListService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ShoppingListServiceClient interface {
+	CreateShoppingList(ctx context.Context, in *CreateShoppingListRequest, opts ...grpc.CallOption) (*ShoppingList, error)
+	GetShoppingList(ctx context.Context, in *GetShoppingListRequest, opts ...grpc.CallOption) (*ShoppingList, error)
+	ListShoppingLists(ctx context.Context, in *ListShoppingListsRequest, opts ...grpc.CallOption) (*ListShoppingListsResponse, error)
+	AddShoppingListItem(ctx context.Context, in *AddShoppingListItemRequest, opts ...grpc.CallOption) (*ShoppingList, error)
+	UpdateShoppingListItem(ctx context.Context, in *UpdateShoppingListItemRequest, opts ...grpc.CallOption) (*ShoppingList, error)
+	DeleteShoppingListItem(ctx context.Context, in *DeleteShoppingListItemRequest, opts ...grpc.CallOption) (*ShoppingList, error)
+}
+
+type shoppingListServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewShoppingListServiceClient(cc grpc.ClientConnInterface) ShoppingListServiceClient {
+	return &shoppingListServiceClient{cc}
+}
+
+func (c *shoppingListServiceClient) CreateShoppingList(ctx context.Context, in *CreateShoppingListRequest, opts ...grpc.CallOption) (*ShoppingList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShoppingList)
+	err := c.cc.Invoke(ctx, ShoppingListService_CreateShoppingList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shoppingListServiceClient) GetShoppingList(ctx context.Context, in *GetShoppingListRequest, opts ...grpc.CallOption) (*ShoppingList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShoppingList)
+	err := c.cc.Invoke(ctx, ShoppingListService_GetShoppingList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shoppingListServiceClient) ListShoppingLists(ctx context.Context, in *ListShoppingListsRequest, opts ...grpc.CallOption) (*ListShoppingListsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShoppingListsResponse)
+	err := c.cc.Invoke(ctx, ShoppingListService_ListShoppingLists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shoppingListServiceClient) AddShoppingListItem(ctx context.Context, in *AddShoppingListItemRequest, opts ...grpc.CallOption) (*ShoppingList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShoppingList)
+	err := c.cc.Invoke(ctx, ShoppingListService_AddShoppingListItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shoppingListServiceClient) UpdateShoppingListItem(ctx context.Context, in *UpdateShoppingListItemRequest, opts ...grpc.CallOption) (*ShoppingList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShoppingList)
+	err := c.cc.Invoke(ctx, ShoppingListService_UpdateShoppingListItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shoppingListServiceClient) DeleteShoppingListItem(ctx context.Context, in *DeleteShoppingListItemRequest, opts ...grpc.CallOption) (*ShoppingList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShoppingList)
+	err := c.cc.Invoke(ctx, ShoppingListService_DeleteShoppingListItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ShoppingListServiceServer is the server API for ShoppingListService service.
+// All implementations must embed UnimplementedShoppingListServiceServer
+// for forward compatibility.
+type ShoppingListServiceServer interface {
+	CreateShoppingList(context.Context, *CreateShoppingListRequest) (*ShoppingList, error)
+	GetShoppingList(context.Context, *GetShoppingListRequest) (*ShoppingList, error)
+	ListShoppingLists(context.Context, *ListShoppingListsRequest) (*ListShoppingListsResponse, error)
+	AddShoppingListItem(context.Context, *AddShoppingListItemRequest) (*ShoppingList, error)
+	UpdateShoppingListItem(context.Context, *UpdateShoppingListItemRequest) (*ShoppingList, error)
+	DeleteShoppingListItem(context.Context, *DeleteShoppingListItemRequest) (*ShoppingList, error)
+	mustEmbedUnimplementedShoppingListServiceServer()
+}
+
+// UnimplementedShoppingListServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedShoppingListServiceServer struct{}
+
+func (UnimplementedShoppingListServiceServer) CreateShoppingList(context.Context, *CreateShoppingListRequest) (*ShoppingList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateShoppingList not implemented")
+}
+func (UnimplementedShoppingListServiceServer) GetShoppingList(context.Context, *GetShoppingListRequest) (*ShoppingList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShoppingList not implemented")
+}
+func (UnimplementedShoppingListServiceServer) ListShoppingLists(context.Context, *ListShoppingListsRequest) (*ListShoppingListsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShoppingLists not implemented")
+}
+func (UnimplementedShoppingListServiceServer) AddShoppingListItem(context.Context, *AddShoppingListItemRequest) (*ShoppingList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddShoppingListItem not implemented")
+}
+func (UnimplementedShoppingListServiceServer) UpdateShoppingListItem(context.Context, *UpdateShoppingListItemRequest) (*ShoppingList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateShoppingListItem not implemented")
+}
+func (UnimplementedShoppingListServiceServer) DeleteShoppingListItem(context.Context, *DeleteShoppingListItemRequest) (*ShoppingList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteShoppingListItem not implemented")
+}
+func (UnimplementedShoppingListServiceServer) mustEmbedUnimplementedShoppingListServiceServer() {}
+func (UnimplementedShoppingListServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeShoppingListServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ShoppingListServiceServer will
+// result in compilation errors.
+type UnsafeShoppingListServiceServer interface {
+	mustEmbedUnimplementedShoppingListServiceServer()
+}
+
+func RegisterShoppingListServiceServer(s grpc.ServiceRegistrar, srv ShoppingListServiceServer) {
+	// If the following call pancis, it indicates UnimplementedShoppingListServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ShoppingListService_ServiceDesc, srv)
+}
+
+func _ShoppingListService_CreateShoppingList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateShoppingListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShoppingListServiceServer).CreateShoppingList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShoppingListService_CreateShoppingList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShoppingListServiceServer).CreateShoppingList(ctx, req.(*CreateShoppingListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShoppingListService_GetShoppingList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShoppingListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShoppingListServiceServer).GetShoppingList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShoppingListService_GetShoppingList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShoppingListServiceServer).GetShoppingList(ctx, req.(*GetShoppingListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShoppingListService_ListShoppingLists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShoppingListsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShoppingListServiceServer).ListShoppingLists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShoppingListService_ListShoppingLists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShoppingListServiceServer).ListShoppingLists(ctx, req.(*ListShoppingListsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShoppingListService_AddShoppingListItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddShoppingListItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShoppingListServiceServer).AddShoppingListItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShoppingListService_AddShoppingListItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShoppingListServiceServer).AddShoppingListItem(ctx, req.(*AddShoppingListItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShoppingListService_UpdateShoppingListItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateShoppingListItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShoppingListServiceServer).UpdateShoppingListItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShoppingListService_UpdateShoppingListItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShoppingListServiceServer).UpdateShoppingListItem(ctx, req.(*UpdateShoppingListItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShoppingListService_DeleteShoppingListItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteShoppingListItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShoppingListServiceServer).DeleteShoppingListItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShoppingListService_DeleteShoppingListItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShoppingListServiceServer).DeleteShoppingListItem(ctx, req.(*DeleteShoppingListItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ShoppingListService_ServiceDesc is the grpc.ServiceDesc for ShoppingListService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ShoppingListService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.ShoppingListService",
+	HandlerType: (*ShoppingListServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateShoppingList",
+			Handler:    _ShoppingListService_CreateShoppingList_Handler,
+		},
+		{
+			MethodName: "GetShoppingList",
+			Handler:    _ShoppingListService_GetShoppingList_Handler,
+		},
+		{
+			MethodName: "ListShoppingLists",
+			Handler:    _ShoppingListService_ListShoppingLists_Handler,
+		},
+		{
+			MethodName: "AddShoppingListItem",
+			Handler:    _ShoppingListService_AddShoppingListItem_Handler,
+		},
+		{
+			MethodName: "UpdateShoppingListItem",
+			Handler:    _ShoppingListService_UpdateShoppingListItem_Handler,
+		},
+		{
+			MethodName: "DeleteShoppingListItem",
+			Handler:    _ShoppingListService_DeleteShoppingListItem_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/shopping_list_service.proto",
+}
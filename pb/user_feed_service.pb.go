@@ -22,8 +22,20 @@ const (
 )
 
 type GetUserAdsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Days of history each item's price_score compares against, defaults to 90
+	HistoryDays int32 `protobuf:"varint,2,opt,name=history_days,json=historyDays,proto3" json:"history_days,omitempty"`
+	// Only return items for these ingredients or their relatives in the ingredient hierarchy
+	IngredientIds []int32 `protobuf:"varint,3,rep,packed,name=ingredient_ids,json=ingredientIds,proto3" json:"ingredient_ids,omitempty"`
+	// Levels up or down the hierarchy an item may be from a requested ingredient. Defaults to 1, 0 is exact.
+	SubstitutionDepth *int32 `protobuf:"varint,4,opt,name=substitution_depth,json=substitutionDepth,proto3,oneof" json:"substitution_depth,omitempty"`
+	// List in-season items first within each ad
+	SeasonalBoost bool `protobuf:"varint,5,opt,name=seasonal_boost,json=seasonalBoost,proto3" json:"seasonal_boost,omitempty"`
+	// Month from 1 to 12 used for in_season, defaults to the current month
+	Month int32 `protobuf:"varint,6,opt,name=month,proto3" json:"month,omitempty"`
+	// "north" (default) or "south"
+	Hemisphere    string `protobuf:"bytes,7,opt,name=hemisphere,proto3" json:"hemisphere,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -65,6 +77,48 @@ func (x *GetUserAdsRequest) GetUserId() int32 {
 	return 0
 }
 
+func (x *GetUserAdsRequest) GetHistoryDays() int32 {
+	if x != nil {
+		return x.HistoryDays
+	}
+	return 0
+}
+
+func (x *GetUserAdsRequest) GetIngredientIds() []int32 {
+	if x != nil {
+		return x.IngredientIds
+	}
+	return nil
+}
+
+func (x *GetUserAdsRequest) GetSubstitutionDepth() int32 {
+	if x != nil && x.SubstitutionDepth != nil {
+		return *x.SubstitutionDepth
+	}
+	return 0
+}
+
+func (x *GetUserAdsRequest) GetSeasonalBoost() bool {
+	if x != nil {
+		return x.SeasonalBoost
+	}
+	return false
+}
+
+func (x *GetUserAdsRequest) GetMonth() int32 {
+	if x != nil {
+		return x.Month
+	}
+	return 0
+}
+
+func (x *GetUserAdsRequest) GetHemisphere() string {
+	if x != nil {
+		return x.Hemisphere
+	}
+	return ""
+}
+
 type GetUserAdsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Ads           []*Ad                  `protobuf:"bytes,1,rep,name=ads,proto3" json:"ads,omitempty"`
@@ -109,11 +163,19 @@ func (x *GetUserAdsResponse) GetAds() []*Ad {
 	return nil
 }
 
+// One flyer of a store. A store running several flyers at once, e.g. a weekly and a
+// monthly one, has an Ad for each.
 type Ad struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	StoreName     string                 `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
-	StoreAddress  string                 `protobuf:"bytes,2,opt,name=store_address,json=storeAddress,proto3" json:"store_address,omitempty"`
-	AdItems       []*AdItemData          `protobuf:"bytes,3,rep,name=ad_items,json=adItems,proto3" json:"ad_items,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	StoreName    string                 `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	StoreAddress string                 `protobuf:"bytes,2,opt,name=store_address,json=storeAddress,proto3" json:"store_address,omitempty"`
+	AdItems      []*AdItemData          `protobuf:"bytes,3,rep,name=ad_items,json=adItems,proto3" json:"ad_items,omitempty"`
+	// The flyer's sale window as YYYY-MM-DD
+	SaleStart string `protobuf:"bytes,4,opt,name=sale_start,json=saleStart,proto3" json:"sale_start,omitempty"`
+	SaleEnd   string `protobuf:"bytes,5,opt,name=sale_end,json=saleEnd,proto3" json:"sale_end,omitempty"`
+	// The ad source's flyer ID, empty when the source has none
+	FlyerId       string `protobuf:"bytes,6,opt,name=flyer_id,json=flyerId,proto3" json:"flyer_id,omitempty"`
+	StoreId       int32  `protobuf:"varint,7,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -169,6 +231,34 @@ func (x *Ad) GetAdItems() []*AdItemData {
 	return nil
 }
 
+func (x *Ad) GetSaleStart() string {
+	if x != nil {
+		return x.SaleStart
+	}
+	return ""
+}
+
+func (x *Ad) GetSaleEnd() string {
+	if x != nil {
+		return x.SaleEnd
+	}
+	return ""
+}
+
+func (x *Ad) GetFlyerId() string {
+	if x != nil {
+		return x.FlyerId
+	}
+	return ""
+}
+
+func (x *Ad) GetStoreId() int32 {
+	if x != nil {
+		return x.StoreId
+	}
+	return 0
+}
+
 type AdItemData struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Ingredient     string                 `protobuf:"bytes,1,opt,name=ingredient,proto3" json:"ingredient,omitempty"`
@@ -176,8 +266,19 @@ type AdItemData struct {
 	Price          *float32               `protobuf:"fixed32,3,opt,name=price,proto3,oneof" json:"price,omitempty"`
 	Sale           *string                `protobuf:"bytes,4,opt,name=sale,proto3,oneof" json:"sale,omitempty"`
 	IngredientType string                 `protobuf:"bytes,5,opt,name=ingredient_type,json=ingredientType,proto3" json:"ingredient_type,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	Deal           *Deal                  `protobuf:"bytes,6,opt,name=deal,proto3" json:"deal,omitempty"`
+	// Unset when the store has no earlier price for this ingredient and unit
+	PriceScore   *PriceScore `protobuf:"bytes,7,opt,name=price_score,json=priceScore,proto3" json:"price_score,omitempty"`
+	IngredientId int32       `protobuf:"varint,8,opt,name=ingredient_id,json=ingredientId,proto3" json:"ingredient_id,omitempty"`
+	// Levels between the item's ingredient and the nearest requested ingredient, 0 when
+	// no ingredient_ids were requested or the match is exact
+	SubstitutionDistance int32 `protobuf:"varint,9,opt,name=substitution_distance,json=substitutionDistance,proto3" json:"substitution_distance,omitempty"`
+	InSeason             bool  `protobuf:"varint,10,opt,name=in_season,json=inSeason,proto3" json:"in_season,omitempty"`
+	// When the item's price is valid, as YYYY-MM-DD
+	ValidFrom     string `protobuf:"bytes,11,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	ValidTo       string `protobuf:"bytes,12,opt,name=valid_to,json=validTo,proto3" json:"valid_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AdItemData) Reset() {
@@ -245,34 +346,1213 @@ func (x *AdItemData) GetIngredientType() string {
 	return ""
 }
 
-var File_proto_user_feed_service_proto protoreflect.FileDescriptor
+func (x *AdItemData) GetDeal() *Deal {
+	if x != nil {
+		return x.Deal
+	}
+	return nil
+}
 
-const file_proto_user_feed_service_proto_rawDesc = "" +
-	"\n" +
-	"\x1dproto/user_feed_service.proto\x12\x02pb\",\n" +
-	"\x11GetUserAdsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x05R\x06userId\".\n" +
-	"\x12GetUserAdsResponse\x12\x18\n" +
-	"\x03ads\x18\x01 \x03(\v2\x06.pb.AdR\x03ads\"s\n" +
-	"\x02Ad\x12\x1d\n" +
-	"\n" +
-	"store_name\x18\x01 \x01(\tR\tstoreName\x12#\n" +
-	"\rstore_address\x18\x02 \x01(\tR\fstoreAddress\x12)\n" +
-	"\bad_items\x18\x03 \x03(\v2\x0e.pb.AdItemDataR\aadItems\"\xb0\x01\n" +
-	"\n" +
-	"AdItemData\x12\x1e\n" +
-	"\n" +
-	"ingredient\x18\x01 \x01(\tR\n" +
-	"ingredient\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x19\n" +
-	"\x05price\x18\x03 \x01(\x02H\x00R\x05price\x88\x01\x01\x12\x17\n" +
-	"\x04sale\x18\x04 \x01(\tH\x01R\x04sale\x88\x01\x01\x12'\n" +
-	"\x0fingredient_type\x18\x05 \x01(\tR\x0eingredientTypeB\b\n" +
-	"\x06_priceB\a\n" +
-	"\x05_sale2N\n" +
+func (x *AdItemData) GetPriceScore() *PriceScore {
+	if x != nil {
+		return x.PriceScore
+	}
+	return nil
+}
+
+func (x *AdItemData) GetIngredientId() int32 {
+	if x != nil {
+		return x.IngredientId
+	}
+	return 0
+}
+
+func (x *AdItemData) GetSubstitutionDistance() int32 {
+	if x != nil {
+		return x.SubstitutionDistance
+	}
+	return 0
+}
+
+func (x *AdItemData) GetInSeason() bool {
+	if x != nil {
+		return x.InSeason
+	}
+	return false
+}
+
+func (x *AdItemData) GetValidFrom() string {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return ""
+}
+
+func (x *AdItemData) GetValidTo() string {
+	if x != nil {
+		return x.ValidTo
+	}
+	return ""
+}
+
+// How the current deal unit price compares with the store's history for the ingredient
+type PriceScore struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 1 at or below the historical minimum, 0 at or above the historical maximum
+	Score          float32 `protobuf:"fixed32,1,opt,name=score,proto3" json:"score,omitempty"`
+	LowestInWindow bool    `protobuf:"varint,2,opt,name=lowest_in_window,json=lowestInWindow,proto3" json:"lowest_in_window,omitempty"`
+	WindowDays     int32   `protobuf:"varint,3,opt,name=window_days,json=windowDays,proto3" json:"window_days,omitempty"`
+	HistoricalMin  float32 `protobuf:"fixed32,4,opt,name=historical_min,json=historicalMin,proto3" json:"historical_min,omitempty"`
+	HistoricalAvg  float32 `protobuf:"fixed32,5,opt,name=historical_avg,json=historicalAvg,proto3" json:"historical_avg,omitempty"`
+	HistoricalMax  float32 `protobuf:"fixed32,6,opt,name=historical_max,json=historicalMax,proto3" json:"historical_max,omitempty"`
+	Observations   int32   `protobuf:"varint,7,opt,name=observations,proto3" json:"observations,omitempty"`
+	// (price - avg) / avg, negative when cheaper than usual
+	ChangeVsAverage float32 `protobuf:"fixed32,8,opt,name=change_vs_average,json=changeVsAverage,proto3" json:"change_vs_average,omitempty"`
+	// e.g. "Lowest price in 90 days"
+	Badge         *string `protobuf:"bytes,9,opt,name=badge,proto3,oneof" json:"badge,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceScore) Reset() {
+	*x = PriceScore{}
+	mi := &file_proto_user_feed_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceScore) ProtoMessage() {}
+
+func (x *PriceScore) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_feed_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceScore.ProtoReflect.Descriptor instead.
+func (*PriceScore) Descriptor() ([]byte, []int) {
+	return file_proto_user_feed_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PriceScore) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *PriceScore) GetLowestInWindow() bool {
+	if x != nil {
+		return x.LowestInWindow
+	}
+	return false
+}
+
+func (x *PriceScore) GetWindowDays() int32 {
+	if x != nil {
+		return x.WindowDays
+	}
+	return 0
+}
+
+func (x *PriceScore) GetHistoricalMin() float32 {
+	if x != nil {
+		return x.HistoricalMin
+	}
+	return 0
+}
+
+func (x *PriceScore) GetHistoricalAvg() float32 {
+	if x != nil {
+		return x.HistoricalAvg
+	}
+	return 0
+}
+
+func (x *PriceScore) GetHistoricalMax() float32 {
+	if x != nil {
+		return x.HistoricalMax
+	}
+	return 0
+}
+
+func (x *PriceScore) GetObservations() int32 {
+	if x != nil {
+		return x.Observations
+	}
+	return 0
+}
+
+func (x *PriceScore) GetChangeVsAverage() float32 {
+	if x != nil {
+		return x.ChangeVsAverage
+	}
+	return 0
+}
+
+func (x *PriceScore) GetBadge() string {
+	if x != nil && x.Badge != nil {
+		return *x.Badge
+	}
+	return ""
+}
+
+// Structured form of price + sale text, e.g. "2 for $5" or "/lb with card"
+type Deal struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Price of one unit after multi-buy, BOGO and second-item terms
+	UnitPrice *float32 `protobuf:"fixed32,1,opt,name=unit_price,json=unitPrice,proto3,oneof" json:"unit_price,omitempty"`
+	// "each", "lb", "kg", "oz" or "g"
+	Unit               string   `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	Quantity           int32    `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	MultiBuyPrice      *float32 `protobuf:"fixed32,4,opt,name=multi_buy_price,json=multiBuyPrice,proto3,oneof" json:"multi_buy_price,omitempty"`
+	MembershipRequired bool     `protobuf:"varint,5,opt,name=membership_required,json=membershipRequired,proto3" json:"membership_required,omitempty"`
+	// Buy one get one free
+	Bogo bool `protobuf:"varint,6,opt,name=bogo,proto3" json:"bogo,omitempty"`
+	// Percentage off the second item of a buy one get one, e.g. 50 for "buy 1 get 1 50% off"
+	SecondItemPercentOff *float32 `protobuf:"fixed32,7,opt,name=second_item_percent_off,json=secondItemPercentOff,proto3,oneof" json:"second_item_percent_off,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Deal) Reset() {
+	*x = Deal{}
+	mi := &file_proto_user_feed_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Deal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Deal) ProtoMessage() {}
+
+func (x *Deal) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_feed_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Deal.ProtoReflect.Descriptor instead.
+func (*Deal) Descriptor() ([]byte, []int) {
+	return file_proto_user_feed_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Deal) GetUnitPrice() float32 {
+	if x != nil && x.UnitPrice != nil {
+		return *x.UnitPrice
+	}
+	return 0
+}
+
+func (x *Deal) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *Deal) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Deal) GetMultiBuyPrice() float32 {
+	if x != nil && x.MultiBuyPrice != nil {
+		return *x.MultiBuyPrice
+	}
+	return 0
+}
+
+func (x *Deal) GetMembershipRequired() bool {
+	if x != nil {
+		return x.MembershipRequired
+	}
+	return false
+}
+
+func (x *Deal) GetBogo() bool {
+	if x != nil {
+		return x.Bogo
+	}
+	return false
+}
+
+func (x *Deal) GetSecondItemPercentOff() float32 {
+	if x != nil && x.SecondItemPercentOff != nil {
+		return *x.SecondItemPercentOff
+	}
+	return 0
+}
+
+type Store struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The store's address as a single line
+	Address    string `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Street     string `protobuf:"bytes,4,opt,name=street,proto3" json:"street,omitempty"`
+	City       string `protobuf:"bytes,5,opt,name=city,proto3" json:"city,omitempty"`
+	State      string `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`
+	PostalCode string `protobuf:"bytes,7,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	// ISO 3166-1 alpha-2 country code
+	Country string `protobuf:"bytes,8,opt,name=country,proto3" json:"country,omitempty"`
+	// Unset when the store's postal code could not be geocoded
+	Latitude      *float64 `protobuf:"fixed64,9,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
+	Longitude     *float64 `protobuf:"fixed64,10,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Store) Reset() {
+	*x = Store{}
+	mi := &file_proto_user_feed_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Store) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Store) ProtoMessage() {}
+
+func (x *Store) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_feed_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Store.ProtoReflect.Descriptor instead.
+func (*Store) Descriptor() ([]byte, []int) {
+	return file_proto_user_feed_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Store) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Store) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Store) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Store) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *Store) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Store) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Store) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *Store) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Store) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *Store) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+// Find stores within radius_km of a postal code or of a latitude and longitude. When
+// both are given the coordinates win.
+type SearchStoresRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	PostalCode string                 `protobuf:"bytes,1,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	// Country of postal_code, defaults to "US"
+	Country   string   `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	Latitude  *float64 `protobuf:"fixed64,3,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
+	Longitude *float64 `protobuf:"fixed64,4,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
+	// Defaults to 10
+	RadiusKm float64 `protobuf:"fixed64,5,opt,name=radius_km,json=radiusKm,proto3" json:"radius_km,omitempty"`
+	// When set, each result says whether this user is subscribed to it
+	UserId int32 `protobuf:"varint,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Maximum number of stores returned, 0 for no limit
+	Limit         int32 `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchStoresRequest) Reset() {
+	*x = SearchStoresRequest{}
+	mi := &file_proto_user_feed_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchStoresRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchStoresRequest) ProtoMessage() {}
+
+func (x *SearchStoresRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_feed_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchStoresRequest.ProtoReflect.Descriptor instead.
+func (*SearchStoresRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_feed_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SearchStoresRequest) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *SearchStoresRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *SearchStoresRequest) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *SearchStoresRequest) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+func (x *SearchStoresRequest) GetRadiusKm() float64 {
+	if x != nil {
+		return x.RadiusKm
+	}
+	return 0
+}
+
+func (x *SearchStoresRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SearchStoresRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type NearbyStore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Store         *Store                 `protobuf:"bytes,1,opt,name=store,proto3" json:"store,omitempty"`
+	DistanceKm    float64                `protobuf:"fixed64,2,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+	Subscribed    bool                   `protobuf:"varint,3,opt,name=subscribed,proto3" json:"subscribed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NearbyStore) Reset() {
+	*x = NearbyStore{}
+	mi := &file_proto_user_feed_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearbyStore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearbyStore) ProtoMessage() {}
+
+func (x *NearbyStore) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_feed_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearbyStore.ProtoReflect.Descriptor instead.
+func (*NearbyStore) Descriptor() ([]byte, []int) {
+	return file_proto_user_feed_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *NearbyStore) GetStore() *Store {
+	if x != nil {
+		return x.Store
+	}
+	return nil
+}
+
+func (x *NearbyStore) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+func (x *NearbyStore) GetSubscribed() bool {
+	if x != nil {
+		return x.Subscribed
+	}
+	return false
+}
+
+type SearchStoresResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Nearest first
+	Stores []*NearbyStore `protobuf:"bytes,1,rep,name=stores,proto3" json:"stores,omitempty"`
+	// The point distances were measured from
+	Latitude      float64 `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64 `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchStoresResponse) Reset() {
+	*x = SearchStoresResponse{}
+	mi := &file_proto_user_feed_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchStoresResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchStoresResponse) ProtoMessage() {}
+
+func (x *SearchStoresResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_feed_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchStoresResponse.ProtoReflect.Descriptor instead.
+func (*SearchStoresResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_feed_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchStoresResponse) GetStores() []*NearbyStore {
+	if x != nil {
+		return x.Stores
+	}
+	return nil
+}
+
+func (x *SearchStoresResponse) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *SearchStoresResponse) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+type SubscribeStoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StoreId       int32                  `protobuf:"varint,2,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeStoreRequest) Reset() {
+	*x = SubscribeStoreRequest{}
+	mi := &file_proto_user_feed_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeStoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeStoreRequest) ProtoMessage() {}
+
+func (x *SubscribeStoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_feed_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeStoreRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeStoreRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_feed_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SubscribeStoreRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SubscribeStoreRequest) GetStoreId() int32 {
+	if x != nil {
+		return x.StoreId
+	}
+	return 0
+}
+
+type SubscribeStoreResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Store         *Store                 `protobuf:"bytes,1,opt,name=store,proto3" json:"store,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeStoreResponse) Reset() {
+	*x = SubscribeStoreResponse{}
+	mi := &file_proto_user_feed_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeStoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeStoreResponse) ProtoMessage() {}
+
+func (x *SubscribeStoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_feed_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeStoreResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeStoreResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_feed_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SubscribeStoreResponse) GetStore() *Store {
+	if x != nil {
+		return x.Store
+	}
+	return nil
+}
+
+type UnsubscribeStoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StoreId       int32                  `protobuf:"varint,2,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnsubscribeStoreRequest) Reset() {
+	*x = UnsubscribeStoreRequest{}
+	mi := &file_proto_user_feed_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnsubscribeStoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsubscribeStoreRequest) ProtoMessage() {}
+
+func (x *UnsubscribeStoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_feed_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsubscribeStoreRequest.ProtoReflect.Descriptor instead.
+func (*UnsubscribeStoreRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_feed_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UnsubscribeStoreRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UnsubscribeStoreRequest) GetStoreId() int32 {
+	if x != nil {
+		return x.StoreId
+	}
+	return 0
+}
+
+type UnsubscribeStoreResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnsubscribeStoreResponse) Reset() {
+	*x = UnsubscribeStoreResponse{}
+	mi := &file_proto_user_feed_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnsubscribeStoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsubscribeStoreResponse) ProtoMessage() {}
+
+func (x *UnsubscribeStoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_feed_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsubscribeStoreResponse.ProtoReflect.Descriptor instead.
+func (*UnsubscribeStoreResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_feed_service_proto_rawDescGZIP(), []int{13}
+}
+
+type ListSubscribedStoresRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubscribedStoresRequest) Reset() {
+	*x = ListSubscribedStoresRequest{}
+	mi := &file_proto_user_feed_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscribedStoresRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscribedStoresRequest) ProtoMessage() {}
+
+func (x *ListSubscribedStoresRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_feed_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubscribedStoresRequest.ProtoReflect.Descriptor instead.
+func (*ListSubscribedStoresRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_feed_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListSubscribedStoresRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ListSubscribedStoresResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stores        []*Store               `protobuf:"bytes,1,rep,name=stores,proto3" json:"stores,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubscribedStoresResponse) Reset() {
+	*x = ListSubscribedStoresResponse{}
+	mi := &file_proto_user_feed_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscribedStoresResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscribedStoresResponse) ProtoMessage() {}
+
+func (x *ListSubscribedStoresResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_feed_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubscribedStoresResponse.ProtoReflect.Descriptor instead.
+func (*ListSubscribedStoresResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_feed_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListSubscribedStoresResponse) GetStores() []*Store {
+	if x != nil {
+		return x.Stores
+	}
+	return nil
+}
+
+type GetPriceHistoryRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	IngredientId int32                  `protobuf:"varint,1,opt,name=ingredient_id,json=ingredientId,proto3" json:"ingredient_id,omitempty"`
+	StoreId      int32                  `protobuf:"varint,2,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"`
+	// Defaults to 30, 90 and 365 days
+	WindowDays    []int32 `protobuf:"varint,3,rep,packed,name=window_days,json=windowDays,proto3" json:"window_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceHistoryRequest) Reset() {
+	*x = GetPriceHistoryRequest{}
+	mi := &file_proto_user_feed_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryRequest) ProtoMessage() {}
+
+func (x *GetPriceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_feed_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_feed_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetPriceHistoryRequest) GetIngredientId() int32 {
+	if x != nil {
+		return x.IngredientId
+	}
+	return 0
+}
+
+func (x *GetPriceHistoryRequest) GetStoreId() int32 {
+	if x != nil {
+		return x.StoreId
+	}
+	return 0
+}
+
+func (x *GetPriceHistoryRequest) GetWindowDays() []int32 {
+	if x != nil {
+		return x.WindowDays
+	}
+	return nil
+}
+
+type PriceWindow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WindowDays    int32                  `protobuf:"varint,1,opt,name=window_days,json=windowDays,proto3" json:"window_days,omitempty"`
+	Unit          string                 `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	Min           float32                `protobuf:"fixed32,3,opt,name=min,proto3" json:"min,omitempty"`
+	Avg           float32                `protobuf:"fixed32,4,opt,name=avg,proto3" json:"avg,omitempty"`
+	Max           float32                `protobuf:"fixed32,5,opt,name=max,proto3" json:"max,omitempty"`
+	Observations  int32                  `protobuf:"varint,6,opt,name=observations,proto3" json:"observations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceWindow) Reset() {
+	*x = PriceWindow{}
+	mi := &file_proto_user_feed_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceWindow) ProtoMessage() {}
+
+func (x *PriceWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_feed_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceWindow.ProtoReflect.Descriptor instead.
+func (*PriceWindow) Descriptor() ([]byte, []int) {
+	return file_proto_user_feed_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PriceWindow) GetWindowDays() int32 {
+	if x != nil {
+		return x.WindowDays
+	}
+	return 0
+}
+
+func (x *PriceWindow) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *PriceWindow) GetMin() float32 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *PriceWindow) GetAvg() float32 {
+	if x != nil {
+		return x.Avg
+	}
+	return 0
+}
+
+func (x *PriceWindow) GetMax() float32 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *PriceWindow) GetObservations() int32 {
+	if x != nil {
+		return x.Observations
+	}
+	return 0
+}
+
+type GetPriceHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Windows       []*PriceWindow         `protobuf:"bytes,1,rep,name=windows,proto3" json:"windows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceHistoryResponse) Reset() {
+	*x = GetPriceHistoryResponse{}
+	mi := &file_proto_user_feed_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryResponse) ProtoMessage() {}
+
+func (x *GetPriceHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_feed_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_feed_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetPriceHistoryResponse) GetWindows() []*PriceWindow {
+	if x != nil {
+		return x.Windows
+	}
+	return nil
+}
+
+var File_proto_user_feed_service_proto protoreflect.FileDescriptor
+
+const file_proto_user_feed_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1dproto/user_feed_service.proto\x12\x02pb\"\x9e\x02\n" +
+	"\x11GetUserAdsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12!\n" +
+	"\fhistory_days\x18\x02 \x01(\x05R\vhistoryDays\x12%\n" +
+	"\x0eingredient_ids\x18\x03 \x03(\x05R\ringredientIds\x122\n" +
+	"\x12substitution_depth\x18\x04 \x01(\x05H\x00R\x11substitutionDepth\x88\x01\x01\x12%\n" +
+	"\x0eseasonal_boost\x18\x05 \x01(\bR\rseasonalBoost\x12\x14\n" +
+	"\x05month\x18\x06 \x01(\x05R\x05month\x12\x1e\n" +
+	"\n" +
+	"hemisphere\x18\a \x01(\tR\n" +
+	"hemisphereB\x15\n" +
+	"\x13_substitution_depth\".\n" +
+	"\x12GetUserAdsResponse\x12\x18\n" +
+	"\x03ads\x18\x01 \x03(\v2\x06.pb.AdR\x03ads\"\xe3\x01\n" +
+	"\x02Ad\x12\x1d\n" +
+	"\n" +
+	"store_name\x18\x01 \x01(\tR\tstoreName\x12#\n" +
+	"\rstore_address\x18\x02 \x01(\tR\fstoreAddress\x12)\n" +
+	"\bad_items\x18\x03 \x03(\v2\x0e.pb.AdItemDataR\aadItems\x12\x1d\n" +
+	"\n" +
+	"sale_start\x18\x04 \x01(\tR\tsaleStart\x12\x19\n" +
+	"\bsale_end\x18\x05 \x01(\tR\asaleEnd\x12\x19\n" +
+	"\bflyer_id\x18\x06 \x01(\tR\aflyerId\x12\x19\n" +
+	"\bstore_id\x18\a \x01(\x05R\astoreId\"\xb0\x03\n" +
+	"\n" +
+	"AdItemData\x12\x1e\n" +
+	"\n" +
+	"ingredient\x18\x01 \x01(\tR\n" +
+	"ingredient\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x19\n" +
+	"\x05price\x18\x03 \x01(\x02H\x00R\x05price\x88\x01\x01\x12\x17\n" +
+	"\x04sale\x18\x04 \x01(\tH\x01R\x04sale\x88\x01\x01\x12'\n" +
+	"\x0fingredient_type\x18\x05 \x01(\tR\x0eingredientType\x12\x1c\n" +
+	"\x04deal\x18\x06 \x01(\v2\b.pb.DealR\x04deal\x12/\n" +
+	"\vprice_score\x18\a \x01(\v2\x0e.pb.PriceScoreR\n" +
+	"priceScore\x12#\n" +
+	"\ringredient_id\x18\b \x01(\x05R\fingredientId\x123\n" +
+	"\x15substitution_distance\x18\t \x01(\x05R\x14substitutionDistance\x12\x1b\n" +
+	"\tin_season\x18\n" +
+	" \x01(\bR\binSeason\x12\x1d\n" +
+	"\n" +
+	"valid_from\x18\v \x01(\tR\tvalidFrom\x12\x19\n" +
+	"\bvalid_to\x18\f \x01(\tR\avalidToB\b\n" +
+	"\x06_priceB\a\n" +
+	"\x05_sale\"\xd7\x02\n" +
+	"\n" +
+	"PriceScore\x12\x14\n" +
+	"\x05score\x18\x01 \x01(\x02R\x05score\x12(\n" +
+	"\x10lowest_in_window\x18\x02 \x01(\bR\x0elowestInWindow\x12\x1f\n" +
+	"\vwindow_days\x18\x03 \x01(\x05R\n" +
+	"windowDays\x12%\n" +
+	"\x0ehistorical_min\x18\x04 \x01(\x02R\rhistoricalMin\x12%\n" +
+	"\x0ehistorical_avg\x18\x05 \x01(\x02R\rhistoricalAvg\x12%\n" +
+	"\x0ehistorical_max\x18\x06 \x01(\x02R\rhistoricalMax\x12\"\n" +
+	"\fobservations\x18\a \x01(\x05R\fobservations\x12*\n" +
+	"\x11change_vs_average\x18\b \x01(\x02R\x0fchangeVsAverage\x12\x19\n" +
+	"\x05badge\x18\t \x01(\tH\x00R\x05badge\x88\x01\x01B\b\n" +
+	"\x06_badge\"\xc7\x02\n" +
+	"\x04Deal\x12\"\n" +
+	"\n" +
+	"unit_price\x18\x01 \x01(\x02H\x00R\tunitPrice\x88\x01\x01\x12\x12\n" +
+	"\x04unit\x18\x02 \x01(\tR\x04unit\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12+\n" +
+	"\x0fmulti_buy_price\x18\x04 \x01(\x02H\x01R\rmultiBuyPrice\x88\x01\x01\x12/\n" +
+	"\x13membership_required\x18\x05 \x01(\bR\x12membershipRequired\x12\x12\n" +
+	"\x04bogo\x18\x06 \x01(\bR\x04bogo\x12:\n" +
+	"\x17second_item_percent_off\x18\a \x01(\x02H\x02R\x14secondItemPercentOff\x88\x01\x01B\r\n" +
+	"\v_unit_priceB\x12\n" +
+	"\x10_multi_buy_priceB\x1a\n" +
+	"\x18_second_item_percent_off\"\xa1\x02\n" +
+	"\x05Store\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x16\n" +
+	"\x06street\x18\x04 \x01(\tR\x06street\x12\x12\n" +
+	"\x04city\x18\x05 \x01(\tR\x04city\x12\x14\n" +
+	"\x05state\x18\x06 \x01(\tR\x05state\x12\x1f\n" +
+	"\vpostal_code\x18\a \x01(\tR\n" +
+	"postalCode\x12\x18\n" +
+	"\acountry\x18\b \x01(\tR\acountry\x12\x1f\n" +
+	"\blatitude\x18\t \x01(\x01H\x00R\blatitude\x88\x01\x01\x12!\n" +
+	"\tlongitude\x18\n" +
+	" \x01(\x01H\x01R\tlongitude\x88\x01\x01B\v\n" +
+	"\t_latitudeB\f\n" +
+	"\n" +
+	"_longitude\"\xfb\x01\n" +
+	"\x13SearchStoresRequest\x12\x1f\n" +
+	"\vpostal_code\x18\x01 \x01(\tR\n" +
+	"postalCode\x12\x18\n" +
+	"\acountry\x18\x02 \x01(\tR\acountry\x12\x1f\n" +
+	"\blatitude\x18\x03 \x01(\x01H\x00R\blatitude\x88\x01\x01\x12!\n" +
+	"\tlongitude\x18\x04 \x01(\x01H\x01R\tlongitude\x88\x01\x01\x12\x1b\n" +
+	"\tradius_km\x18\x05 \x01(\x01R\bradiusKm\x12\x17\n" +
+	"\auser_id\x18\x06 \x01(\x05R\x06userId\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limitB\v\n" +
+	"\t_latitudeB\f\n" +
+	"\n" +
+	"_longitude\"o\n" +
+	"\vNearbyStore\x12\x1f\n" +
+	"\x05store\x18\x01 \x01(\v2\t.pb.StoreR\x05store\x12\x1f\n" +
+	"\vdistance_km\x18\x02 \x01(\x01R\n" +
+	"distanceKm\x12\x1e\n" +
+	"\n" +
+	"subscribed\x18\x03 \x01(\bR\n" +
+	"subscribed\"y\n" +
+	"\x14SearchStoresResponse\x12'\n" +
+	"\x06stores\x18\x01 \x03(\v2\x0f.pb.NearbyStoreR\x06stores\x12\x1a\n" +
+	"\blatitude\x18\x02 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x03 \x01(\x01R\tlongitude\"K\n" +
+	"\x15SubscribeStoreRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x19\n" +
+	"\bstore_id\x18\x02 \x01(\x05R\astoreId\"9\n" +
+	"\x16SubscribeStoreResponse\x12\x1f\n" +
+	"\x05store\x18\x01 \x01(\v2\t.pb.StoreR\x05store\"M\n" +
+	"\x17UnsubscribeStoreRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x19\n" +
+	"\bstore_id\x18\x02 \x01(\x05R\astoreId\"\x1a\n" +
+	"\x18UnsubscribeStoreResponse\"6\n" +
+	"\x1bListSubscribedStoresRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"A\n" +
+	"\x1cListSubscribedStoresResponse\x12!\n" +
+	"\x06stores\x18\x01 \x03(\v2\t.pb.StoreR\x06stores\"y\n" +
+	"\x16GetPriceHistoryRequest\x12#\n" +
+	"\ringredient_id\x18\x01 \x01(\x05R\fingredientId\x12\x19\n" +
+	"\bstore_id\x18\x02 \x01(\x05R\astoreId\x12\x1f\n" +
+	"\vwindow_days\x18\x03 \x03(\x05R\n" +
+	"windowDays\"\x9c\x01\n" +
+	"\vPriceWindow\x12\x1f\n" +
+	"\vwindow_days\x18\x01 \x01(\x05R\n" +
+	"windowDays\x12\x12\n" +
+	"\x04unit\x18\x02 \x01(\tR\x04unit\x12\x10\n" +
+	"\x03min\x18\x03 \x01(\x02R\x03min\x12\x10\n" +
+	"\x03avg\x18\x04 \x01(\x02R\x03avg\x12\x10\n" +
+	"\x03max\x18\x05 \x01(\x02R\x03max\x12\"\n" +
+	"\fobservations\x18\x06 \x01(\x05R\fobservations\"D\n" +
+	"\x17GetPriceHistoryResponse\x12)\n" +
+	"\awindows\x18\x01 \x03(\v2\x0f.pb.PriceWindowR\awindows2\xd0\x03\n" +
 	"\x0fUserFeedService\x12;\n" +
 	"\n" +
-	"GetUserAds\x12\x15.pb.GetUserAdsRequest\x1a\x16.pb.GetUserAdsResponseB\x06Z\x04./pbb\x06proto3"
+	"GetUserAds\x12\x15.pb.GetUserAdsRequest\x1a\x16.pb.GetUserAdsResponse\x12G\n" +
+	"\x0eSubscribeStore\x12\x19.pb.SubscribeStoreRequest\x1a\x1a.pb.SubscribeStoreResponse\x12M\n" +
+	"\x10UnsubscribeStore\x12\x1b.pb.UnsubscribeStoreRequest\x1a\x1c.pb.UnsubscribeStoreResponse\x12Y\n" +
+	"\x14ListSubscribedStores\x12\x1f.pb.ListSubscribedStoresRequest\x1a .pb.ListSubscribedStoresResponse\x12J\n" +
+	"\x0fGetPriceHistory\x12\x1a.pb.GetPriceHistoryRequest\x1a\x1b.pb.GetPriceHistoryResponse\x12A\n" +
+	"\fSearchStores\x12\x17.pb.SearchStoresRequest\x1a\x18.pb.SearchStoresResponseB\x06Z\x04./pbb\x06proto3"
 
 var (
 	file_proto_user_feed_service_proto_rawDescOnce sync.Once
@@ -286,23 +1566,55 @@ func file_proto_user_feed_service_proto_rawDescGZIP() []byte {
 	return file_proto_user_feed_service_proto_rawDescData
 }
 
-var file_proto_user_feed_service_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_user_feed_service_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_proto_user_feed_service_proto_goTypes = []any{
-	(*GetUserAdsRequest)(nil),  // 0: pb.GetUserAdsRequest
-	(*GetUserAdsResponse)(nil), // 1: pb.GetUserAdsResponse
-	(*Ad)(nil),                 // 2: pb.Ad
-	(*AdItemData)(nil),         // 3: pb.AdItemData
+	(*GetUserAdsRequest)(nil),            // 0: pb.GetUserAdsRequest
+	(*GetUserAdsResponse)(nil),           // 1: pb.GetUserAdsResponse
+	(*Ad)(nil),                           // 2: pb.Ad
+	(*AdItemData)(nil),                   // 3: pb.AdItemData
+	(*PriceScore)(nil),                   // 4: pb.PriceScore
+	(*Deal)(nil),                         // 5: pb.Deal
+	(*Store)(nil),                        // 6: pb.Store
+	(*SearchStoresRequest)(nil),          // 7: pb.SearchStoresRequest
+	(*NearbyStore)(nil),                  // 8: pb.NearbyStore
+	(*SearchStoresResponse)(nil),         // 9: pb.SearchStoresResponse
+	(*SubscribeStoreRequest)(nil),        // 10: pb.SubscribeStoreRequest
+	(*SubscribeStoreResponse)(nil),       // 11: pb.SubscribeStoreResponse
+	(*UnsubscribeStoreRequest)(nil),      // 12: pb.UnsubscribeStoreRequest
+	(*UnsubscribeStoreResponse)(nil),     // 13: pb.UnsubscribeStoreResponse
+	(*ListSubscribedStoresRequest)(nil),  // 14: pb.ListSubscribedStoresRequest
+	(*ListSubscribedStoresResponse)(nil), // 15: pb.ListSubscribedStoresResponse
+	(*GetPriceHistoryRequest)(nil),       // 16: pb.GetPriceHistoryRequest
+	(*PriceWindow)(nil),                  // 17: pb.PriceWindow
+	(*GetPriceHistoryResponse)(nil),      // 18: pb.GetPriceHistoryResponse
 }
 var file_proto_user_feed_service_proto_depIdxs = []int32{
-	2, // 0: pb.GetUserAdsResponse.ads:type_name -> pb.Ad
-	3, // 1: pb.Ad.ad_items:type_name -> pb.AdItemData
-	0, // 2: pb.UserFeedService.GetUserAds:input_type -> pb.GetUserAdsRequest
-	1, // 3: pb.UserFeedService.GetUserAds:output_type -> pb.GetUserAdsResponse
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	2,  // 0: pb.GetUserAdsResponse.ads:type_name -> pb.Ad
+	3,  // 1: pb.Ad.ad_items:type_name -> pb.AdItemData
+	5,  // 2: pb.AdItemData.deal:type_name -> pb.Deal
+	4,  // 3: pb.AdItemData.price_score:type_name -> pb.PriceScore
+	6,  // 4: pb.NearbyStore.store:type_name -> pb.Store
+	8,  // 5: pb.SearchStoresResponse.stores:type_name -> pb.NearbyStore
+	6,  // 6: pb.SubscribeStoreResponse.store:type_name -> pb.Store
+	6,  // 7: pb.ListSubscribedStoresResponse.stores:type_name -> pb.Store
+	17, // 8: pb.GetPriceHistoryResponse.windows:type_name -> pb.PriceWindow
+	0,  // 9: pb.UserFeedService.GetUserAds:input_type -> pb.GetUserAdsRequest
+	10, // 10: pb.UserFeedService.SubscribeStore:input_type -> pb.SubscribeStoreRequest
+	12, // 11: pb.UserFeedService.UnsubscribeStore:input_type -> pb.UnsubscribeStoreRequest
+	14, // 12: pb.UserFeedService.ListSubscribedStores:input_type -> pb.ListSubscribedStoresRequest
+	16, // 13: pb.UserFeedService.GetPriceHistory:input_type -> pb.GetPriceHistoryRequest
+	7,  // 14: pb.UserFeedService.SearchStores:input_type -> pb.SearchStoresRequest
+	1,  // 15: pb.UserFeedService.GetUserAds:output_type -> pb.GetUserAdsResponse
+	11, // 16: pb.UserFeedService.SubscribeStore:output_type -> pb.SubscribeStoreResponse
+	13, // 17: pb.UserFeedService.UnsubscribeStore:output_type -> pb.UnsubscribeStoreResponse
+	15, // 18: pb.UserFeedService.ListSubscribedStores:output_type -> pb.ListSubscribedStoresResponse
+	18, // 19: pb.UserFeedService.GetPriceHistory:output_type -> pb.GetPriceHistoryResponse
+	9,  // 20: pb.UserFeedService.SearchStores:output_type -> pb.SearchStoresResponse
+	15, // [15:21] is the sub-list for method output_type
+	9,  // [9:15] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_proto_user_feed_service_proto_init() }
@@ -310,14 +1622,19 @@ func file_proto_user_feed_service_proto_init() {
 	if File_proto_user_feed_service_proto != nil {
 		return
 	}
+	file_proto_user_feed_service_proto_msgTypes[0].OneofWrappers = []any{}
 	file_proto_user_feed_service_proto_msgTypes[3].OneofWrappers = []any{}
+	file_proto_user_feed_service_proto_msgTypes[4].OneofWrappers = []any{}
+	file_proto_user_feed_service_proto_msgTypes[5].OneofWrappers = []any{}
+	file_proto_user_feed_service_proto_msgTypes[6].OneofWrappers = []any{}
+	file_proto_user_feed_service_proto_msgTypes[7].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_user_feed_service_proto_rawDesc), len(file_proto_user_feed_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
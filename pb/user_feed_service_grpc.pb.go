@@ -19,7 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserFeedService_GetUserAds_FullMethodName = "/pb.UserFeedService/GetUserAds"
+	UserFeedService_GetUserAds_FullMethodName           = "/pb.UserFeedService/GetUserAds"
+	UserFeedService_SubscribeStore_FullMethodName       = "/pb.UserFeedService/SubscribeStore"
+	UserFeedService_UnsubscribeStore_FullMethodName     = "/pb.UserFeedService/UnsubscribeStore"
+	UserFeedService_ListSubscribedStores_FullMethodName = "/pb.UserFeedService/ListSubscribedStores"
+	UserFeedService_GetPriceHistory_FullMethodName      = "/pb.UserFeedService/GetPriceHistory"
+	UserFeedService_SearchStores_FullMethodName         = "/pb.UserFeedService/SearchStores"
 )
 
 // UserFeedServiceClient is the client API for UserFeedService service.
@@ -27,6 +32,11 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type UserFeedServiceClient interface {
 	GetUserAds(ctx context.Context, in *GetUserAdsRequest, opts ...grpc.CallOption) (*GetUserAdsResponse, error)
+	SubscribeStore(ctx context.Context, in *SubscribeStoreRequest, opts ...grpc.CallOption) (*SubscribeStoreResponse, error)
+	UnsubscribeStore(ctx context.Context, in *UnsubscribeStoreRequest, opts ...grpc.CallOption) (*UnsubscribeStoreResponse, error)
+	ListSubscribedStores(ctx context.Context, in *ListSubscribedStoresRequest, opts ...grpc.CallOption) (*ListSubscribedStoresResponse, error)
+	GetPriceHistory(ctx context.Context, in *GetPriceHistoryRequest, opts ...grpc.CallOption) (*GetPriceHistoryResponse, error)
+	SearchStores(ctx context.Context, in *SearchStoresRequest, opts ...grpc.CallOption) (*SearchStoresResponse, error)
 }
 
 type userFeedServiceClient struct {
@@ -47,11 +57,66 @@ func (c *userFeedServiceClient) GetUserAds(ctx context.Context, in *GetUserAdsRe
 	return out, nil
 }
 
+func (c *userFeedServiceClient) SubscribeStore(ctx context.Context, in *SubscribeStoreRequest, opts ...grpc.CallOption) (*SubscribeStoreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubscribeStoreResponse)
+	err := c.cc.Invoke(ctx, UserFeedService_SubscribeStore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userFeedServiceClient) UnsubscribeStore(ctx context.Context, in *UnsubscribeStoreRequest, opts ...grpc.CallOption) (*UnsubscribeStoreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnsubscribeStoreResponse)
+	err := c.cc.Invoke(ctx, UserFeedService_UnsubscribeStore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userFeedServiceClient) ListSubscribedStores(ctx context.Context, in *ListSubscribedStoresRequest, opts ...grpc.CallOption) (*ListSubscribedStoresResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSubscribedStoresResponse)
+	err := c.cc.Invoke(ctx, UserFeedService_ListSubscribedStores_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userFeedServiceClient) GetPriceHistory(ctx context.Context, in *GetPriceHistoryRequest, opts ...grpc.CallOption) (*GetPriceHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPriceHistoryResponse)
+	err := c.cc.Invoke(ctx, UserFeedService_GetPriceHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userFeedServiceClient) SearchStores(ctx context.Context, in *SearchStoresRequest, opts ...grpc.CallOption) (*SearchStoresResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchStoresResponse)
+	err := c.cc.Invoke(ctx, UserFeedService_SearchStores_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserFeedServiceServer is the server API for UserFeedService service.
 // All implementations must embed UnimplementedUserFeedServiceServer
 // for forward compatibility.
 type UserFeedServiceServer interface {
 	GetUserAds(context.Context, *GetUserAdsRequest) (*GetUserAdsResponse, error)
+	SubscribeStore(context.Context, *SubscribeStoreRequest) (*SubscribeStoreResponse, error)
+	UnsubscribeStore(context.Context, *UnsubscribeStoreRequest) (*UnsubscribeStoreResponse, error)
+	ListSubscribedStores(context.Context, *ListSubscribedStoresRequest) (*ListSubscribedStoresResponse, error)
+	GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*GetPriceHistoryResponse, error)
+	SearchStores(context.Context, *SearchStoresRequest) (*SearchStoresResponse, error)
 	mustEmbedUnimplementedUserFeedServiceServer()
 }
 
@@ -65,6 +130,21 @@ type UnimplementedUserFeedServiceServer struct{}
 func (UnimplementedUserFeedServiceServer) GetUserAds(context.Context, *GetUserAdsRequest) (*GetUserAdsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserAds not implemented")
 }
+func (UnimplementedUserFeedServiceServer) SubscribeStore(context.Context, *SubscribeStoreRequest) (*SubscribeStoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubscribeStore not implemented")
+}
+func (UnimplementedUserFeedServiceServer) UnsubscribeStore(context.Context, *UnsubscribeStoreRequest) (*UnsubscribeStoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnsubscribeStore not implemented")
+}
+func (UnimplementedUserFeedServiceServer) ListSubscribedStores(context.Context, *ListSubscribedStoresRequest) (*ListSubscribedStoresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubscribedStores not implemented")
+}
+func (UnimplementedUserFeedServiceServer) GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*GetPriceHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPriceHistory not implemented")
+}
+func (UnimplementedUserFeedServiceServer) SearchStores(context.Context, *SearchStoresRequest) (*SearchStoresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchStores not implemented")
+}
 func (UnimplementedUserFeedServiceServer) mustEmbedUnimplementedUserFeedServiceServer() {}
 func (UnimplementedUserFeedServiceServer) testEmbeddedByValue()                         {}
 
@@ -104,6 +184,96 @@ func _UserFeedService_GetUserAds_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserFeedService_SubscribeStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubscribeStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserFeedServiceServer).SubscribeStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserFeedService_SubscribeStore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserFeedServiceServer).SubscribeStore(ctx, req.(*SubscribeStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserFeedService_UnsubscribeStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsubscribeStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserFeedServiceServer).UnsubscribeStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserFeedService_UnsubscribeStore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserFeedServiceServer).UnsubscribeStore(ctx, req.(*UnsubscribeStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserFeedService_ListSubscribedStores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubscribedStoresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserFeedServiceServer).ListSubscribedStores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserFeedService_ListSubscribedStores_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserFeedServiceServer).ListSubscribedStores(ctx, req.(*ListSubscribedStoresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserFeedService_GetPriceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserFeedServiceServer).GetPriceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserFeedService_GetPriceHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserFeedServiceServer).GetPriceHistory(ctx, req.(*GetPriceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserFeedService_SearchStores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchStoresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserFeedServiceServer).SearchStores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserFeedService_SearchStores_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserFeedServiceServer).SearchStores(ctx, req.(*SearchStoresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserFeedService_ServiceDesc is the grpc.ServiceDesc for UserFeedService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,6 +285,26 @@ var UserFeedService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUserAds",
 			Handler:    _UserFeedService_GetUserAds_Handler,
 		},
+		{
+			MethodName: "SubscribeStore",
+			Handler:    _UserFeedService_SubscribeStore_Handler,
+		},
+		{
+			MethodName: "UnsubscribeStore",
+			Handler:    _UserFeedService_UnsubscribeStore_Handler,
+		},
+		{
+			MethodName: "ListSubscribedStores",
+			Handler:    _UserFeedService_ListSubscribedStores_Handler,
+		},
+		{
+			MethodName: "GetPriceHistory",
+			Handler:    _UserFeedService_GetPriceHistory_Handler,
+		},
+		{
+			MethodName: "SearchStores",
+			Handler:    _UserFeedService_SearchStores_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/user_feed_service.proto",
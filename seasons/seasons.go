@@ -0,0 +1,87 @@
+// Package seasons answers whether produce is in season. Ingredient seasons are stored
+// as northern hemisphere month numbers (1-12); southern hemisphere months are shifted by
+// six.
+package seasons
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrInvalidMonth is returned for a month outside 1-12
+var ErrInvalidMonth = errors.New("seasons: month must be between 1 and 12")
+
+// ErrInvalidHemisphere is returned for a hemisphere other than north or south
+var ErrInvalidHemisphere = errors.New("seasons: hemisphere must be north or south")
+
+// Hemisphere selects which half of the globe months are read in
+type Hemisphere int
+
+const (
+	North Hemisphere = iota
+	South
+)
+
+func (h Hemisphere) String() string {
+	if h == South {
+		return "south"
+	}
+	return "north"
+}
+
+// ParseHemisphere reads "north"/"northern" or "south"/"southern". An empty string is North.
+func ParseHemisphere(s string) (Hemisphere, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "north", "northern":
+		return North, nil
+	case "south", "southern":
+		return South, nil
+	}
+	return North, ErrInvalidHemisphere
+}
+
+// Boost is the extra weight an in-season match carries when ranking with a seasonal
+// boost: an in-season match counts as 1.5 matches
+const Boost float32 = 0.5
+
+// Month returns a month as a number from 1 to 12, or ErrInvalidMonth. Zero means the
+// current month.
+func Month(month int32, now time.Time) (int, error) {
+	if month == 0 {
+		return int(now.Month()), nil
+	}
+	if month < 1 || month > 12 {
+		return 0, ErrInvalidMonth
+	}
+	return int(month), nil
+}
+
+// Stored converts a month in the given hemisphere to the northern hemisphere month that
+// seasons are stored as
+func Stored(month int, hemisphere Hemisphere) int {
+	if hemisphere == South {
+		return (month+5)%12 + 1
+	}
+	return month
+}
+
+// Local converts stored northern hemisphere months to the given hemisphere, sorted
+func Local(season []int, hemisphere Hemisphere) []int {
+	local := make([]int, 0, len(season))
+	for _, month := range season {
+		local = append(local, Stored(month, hemisphere))
+	}
+	slices.Sort(local)
+	return local
+}
+
+// InSeason reports whether an ingredient with the stored season is in season in month
+// of the given hemisphere. Ingredients without a season are never in season.
+func InSeason(season *[]int, month int, hemisphere Hemisphere) bool {
+	if season == nil {
+		return false
+	}
+	return slices.Contains(*season, Stored(month, hemisphere))
+}
@@ -0,0 +1,83 @@
+package seasons
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestStored(t *testing.T) {
+	tests := []struct {
+		month      int
+		hemisphere Hemisphere
+		want       int
+	}{
+		{1, North, 1},
+		{12, North, 12},
+		{1, South, 7},
+		{6, South, 12},
+		{7, South, 1},
+		{12, South, 6},
+	}
+	for _, tt := range tests {
+		if got := Stored(tt.month, tt.hemisphere); got != tt.want {
+			t.Errorf("Stored(%d, %v) = %d, want %d", tt.month, tt.hemisphere, got, tt.want)
+		}
+	}
+}
+
+func TestInSeason(t *testing.T) {
+	sprouts := []int{9, 10, 11}
+	tests := []struct {
+		name       string
+		season     *[]int
+		month      int
+		hemisphere Hemisphere
+		want       bool
+	}{
+		{"northern autumn", &sprouts, 10, North, true},
+		{"northern spring", &sprouts, 4, North, false},
+		{"southern autumn", &sprouts, 4, South, true},
+		{"southern spring", &sprouts, 10, South, false},
+		{"no season", nil, 10, North, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InSeason(tt.season, tt.month, tt.hemisphere); got != tt.want {
+				t.Errorf("InSeason() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocal(t *testing.T) {
+	if got, want := Local([]int{11, 12, 1}, South), []int{5, 6, 7}; !slices.Equal(got, want) {
+		t.Errorf("Local() = %v, want %v", got, want)
+	}
+}
+
+func TestMonth(t *testing.T) {
+	now := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+	if got, err := Month(0, now); err != nil || got != 3 {
+		t.Errorf("Month(0) = %d, %v", got, err)
+	}
+	if got, err := Month(11, now); err != nil || got != 11 {
+		t.Errorf("Month(11) = %d, %v", got, err)
+	}
+	for _, month := range []int32{-1, 13} {
+		if _, err := Month(month, now); err != ErrInvalidMonth {
+			t.Errorf("Month(%d) error = %v, want ErrInvalidMonth", month, err)
+		}
+	}
+}
+
+func TestParseHemisphere(t *testing.T) {
+	for input, want := range map[string]Hemisphere{"": North, "North": North, "southern": South, " south ": South} {
+		if got, err := ParseHemisphere(input); err != nil || got != want {
+			t.Errorf("ParseHemisphere(%q) = %v, %v", input, got, err)
+		}
+	}
+	if _, err := ParseHemisphere("east"); err != ErrInvalidHemisphere {
+		t.Errorf("ParseHemisphere(east) error = %v", err)
+	}
+}
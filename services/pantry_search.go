@@ -0,0 +1,288 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"backend/main/models"
+	"backend/main/pb"
+	"backend/main/taxonomy"
+	"backend/main/units"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PantrySearchOptions filters the recipes returned by SearchPantryRecipes
+type PantrySearchOptions struct {
+	// MaxMissing drops recipes missing more ingredients than this when set
+	MaxMissing *int
+	// FoodTypes keeps only recipes using at least one ingredient of these types when non-empty
+	FoodTypes map[models.FoodType]bool
+}
+
+// MissingIngredient is a recipe ingredient the user does not have enough of, with its
+// best sale if any. Shortfall is set when the pantry holds some but not all of it.
+// Distance is how many levels of the ingredient hierarchy separate it from the sale.
+type MissingIngredient struct {
+	RecipeIngredient models.RecipeIngredient
+	Shortfall        *units.Quantity
+	Sale             *SaleItem
+	Distance         int
+}
+
+// PantryRecipe is a recipe scored by how much of it is already in the user's pantry
+type PantryRecipe struct {
+	Recipe         models.Recipe
+	InPantry       int
+	PantryFraction float32
+	Missing        []MissingIngredient
+}
+
+// SearchPantryRecipes ranks recipes by the fraction of their ingredients already in the
+// user's pantry and reports whether each missing ingredient is on sale at a subscribed store
+func (s *RecommendationService) SearchPantryRecipes(ctx context.Context, req *pb.SearchPantryRecipesRequest) (*pb.SearchPantryRecipesResponse, error) {
+	fmt.Println("SearchPantryRecipes called with UserId:", req.UserId)
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	depth, err := substitutionDepth(req.SubstitutionDepth, s.SubstitutionDepth)
+	if err != nil {
+		return nil, err
+	}
+	options := PantrySearchOptions{}
+	if req.MaxMissing != nil {
+		if *req.MaxMissing < 0 {
+			return nil, status.Error(codes.InvalidArgument, "max_missing must not be negative")
+		}
+		maxMissing := int(*req.MaxMissing)
+		options.MaxMissing = &maxMissing
+	}
+	options.FoodTypes, err = parseFoodTypes(req.FoodTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	pantry, err := s.PantryModel.GetPantryItems(ctx, uint(req.UserId))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "loading pantry: %v", err)
+	}
+	sales, err := loadSaleIndex(ctx, s.StoreModel, s.AdModel, uint(req.UserId))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "loading subscribed store ads: %v", err)
+	}
+	recipes, err := s.RecipeModel.GetAllRecipesWithIngredients(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "loading recipes: %v", err)
+	}
+	tree, err := loadTaxonomy(ctx, s.IngredientModel)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "loading ingredient hierarchy: %v", err)
+	}
+	var foodTypes map[uint]models.FoodType
+	if len(options.FoodTypes) > 0 {
+		ingredients, err := s.IngredientModel.GetAllIngredients(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "loading ingredients: %v", err)
+		}
+		foodTypes = make(map[uint]models.FoodType, len(ingredients))
+		for _, ingredient := range ingredients {
+			foodTypes[*ingredient.ID] = ingredient.Type
+		}
+	}
+
+	ranked := rankPantryRecipes(recipes, pantry, sales, tree, depth, foodTypes, options)
+	if req.Limit > 0 && int(req.Limit) < len(ranked) {
+		ranked = ranked[:req.Limit]
+	}
+	var results []*pb.PantryRecipe
+	for _, recipe := range ranked {
+		results = append(results, pantryRecipeToPb(recipe))
+	}
+	return &pb.SearchPantryRecipesResponse{Recipes: results}, nil
+}
+
+// rankPantryRecipes orders recipes by pantry fraction, then by fewest missing ingredients.
+// Pantry items and sales within depth levels of a recipe ingredient in tree count towards it,
+// nearest first. foodTypes maps ingredient ids to their type and is only consulted when
+// options.FoodTypes is set.
+func rankPantryRecipes(recipes []models.Recipe, pantry []models.PantryIngredient, sales saleIndex, tree *taxonomy.Tree, depth int, foodTypes map[uint]models.FoodType, options PantrySearchOptions) []PantryRecipe {
+	have := make(map[uint][]models.PantryIngredient, len(pantry))
+	for _, item := range pantry {
+		if item.IngredientID != nil {
+			have[*item.IngredientID] = append(have[*item.IngredientID], item)
+		}
+	}
+
+	var ranked []PantryRecipe
+	for _, recipe := range recipes {
+		if len(recipe.Ingredient) == 0 {
+			continue
+		}
+		if len(options.FoodTypes) > 0 && !usesFoodType(recipe, foodTypes, options.FoodTypes) {
+			continue
+		}
+		result := PantryRecipe{Recipe: recipe}
+		for _, ri := range recipe.Ingredient {
+			var shortfall *units.Quantity
+			if ri.IngredientID != nil {
+				if held := nearestPantryItems(have, *ri.IngredientID, tree, depth); len(held) > 0 {
+					shortfall = pantryShortfall(ri, held)
+					if shortfall == nil {
+						result.InPantry++
+						continue
+					}
+				}
+			}
+			missing := MissingIngredient{RecipeIngredient: ri, Shortfall: shortfall}
+			if ri.IngredientID != nil {
+				if sale, distance, ok := sales.bestRelated(*ri.IngredientID, tree, depth); ok {
+					missing.Sale = &sale
+					missing.Distance = distance
+				}
+			}
+			result.Missing = append(result.Missing, missing)
+		}
+		if options.MaxMissing != nil && len(result.Missing) > *options.MaxMissing {
+			continue
+		}
+		result.PantryFraction = float32(result.InPantry) / float32(len(recipe.Ingredient))
+		ranked = append(ranked, result)
+	}
+	sort.SliceStable(ranked, func(a, b int) bool {
+		if ranked[a].PantryFraction != ranked[b].PantryFraction {
+			return ranked[a].PantryFraction > ranked[b].PantryFraction
+		}
+		return len(ranked[a].Missing) < len(ranked[b].Missing)
+	})
+	return ranked
+}
+
+// nearestPantryItems returns the pantry items for an ingredient or, failing that, for its
+// nearest relatives within depth levels
+func nearestPantryItems(have map[uint][]models.PantryIngredient, ingredientID uint, tree *taxonomy.Tree, depth int) []models.PantryIngredient {
+	var held []models.PantryIngredient
+	distance := 0
+	for _, relative := range tree.Related(ingredientID, depth) {
+		if len(held) > 0 && relative.Distance > distance {
+			break
+		}
+		if items := have[relative.ID]; len(items) > 0 {
+			held = append(held, items...)
+			distance = relative.Distance
+		}
+	}
+	return held
+}
+
+// pantryShortfall returns how much more of a recipe ingredient is needed than the pantry
+// holds, or nil when the pantry has enough. Amounts that cannot be parsed or converted
+// are treated as covered, since the ingredient is in the pantry.
+func pantryShortfall(ri models.RecipeIngredient, pantry []models.PantryIngredient) *units.Quantity {
+	if ri.Amount == nil {
+		return nil
+	}
+	var unit string
+	if ri.Unit != nil {
+		unit = *ri.Unit
+	}
+	need, err := units.ParseQuantity(*ri.Amount, unit)
+	if err != nil {
+		return nil
+	}
+	remaining := need
+	for _, item := range pantry {
+		if item.Quantity == nil {
+			return nil
+		}
+		var pantryUnit string
+		if item.Unit != nil {
+			pantryUnit = *item.Unit
+		}
+		held, err := units.ParseQuantity(*item.Quantity, pantryUnit)
+		if err != nil {
+			return nil
+		}
+		remaining, err = converter.Sub(remaining, held, ri.Name)
+		if err != nil {
+			return nil
+		}
+	}
+	if remaining.Value <= 0 {
+		return nil
+	}
+	return &remaining
+}
+
+// parseFoodTypes reads a food_types filter, matching names from models.FoodTypeNames
+// case-insensitively along with the aliases models.ParseFoodType knows. Empty names are
+// skipped, and nil means no filter.
+func parseFoodTypes(names []string) (map[models.FoodType]bool, error) {
+	var foodTypes map[models.FoodType]bool
+	known := models.FoodTypeNames()
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		foodType := models.ParseFoodType(name)
+		if i := slices.IndexFunc(known, func(known string) bool { return strings.EqualFold(known, name) }); i >= 0 {
+			foodType = models.ParseFoodType(known[i])
+		} else if foodType == models.Other {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown food type %q, expected one of %s", name, strings.Join(known, ", "))
+		}
+		if foodTypes == nil {
+			foodTypes = make(map[models.FoodType]bool)
+		}
+		foodTypes[foodType] = true
+	}
+	return foodTypes, nil
+}
+
+func usesFoodType(recipe models.Recipe, foodTypes map[uint]models.FoodType, wanted map[models.FoodType]bool) bool {
+	for _, ri := range recipe.Ingredient {
+		if ri.IngredientID == nil {
+			continue
+		}
+		if foodType, ok := foodTypes[*ri.IngredientID]; ok && wanted[foodType] {
+			return true
+		}
+	}
+	return false
+}
+
+func pantryRecipeToPb(recipe PantryRecipe) *pb.PantryRecipe {
+	out := &pb.PantryRecipe{
+		Title:           recipe.Recipe.Title,
+		Link:            recipe.Recipe.Link,
+		IngredientCount: int32(len(recipe.Recipe.Ingredient)),
+		InPantryCount:   int32(recipe.InPantry),
+		PantryFraction:  recipe.PantryFraction,
+	}
+	if recipe.Recipe.ID != nil {
+		out.RecipeId = int32(*recipe.Recipe.ID)
+	}
+	for _, missing := range recipe.Missing {
+		item := &pb.MissingIngredient{
+			Name:   missing.RecipeIngredient.Name,
+			Amount: missing.RecipeIngredient.Amount,
+			Unit:   missing.RecipeIngredient.Unit,
+			OnSale: missing.Sale != nil,
+		}
+		if missing.Shortfall != nil {
+			shortfall := missing.Shortfall.String()
+			item.Shortfall = &shortfall
+		}
+		if missing.RecipeIngredient.IngredientID != nil {
+			id := int32(*missing.RecipeIngredient.IngredientID)
+			item.IngredientId = &id
+		}
+		if missing.Sale != nil {
+			item.Sale = matchToPb(RecipeMatch{RecipeIngredient: missing.RecipeIngredient, Sale: *missing.Sale, Distance: missing.Distance})
+		}
+		out.MissingIngredients = append(out.MissingIngredients, item)
+	}
+	return out
+}
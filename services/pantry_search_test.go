@@ -0,0 +1,180 @@
+package services
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"backend/main/models"
+	"backend/main/taxonomy"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseFoodTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		want    map[models.FoodType]bool
+		wantErr bool
+	}{
+		{"no filter", nil, nil, false},
+		{"empty names skipped", []string{"", ""}, nil, false},
+		{"display names", []string{"Seafood", "Canned Goods"}, map[models.FoodType]bool{models.Seafood: true, models.Canned_Goods: true}, false},
+		{"any case", []string{"seafood", "CONDIMENTS/SPICES"}, map[models.FoodType]bool{models.Seafood: true, models.Condiments_Spices: true}, false},
+		{"alias", []string{"Spice"}, map[models.FoodType]bool{models.Condiments_Spices: true}, false},
+		{"other", []string{"other"}, map[models.FoodType]bool{models.Other: true}, false},
+		{"typo", []string{"Fruit", "Seafod"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFoodTypes(tt.names)
+			if tt.wantErr {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Fatalf("parseFoodTypes() error = %v, want InvalidArgument", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFoodTypes() error = %v", err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("parseFoodTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPantryShortfall(t *testing.T) {
+	tests := []struct {
+		name   string
+		need   models.RecipeIngredient
+		pantry []models.PantryIngredient
+		want   string
+	}{
+		{"enough", recipeIngredient(2, "milk", "1", "cup"), []models.PantryIngredient{pantryItem(2, "2", "cups")}, ""},
+		{"short", recipeIngredient(2, "milk", "2", "cups"), []models.PantryIngredient{pantryItem(2, "1", "cup")}, "1 cup"},
+		{"short across units", recipeIngredient(2, "milk", "2", "cups"), []models.PantryIngredient{pantryItem(2, "8", "fl oz")}, "1 cup"},
+		{"several pantry items", recipeIngredient(3, "eggs", "6", ""), []models.PantryIngredient{pantryItem(3, "2", ""), pantryItem(3, "3", "")}, "1 each"},
+		{"recipe without an amount", recipeIngredient(2, "milk", "", ""), []models.PantryIngredient{pantryItem(2, "1", "cup")}, ""},
+		{"unparsed recipe amount", recipeIngredient(2, "salt", "to taste", ""), []models.PantryIngredient{pantryItem(2, "1", "g")}, ""},
+		{"pantry without a quantity", recipeIngredient(2, "milk", "2", "cups"), []models.PantryIngredient{pantryItem(2, "", "")}, ""},
+		{"units that do not convert", recipeIngredient(5, "tomatoes", "2", "cans"), []models.PantryIngredient{pantryItem(5, "100", "g")}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if shortfall := pantryShortfall(tt.need, tt.pantry); shortfall != nil {
+				got = shortfall.String()
+			}
+			if got != tt.want {
+				t.Errorf("pantryShortfall() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// describePantryRecipe formats a ranked recipe as its title, pantry fraction and missing
+// ingredients, each with its shortfall and the store and distance of its sale
+func describePantryRecipe(recipe PantryRecipe) string {
+	var missing []string
+	for _, m := range recipe.Missing {
+		text := m.RecipeIngredient.Name
+		if m.Shortfall != nil {
+			text += " short " + m.Shortfall.String()
+		}
+		if m.Sale != nil {
+			text += fmt.Sprintf(" on sale at %s +%d", *m.Sale.Store.Name, m.Distance)
+		}
+		missing = append(missing, text)
+	}
+	return fmt.Sprintf("%s %.2f [%s]", recipe.Recipe.Title, recipe.PantryFraction, strings.Join(missing, ", "))
+}
+
+func TestRankPantryRecipes(t *testing.T) {
+	recipes := []models.Recipe{
+		{Title: "soup", Ingredient: []models.RecipeIngredient{recipeIngredient(20, "stock", "1", "quart"), recipeIngredient(21, "leek", "2", "")}},
+		{Title: "pancakes", Ingredient: []models.RecipeIngredient{
+			recipeIngredient(1, "flour", "2", "cups"), recipeIngredient(2, "milk", "1", "cup"), recipeIngredient(3, "eggs", "2", "")}},
+		{Title: "toast", Ingredient: []models.RecipeIngredient{recipeIngredient(7, "bread", "2", "slices")}},
+		{Title: "grilled cheese", Ingredient: []models.RecipeIngredient{recipeIngredient(7, "bread", "2", "slices"), recipeIngredient(10, "sharp cheddar", "", "")}},
+		{Title: "empty"},
+	}
+	pantry := []models.PantryIngredient{
+		pantryItem(1, "3", "cups"), pantryItem(2, "0.5", "cup"), pantryItem(3, "12", ""), pantryItem(7, "", ""),
+		// cheddar, the parent of sharp cheddar
+		pantryItem(11, "200", "g"),
+	}
+	sales := saleIndex{
+		2:  {saleAt(1, "Safeway", 2, 3.49)},
+		20: {saleAt(2, "Trader Joe's", 20, 2.99)},
+		// leek's parent, onion
+		22: {saleAt(1, "Safeway", 22, 0.99)},
+	}
+	tree := taxonomy.New(map[uint]uint{10: 11, 21: 22})
+	foodTypes := map[uint]models.FoodType{1: models.Baking, 2: models.Dairy, 3: models.Dairy, 7: models.Bakery, 10: models.Dairy,
+		20: models.Canned_Goods, 21: models.Vegetable}
+
+	tests := []struct {
+		name    string
+		depth   int
+		options PantrySearchOptions
+		want    []string
+	}{
+		{
+			name: "pantry fraction, then fewest missing",
+			want: []string{
+				"toast 1.00 []",
+				"pancakes 0.67 [milk short 0.5 cup on sale at Safeway +0]",
+				"grilled cheese 0.50 [sharp cheddar]",
+				"soup 0.00 [stock on sale at Trader Joe's +0, leek]",
+			},
+		},
+		{
+			name:  "relatives count within depth",
+			depth: 1,
+			want: []string{
+				"toast 1.00 []",
+				"grilled cheese 1.00 []",
+				"pancakes 0.67 [milk short 0.5 cup on sale at Safeway +0]",
+				"soup 0.00 [stock on sale at Trader Joe's +0, leek on sale at Safeway +1]",
+			},
+		},
+		{
+			name:    "max missing",
+			options: PantrySearchOptions{MaxMissing: ptr(1)},
+			want: []string{
+				"toast 1.00 []",
+				"pancakes 0.67 [milk short 0.5 cup on sale at Safeway +0]",
+				"grilled cheese 0.50 [sharp cheddar]",
+			},
+		},
+		{
+			name:    "nothing missing",
+			options: PantrySearchOptions{MaxMissing: ptr(0)},
+			want:    []string{"toast 1.00 []"},
+		},
+		{
+			name:    "food types",
+			options: PantrySearchOptions{FoodTypes: map[models.FoodType]bool{models.Dairy: true}},
+			want: []string{
+				"pancakes 0.67 [milk short 0.5 cup on sale at Safeway +0]",
+				"grilled cheese 0.50 [sharp cheddar]",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, recipe := range rankPantryRecipes(recipes, pantry, sales, tree, tt.depth, foodTypes, tt.options) {
+				got = append(got, describePantryRecipe(recipe))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("rankPantryRecipes() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
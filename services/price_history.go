@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"backend/main/deals"
+	"backend/main/models"
+	"backend/main/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultHistoryDays = 90
+
+var defaultHistoryWindows = []int32{30, 90, 365}
+
+// GetPriceHistory returns min/avg/max deal unit prices for an ingredient at a store over
+// each requested window
+func (s *UserFeedService) GetPriceHistory(ctx context.Context, req *pb.GetPriceHistoryRequest) (*pb.GetPriceHistoryResponse, error) {
+	if req.IngredientId <= 0 || req.StoreId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "ingredient_id and store_id are required")
+	}
+	windows := req.WindowDays
+	if len(windows) == 0 {
+		windows = defaultHistoryWindows
+	}
+	if _, err := s.lookupStore(ctx, uint(req.StoreId)); err != nil {
+		return nil, err
+	}
+	var out []*pb.PriceWindow
+	for _, days := range windows {
+		if days <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "window_days must be positive, got %d", days)
+		}
+		stats, err := s.AdModel.GetPriceStats(ctx, uint(req.StoreId), []uint{uint(req.IngredientId)}, int(days), nil)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "getting price history: %v", err)
+		}
+		var units []string
+		for key := range stats {
+			units = append(units, key.Unit)
+		}
+		sort.Strings(units)
+		for _, unit := range units {
+			window := stats[models.PriceKey{IngredientID: uint(req.IngredientId), Unit: unit}]
+			out = append(out, &pb.PriceWindow{
+				WindowDays:   days,
+				Unit:         unit,
+				Min:          window.Min,
+				Avg:          window.Avg,
+				Max:          window.Max,
+				Observations: int32(window.Count),
+			})
+		}
+	}
+	return &pb.GetPriceHistoryResponse{Windows: out}, nil
+}
+
+// scorePrice compares a deal's unit price with the store's history for the same ingredient
+// and unit. It returns nil when there is no price or no history to compare against.
+func scorePrice(deal deals.Deal, history map[models.PriceKey]models.PriceStats, ingredientID uint) *pb.PriceScore {
+	if deal.UnitPrice == nil {
+		return nil
+	}
+	stats, ok := history[models.PriceKey{IngredientID: ingredientID, Unit: deal.Unit}]
+	if !ok || stats.Count == 0 {
+		return nil
+	}
+	price := *deal.UnitPrice
+	score := &pb.PriceScore{
+		WindowDays:     int32(stats.WindowDays),
+		HistoricalMin:  stats.Min,
+		HistoricalAvg:  stats.Avg,
+		HistoricalMax:  stats.Max,
+		Observations:   int32(stats.Count),
+		LowestInWindow: price <= stats.Min,
+	}
+	switch {
+	case price <= stats.Min:
+		score.Score = 1
+	case price >= stats.Max:
+		score.Score = 0
+	default:
+		score.Score = (stats.Max - price) / (stats.Max - stats.Min)
+	}
+	if stats.Avg > 0 {
+		score.ChangeVsAverage = (price - stats.Avg) / stats.Avg
+	}
+	if score.LowestInWindow {
+		badge := fmt.Sprintf("Lowest price in %d days", stats.WindowDays)
+		score.Badge = &badge
+	}
+	return score
+}
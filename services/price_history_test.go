@@ -0,0 +1,65 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"backend/main/deals"
+	"backend/main/models"
+)
+
+func TestScorePrice(t *testing.T) {
+	const milk uint = 2
+	history := map[models.PriceKey]models.PriceStats{
+		{IngredientID: milk, Unit: "gal"}: {WindowDays: 90, Min: 3.00, Avg: 4.00, Max: 5.00, Count: 12},
+		{IngredientID: milk, Unit: "qt"}:  {WindowDays: 90, Min: 1.25, Avg: 1.25, Max: 1.25, Count: 3},
+		{IngredientID: milk, Unit: "oz"}:  {WindowDays: 90},
+	}
+	deal := func(price float32, unit string) deals.Deal {
+		return deals.Deal{UnitPrice: ptr(price), Unit: unit}
+	}
+	tests := []struct {
+		name       string
+		deal       deals.Deal
+		ingredient uint
+		wantNil    bool
+		score      float32
+		change     float32
+		badge      string
+	}{
+		{name: "no unit price", deal: deals.Deal{Unit: "gal"}, ingredient: milk, wantNil: true},
+		{name: "no history for the unit", deal: deal(3.50, "lb"), ingredient: milk, wantNil: true},
+		{name: "no history for the ingredient", deal: deal(3.50, "gal"), ingredient: 9, wantNil: true},
+		{name: "no observations", deal: deal(0.10, "oz"), ingredient: milk, wantNil: true},
+		{name: "interpolated", deal: deal(3.50, "gal"), ingredient: milk, score: 0.75, change: -0.125},
+		{name: "average", deal: deal(4.00, "gal"), ingredient: milk, score: 0.5, change: 0},
+		{name: "at the minimum", deal: deal(3.00, "gal"), ingredient: milk, score: 1, change: -0.25, badge: "Lowest price in 90 days"},
+		{name: "below the minimum", deal: deal(2.00, "gal"), ingredient: milk, score: 1, change: -0.5, badge: "Lowest price in 90 days"},
+		{name: "above the maximum", deal: deal(6.00, "gal"), ingredient: milk, score: 0, change: 0.5},
+		{name: "flat history at its price", deal: deal(1.25, "qt"), ingredient: milk, score: 1, change: 0, badge: "Lowest price in 90 days"},
+		{name: "flat history above its price", deal: deal(1.50, "qt"), ingredient: milk, score: 0, change: 0.2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scorePrice(tt.deal, history, tt.ingredient)
+			if tt.wantNil {
+				if got != nil {
+					t.Fatalf("scorePrice() = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("scorePrice() = nil")
+			}
+			if math.Abs(float64(got.Score-tt.score)) > 1e-6 || math.Abs(float64(got.ChangeVsAverage-tt.change)) > 1e-6 {
+				t.Errorf("score = %v, change vs average = %v, want %v and %v", got.Score, got.ChangeVsAverage, tt.score, tt.change)
+			}
+			if got.GetBadge() != tt.badge || got.LowestInWindow != (tt.badge != "") {
+				t.Errorf("badge = %q, lowest in window = %v, want %q", got.GetBadge(), got.LowestInWindow, tt.badge)
+			}
+			if got.WindowDays != 90 || got.Observations == 0 {
+				t.Errorf("window days = %d, observations = %d", got.WindowDays, got.Observations)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"backend/main/models"
+	"backend/main/pb"
+	"backend/main/seasons"
+	"backend/main/taxonomy"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type RecommendationService struct {
+	pb.UnimplementedRecommendationServiceServer
+	StoreModel      *models.StoreModel
+	AdModel         *models.AdModel
+	RecipeModel     *models.RecipeModel
+	PantryModel     *models.PantryModel
+	IngredientModel *models.IngredientModel
+	// SubstitutionDepth is how many levels up or down the ingredient hierarchy a match may
+	// roll when a request does not say
+	SubstitutionDepth int
+}
+
+func NewRecommendationService(storeModel *models.StoreModel, adModel *models.AdModel, recipeModel *models.RecipeModel, pantryModel *models.PantryModel, ingredientModel *models.IngredientModel) *RecommendationService {
+	return &RecommendationService{
+		StoreModel:        storeModel,
+		AdModel:           adModel,
+		RecipeModel:       recipeModel,
+		PantryModel:       pantryModel,
+		IngredientModel:   ingredientModel,
+		SubstitutionDepth: taxonomy.DefaultDepth,
+	}
+}
+
+// RecipeMatch is a recipe ingredient that is on sale at one of the user's stores. Distance
+// is how many levels of the ingredient hierarchy separate it from the ingredient on sale.
+type RecipeMatch struct {
+	RecipeIngredient models.RecipeIngredient
+	Sale             SaleItem
+	Distance         int
+	InSeason         bool
+}
+
+// RecipeRecommendation is a recipe scored against the user's current sales
+type RecipeRecommendation struct {
+	Recipe   models.Recipe
+	Matches  []RecipeMatch
+	InSeason int
+	Savings  float32
+	Score    float32
+}
+
+// RecommendationOptions controls how rankRecipes matches and orders recipes
+type RecommendationOptions struct {
+	// Tree and Depth let a sale within Depth levels of a recipe ingredient match it
+	Tree  *taxonomy.Tree
+	Depth int
+	// InSeason holds the ids of ingredients currently in season
+	InSeason map[uint]bool
+	// SeasonalBoost weighs in-season matches by 1 + seasons.Boost when ordering
+	SeasonalBoost bool
+}
+
+// weight is the number of matches used for ordering, boosted for in-season matches
+func (rec RecipeRecommendation) weight(seasonalBoost bool) float32 {
+	weight := float32(len(rec.Matches))
+	if seasonalBoost {
+		weight += seasons.Boost * float32(rec.InSeason)
+	}
+	return weight
+}
+
+// GetRecipeRecommendations ranks recipes by how many of their ingredients are on sale
+// at the user's subscribed stores, then by how much the user would save
+func (s *RecommendationService) GetRecipeRecommendations(ctx context.Context, req *pb.GetRecipeRecommendationsRequest) (*pb.GetRecipeRecommendationsResponse, error) {
+	fmt.Println("GetRecipeRecommendations called with UserId:", req.UserId)
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	depth, err := substitutionDepth(req.SubstitutionDepth, s.SubstitutionDepth)
+	if err != nil {
+		return nil, err
+	}
+	when, err := parseSeason(req.Month, req.Hemisphere, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	tree, err := loadTaxonomy(ctx, s.IngredientModel)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "loading ingredient hierarchy: %v", err)
+	}
+	inSeason, err := inSeasonIngredients(ctx, s.IngredientModel, when)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "loading in-season ingredients: %v", err)
+	}
+	sales, err := loadSaleIndex(ctx, s.StoreModel, s.AdModel, uint(req.UserId))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "loading subscribed store ads: %v", err)
+	}
+	recipes, err := s.RecipeModel.GetAllRecipesWithIngredients(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "loading recipes: %v", err)
+	}
+
+	ranked := rankRecipes(recipes, sales, RecommendationOptions{
+		Tree:          tree,
+		Depth:         depth,
+		InSeason:      inSeason,
+		SeasonalBoost: req.SeasonalBoost,
+	})
+	if req.Limit > 0 && int(req.Limit) < len(ranked) {
+		ranked = ranked[:req.Limit]
+	}
+	var recommendations []*pb.RecipeRecommendation
+	for _, rec := range ranked {
+		recommendations = append(recommendations, recommendationToPb(rec))
+	}
+	return &pb.GetRecipeRecommendationsResponse{Recommendations: recommendations}, nil
+}
+
+// rankRecipes scores every recipe with at least one ingredient on sale. Score is the
+// fraction of the recipe's ingredients on sale; results are ordered by matched count,
+// boosted for in-season matches when options.SeasonalBoost is set, then savings, then
+// score. An ingredient matches the nearest sale within options.Depth levels of it.
+func rankRecipes(recipes []models.Recipe, sales saleIndex, options RecommendationOptions) []RecipeRecommendation {
+	var ranked []RecipeRecommendation
+	for _, recipe := range recipes {
+		if len(recipe.Ingredient) == 0 {
+			continue
+		}
+		rec := RecipeRecommendation{Recipe: recipe}
+		for _, ri := range recipe.Ingredient {
+			if ri.IngredientID == nil {
+				continue
+			}
+			sale, distance, ok := sales.bestRelated(*ri.IngredientID, options.Tree, options.Depth)
+			if !ok {
+				continue
+			}
+			match := RecipeMatch{RecipeIngredient: ri, Sale: sale, Distance: distance, InSeason: options.InSeason[sale.Item.IngredientID]}
+			if match.InSeason {
+				rec.InSeason++
+			}
+			rec.Matches = append(rec.Matches, match)
+			rec.Savings += sale.Savings()
+		}
+		if len(rec.Matches) == 0 {
+			continue
+		}
+		rec.Score = float32(len(rec.Matches)) / float32(len(recipe.Ingredient))
+		ranked = append(ranked, rec)
+	}
+	sort.SliceStable(ranked, func(a, b int) bool {
+		if wa, wb := ranked[a].weight(options.SeasonalBoost), ranked[b].weight(options.SeasonalBoost); wa != wb {
+			return wa > wb
+		}
+		if ranked[a].Savings != ranked[b].Savings {
+			return ranked[a].Savings > ranked[b].Savings
+		}
+		return ranked[a].Score > ranked[b].Score
+	})
+	return ranked
+}
+
+func recommendationToPb(rec RecipeRecommendation) *pb.RecipeRecommendation {
+	out := &pb.RecipeRecommendation{
+		Title:           rec.Recipe.Title,
+		Link:            rec.Recipe.Link,
+		IngredientCount: int32(len(rec.Recipe.Ingredient)),
+		MatchedCount:    int32(len(rec.Matches)),
+		Savings:         rec.Savings,
+		Score:           rec.Score,
+		InSeasonCount:   int32(rec.InSeason),
+	}
+	if rec.Recipe.ID != nil {
+		out.RecipeId = int32(*rec.Recipe.ID)
+	}
+	for _, match := range rec.Matches {
+		out.MatchedIngredients = append(out.MatchedIngredients, matchToPb(match))
+	}
+	return out
+}
+
+func matchToPb(match RecipeMatch) *pb.MatchedIngredient {
+	out := &pb.MatchedIngredient{
+		IngredientId:         int32(match.Sale.Item.IngredientID),
+		RecipeIngredient:     match.RecipeIngredient.Name,
+		AdItemName:           match.Sale.Item.Name,
+		Price:                match.Sale.Item.Price,
+		OriginalPrice:        match.Sale.Item.OriginalPrice,
+		Sale:                 match.Sale.Item.Sale,
+		SubstitutionDistance: int32(match.Distance),
+		InSeason:             match.InSeason,
+	}
+	if match.Sale.Store.ID != nil {
+		out.StoreId = int32(*match.Sale.Store.ID)
+	}
+	if match.Sale.Store.Name != nil {
+		out.StoreName = *match.Sale.Store.Name
+	}
+	return out
+}
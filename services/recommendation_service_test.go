@@ -0,0 +1,106 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"backend/main/models"
+	"backend/main/taxonomy"
+)
+
+// discounted is a sale marked down from original
+func discounted(sale SaleItem, original float32) SaleItem {
+	sale.Item.OriginalPrice = ptr(original)
+	return sale
+}
+
+func recipeOf(title string, ingredientIDs ...uint) models.Recipe {
+	recipe := models.Recipe{Title: title}
+	for _, id := range ingredientIDs {
+		name := fmt.Sprintf("ingredient %d", id)
+		if id == 0 {
+			name = "unknown"
+		}
+		recipe.Ingredient = append(recipe.Ingredient, recipeIngredient(id, name, "", ""))
+	}
+	return recipe
+}
+
+func TestSaleItemSavings(t *testing.T) {
+	tests := []struct {
+		name string
+		sale SaleItem
+		want float32
+	}{
+		{"marked down", discounted(saleAt(1, "Safeway", 1, 2.50), 4.00), 1.50},
+		{"no original price", saleAt(1, "Safeway", 1, 2.50), 0},
+		{"no price", discounted(saleAt(1, "Safeway", 1, -1), 4.00), 0},
+		{"original below price", discounted(saleAt(1, "Safeway", 1, 2.50), 2.00), 0},
+	}
+	for _, tt := range tests {
+		if got := tt.sale.Savings(); got != tt.want {
+			t.Errorf("%s: Savings() = %.2f, want %.2f", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRankRecipes(t *testing.T) {
+	sales := saleIndex{
+		1: {discounted(saleAt(1, "Safeway", 1, 2.00), 3.00)},
+		// the cheapest sale is used, not the biggest markdown
+		2: {discounted(saleAt(1, "Safeway", 2, 1.20), 2.00), discounted(saleAt(2, "Trader Joe's", 2, 1.00), 1.50)},
+		4: {discounted(saleAt(1, "Safeway", 4, 2.00), 4.00)},
+		5: {saleAt(2, "Trader Joe's", 5, 3.00)},
+		8: {saleAt(2, "Trader Joe's", 8, 0.99)},
+		// cheddar, the parent of sharp cheddar
+		11: {discounted(saleAt(1, "Safeway", 11, 3.00), 3.25)},
+	}
+	tree := taxonomy.New(map[uint]uint{10: 11})
+	recipes := []models.Recipe{
+		recipeOf("pasta", 1, 2, 3),
+		recipeOf("salad", 2, 4),
+		recipeOf("toast", 5, 6),
+		recipeOf("soup", 7),
+		recipeOf("rice", 0, 1),
+		recipeOf("omelet", 8),
+		recipeOf("grilled cheese", 10, 12),
+		recipeOf("empty"),
+	}
+	tests := []struct {
+		name    string
+		options RecommendationOptions
+		want    []string
+	}{
+		{
+			// salad and pasta match twice and salad saves more; among single matches rice
+			// saves most, then omelet beats toast on score
+			name: "matches, then savings, then score",
+			want: []string{"salad 2 2.50 1.00", "pasta 2 1.50 0.67", "rice 1 1.00 0.50", "omelet 1 0.00 1.00", "toast 1 0.00 0.50"},
+		},
+		{
+			name:    "substitution",
+			options: RecommendationOptions{Tree: tree, Depth: 1},
+			want: []string{"salad 2 2.50 1.00", "pasta 2 1.50 0.67", "rice 1 1.00 0.50", "grilled cheese 1 0.25 0.50",
+				"omelet 1 0.00 1.00", "toast 1 0.00 0.50"},
+		},
+		{
+			// in-season matches lift omelet and toast above rice's larger savings
+			name:    "seasonal boost",
+			options: RecommendationOptions{InSeason: map[uint]bool{5: true, 8: true}, SeasonalBoost: true},
+			want:    []string{"salad 2 2.50 1.00", "pasta 2 1.50 0.67", "omelet 1 0.00 1.00", "toast 1 0.00 0.50", "rice 1 1.00 0.50"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, rec := range rankRecipes(recipes, sales, tt.options) {
+				got = append(got, fmt.Sprintf("%s %d %.2f %.2f", rec.Recipe.Title, len(rec.Matches), rec.Savings, rec.Score))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("rankRecipes() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"backend/main/models"
+	"backend/main/taxonomy"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SaleItem is an ad item currently on sale at one of a user's subscribed stores
+type SaleItem struct {
+	Store models.Store
+	Item  models.AdIngredient
+}
+
+// Savings returns original_price - price when the ad reports both, otherwise 0
+func (s SaleItem) Savings() float32 {
+	if s.Item.Price == nil || s.Item.OriginalPrice == nil || *s.Item.OriginalPrice <= *s.Item.Price {
+		return 0
+	}
+	return *s.Item.OriginalPrice - *s.Item.Price
+}
+
+// saleIndex maps an ingredient id to every sale item for it across a user's stores
+type saleIndex map[uint][]SaleItem
+
+// loadSaleIndex collects the items of every ad active today at the stores the user is
+// subscribed to. Stores without an active ad are skipped.
+func loadSaleIndex(ctx context.Context, storeModel models.StoreRepository, adModel models.AdRepository, userID uint) (saleIndex, error) {
+	stores, err := storeModel.GetSubscribedStores(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	index := make(saleIndex)
+	today := time.Now()
+	for _, store := range stores {
+		ads, err := adModel.GetActiveAds(ctx, *store.ID, today)
+		if err != nil {
+			return nil, err
+		}
+		for _, ad := range ads {
+			for _, item := range ad.Ingredient {
+				index[item.IngredientID] = append(index[item.IngredientID], SaleItem{Store: store, Item: item})
+			}
+		}
+	}
+	return index, nil
+}
+
+// best returns the cheapest sale item for an ingredient. Items without a price
+// (e.g. BOGO offers) are only chosen when nothing priced is on sale.
+func (s saleIndex) best(ingredientID uint) (SaleItem, bool) {
+	items := s[ingredientID]
+	if len(items) == 0 {
+		return SaleItem{}, false
+	}
+	best := items[0]
+	for _, item := range items[1:] {
+		if item.Item.Price == nil {
+			continue
+		}
+		if best.Item.Price == nil || *item.Item.Price < *best.Item.Price {
+			best = item
+		}
+	}
+	return best, true
+}
+
+// bestRelated returns the cheapest sale item for an ingredient or, failing that, for the
+// nearest ingredient within depth levels of it in the hierarchy, along with how many
+// levels away the matched ingredient is
+func (s saleIndex) bestRelated(ingredientID uint, tree *taxonomy.Tree, depth int) (SaleItem, int, bool) {
+	var best SaleItem
+	found := false
+	distance := 0
+	for _, relative := range tree.Related(ingredientID, depth) {
+		if found && relative.Distance > distance {
+			break
+		}
+		sale, ok := s.best(relative.ID)
+		if !ok {
+			continue
+		}
+		if !found || cheaper(sale, best) {
+			best, distance, found = sale, relative.Distance, true
+		}
+	}
+	return best, distance, found
+}
+
+// cheaper reports whether a is priced below b, treating an unpriced item as the most expensive
+func cheaper(a SaleItem, b SaleItem) bool {
+	if a.Item.Price == nil {
+		return false
+	}
+	return b.Item.Price == nil || *a.Item.Price < *b.Item.Price
+}
+
+// loadTaxonomy builds the ingredient hierarchy used for substitution
+func loadTaxonomy(ctx context.Context, ingredientModel models.IngredientRepository) (*taxonomy.Tree, error) {
+	parents, err := ingredientModel.GetIngredientParents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return taxonomy.New(parents), nil
+}
+
+// substitutionDepth returns the request's depth when set, otherwise the service default
+func substitutionDepth(requested *int32, fallback int) (int, error) {
+	if requested == nil {
+		return fallback, nil
+	}
+	if *requested < 0 {
+		return 0, status.Error(codes.InvalidArgument, "substitution_depth must not be negative")
+	}
+	return int(*requested), nil
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend/main/models"
+	"backend/main/pb"
+	"backend/main/seasons"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// season is the month and hemisphere a request asks about
+type season struct {
+	Month      int
+	Hemisphere seasons.Hemisphere
+}
+
+// parseSeason reads a request's month and hemisphere, defaulting to the current month
+// in the northern hemisphere
+func parseSeason(month int32, hemisphere string, now time.Time) (season, error) {
+	m, err := seasons.Month(month, now)
+	if err != nil {
+		return season{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	h, err := seasons.ParseHemisphere(hemisphere)
+	if err != nil {
+		return season{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return season{Month: m, Hemisphere: h}, nil
+}
+
+// InSeason reports whether an ingredient with the stored season is in season
+func (s season) InSeason(months *[]int) bool {
+	return seasons.InSeason(months, s.Month, s.Hemisphere)
+}
+
+// GetSeasonalIngredients returns the ingredients in season for a month, read in either
+// hemisphere
+func (s *RecommendationService) GetSeasonalIngredients(ctx context.Context, req *pb.GetSeasonalIngredientsRequest) (*pb.GetSeasonalIngredientsResponse, error) {
+	fmt.Println("GetSeasonalIngredients called with Month:", req.Month, "Hemisphere:", req.Hemisphere)
+	when, err := parseSeason(req.Month, req.Hemisphere, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	foodTypes, err := parseFoodTypes(req.FoodTypes)
+	if err != nil {
+		return nil, err
+	}
+	ingredients, err := s.IngredientModel.GetIngredientsInSeason(ctx, seasons.Stored(when.Month, when.Hemisphere))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "loading in-season ingredients: %v", err)
+	}
+	out := &pb.GetSeasonalIngredientsResponse{Month: int32(when.Month), Hemisphere: when.Hemisphere.String()}
+	for _, ingredient := range ingredients {
+		if foodTypes != nil && !foodTypes[ingredient.Type] {
+			continue
+		}
+		out.Ingredients = append(out.Ingredients, seasonalIngredientToPb(ingredient, when.Hemisphere))
+	}
+	return out, nil
+}
+
+// inSeasonIngredients returns the ids of every ingredient in season
+func inSeasonIngredients(ctx context.Context, ingredientModel models.IngredientRepository, when season) (map[uint]bool, error) {
+	ingredients, err := ingredientModel.GetIngredientsInSeason(ctx, seasons.Stored(when.Month, when.Hemisphere))
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[uint]bool, len(ingredients))
+	for _, ingredient := range ingredients {
+		if ingredient.ID != nil {
+			ids[*ingredient.ID] = true
+		}
+	}
+	return ids, nil
+}
+
+func seasonalIngredientToPb(ingredient models.Ingredient, hemisphere seasons.Hemisphere) *pb.SeasonalIngredient {
+	out := &pb.SeasonalIngredient{
+		Name: ingredient.Name,
+		Type: ingredient.Type.String(),
+	}
+	if ingredient.ID != nil {
+		out.Id = int32(*ingredient.ID)
+	}
+	if ingredient.Season != nil {
+		for _, month := range seasons.Local(*ingredient.Season, hemisphere) {
+			out.Season = append(out.Season, int32(month))
+		}
+	}
+	return out
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"backend/main/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetSeasonalIngredientsUnknownFoodType(t *testing.T) {
+	// the filter is checked before any model is used
+	service := &RecommendationService{}
+	_, err := service.GetSeasonalIngredients(context.Background(), &pb.GetSeasonalIngredientsRequest{Month: 6, FoodTypes: []string{"Seafod"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetSeasonalIngredients() error = %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,478 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"backend/main/models"
+	"backend/main/pb"
+	"backend/main/units"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// converter combines recipe, pantry and shopping list quantities
+var converter = units.NewConverter(nil)
+
+type ShoppingListService struct {
+	pb.UnimplementedShoppingListServiceServer
+	ShoppingListModel models.ShoppingListRepository
+	RecipeModel       *models.RecipeModel
+	PantryModel       *models.PantryModel
+	StoreModel        *models.StoreModel
+	AdModel           *models.AdModel
+}
+
+func NewShoppingListService(shoppingListModel models.ShoppingListRepository, recipeModel *models.RecipeModel, pantryModel *models.PantryModel, storeModel *models.StoreModel, adModel *models.AdModel) *ShoppingListService {
+	return &ShoppingListService{
+		ShoppingListModel: shoppingListModel,
+		RecipeModel:       recipeModel,
+		PantryModel:       pantryModel,
+		StoreModel:        storeModel,
+		AdModel:           adModel,
+	}
+}
+
+// CreateShoppingList builds a list from the chosen recipes minus the user's pantry and
+// assigns each item to the subscribed store with the cheapest current sale
+func (s *ShoppingListService) CreateShoppingList(ctx context.Context, req *pb.CreateShoppingListRequest) (*pb.ShoppingList, error) {
+	fmt.Println("CreateShoppingList called with UserId:", req.UserId)
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if len(req.RecipeIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one recipe_id is required")
+	}
+	var recipes []models.Recipe
+	for _, recipeID := range req.RecipeIds {
+		recipe, err := s.RecipeModel.GetRecipeByID(ctx, uint(recipeID))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "recipe %d not found", recipeID)
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "loading recipe: %v", err)
+		}
+		recipes = append(recipes, recipe)
+	}
+	pantry, err := s.PantryModel.GetPantryItems(ctx, uint(req.UserId))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "loading pantry: %v", err)
+	}
+	sales, err := loadSaleIndex(ctx, s.StoreModel, s.AdModel, uint(req.UserId))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "loading subscribed store ads: %v", err)
+	}
+
+	name := req.Name
+	if name == "" {
+		name = "Shopping list"
+	}
+	list := models.NewShoppingList(nil, uint(req.UserId), name, buildShoppingItems(recipes, pantry, sales))
+	listID, err := s.ShoppingListModel.CreateShoppingList(ctx, *list)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "saving shopping list: %v", err)
+	}
+	return s.shoppingListResponse(ctx, listID)
+}
+
+// GetShoppingList returns a shopping list grouped by store and aisle
+func (s *ShoppingListService) GetShoppingList(ctx context.Context, req *pb.GetShoppingListRequest) (*pb.ShoppingList, error) {
+	if req.ShoppingListId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "shopping_list_id is required")
+	}
+	return s.shoppingListResponse(ctx, uint(req.ShoppingListId))
+}
+
+// ListShoppingLists returns a user's shopping lists without their items
+func (s *ShoppingListService) ListShoppingLists(ctx context.Context, req *pb.ListShoppingListsRequest) (*pb.ListShoppingListsResponse, error) {
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	lists, err := s.ShoppingListModel.GetShoppingLists(ctx, uint(req.UserId))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "listing shopping lists: %v", err)
+	}
+	var out []*pb.ShoppingList
+	for _, list := range lists {
+		out = append(out, shoppingListToPb(list))
+	}
+	return &pb.ListShoppingListsResponse{ShoppingLists: out}, nil
+}
+
+// AddShoppingListItem adds an item to a list. Items with an ingredient but no store are
+// assigned to the cheapest current sale at the list owner's subscribed stores.
+func (s *ShoppingListService) AddShoppingListItem(ctx context.Context, req *pb.AddShoppingListItemRequest) (*pb.ShoppingList, error) {
+	if req.ShoppingListId <= 0 || req.Item == nil {
+		return nil, status.Error(codes.InvalidArgument, "shopping_list_id and item are required")
+	}
+	if req.Item.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "item name is required")
+	}
+	list, err := s.lookupShoppingList(ctx, uint(req.ShoppingListId))
+	if err != nil {
+		return nil, err
+	}
+	item := shoppingListItemFromPb(req.Item)
+	if item.IngredientID != nil && item.StoreID == nil {
+		sales, err := loadSaleIndex(ctx, s.StoreModel, s.AdModel, list.UserID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "loading subscribed store ads: %v", err)
+		}
+		assignStore(&item, sales)
+	}
+	_, err = s.ShoppingListModel.AddShoppingListItem(ctx, uint(req.ShoppingListId), item)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "adding shopping list item: %v", err)
+	}
+	return s.shoppingListResponse(ctx, uint(req.ShoppingListId))
+}
+
+// UpdateShoppingListItem changes the amount, unit, store, price and checked state the
+// request sets and keeps the item's other fields
+func (s *ShoppingListService) UpdateShoppingListItem(ctx context.Context, req *pb.UpdateShoppingListItemRequest) (*pb.ShoppingList, error) {
+	if req.ShoppingListId <= 0 || req.Item == nil || req.Item.Id <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "shopping_list_id and item.id are required")
+	}
+	item := shoppingListItemFromPb(req.Item)
+	update := models.ShoppingListItemUpdate{
+		ID:      *item.ID,
+		Amount:  item.Amount,
+		Unit:    item.Unit,
+		StoreID: item.StoreID,
+		Price:   item.Price,
+		Checked: req.Item.Checked,
+	}
+	err := s.ShoppingListModel.UpdateShoppingListItem(ctx, uint(req.ShoppingListId), update)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "item %d not found on shopping list %d", req.Item.Id, req.ShoppingListId)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "updating shopping list item: %v", err)
+	}
+	return s.shoppingListResponse(ctx, uint(req.ShoppingListId))
+}
+
+// DeleteShoppingListItem removes an item from a list
+func (s *ShoppingListService) DeleteShoppingListItem(ctx context.Context, req *pb.DeleteShoppingListItemRequest) (*pb.ShoppingList, error) {
+	if req.ShoppingListId <= 0 || req.ItemId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "shopping_list_id and item_id are required")
+	}
+	err := s.ShoppingListModel.DeleteShoppingListItem(ctx, uint(req.ShoppingListId), uint(req.ItemId))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "item %d not found on shopping list %d", req.ItemId, req.ShoppingListId)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "deleting shopping list item: %v", err)
+	}
+	return s.shoppingListResponse(ctx, uint(req.ShoppingListId))
+}
+
+func (s *ShoppingListService) lookupShoppingList(ctx context.Context, listID uint) (models.ShoppingList, error) {
+	list, err := s.ShoppingListModel.GetShoppingList(ctx, listID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ShoppingList{}, status.Errorf(codes.NotFound, "shopping list %d not found", listID)
+	}
+	if err != nil {
+		return models.ShoppingList{}, status.Errorf(codes.Internal, "getting shopping list: %v", err)
+	}
+	return list, nil
+}
+
+func (s *ShoppingListService) shoppingListResponse(ctx context.Context, listID uint) (*pb.ShoppingList, error) {
+	list, err := s.lookupShoppingList(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+	return shoppingListToPb(list), nil
+}
+
+// shoppingKey identifies one ingredient on a shopping list
+type shoppingKey struct {
+	ingredientID uint
+	name         string
+}
+
+// shoppingLine accumulates recipe amounts that can be added together. Amounts in units
+// that cannot be converted into each other (e.g. "2 cans" and "400 g") get separate
+// lines; amounts that cannot be parsed are kept verbatim on a line without a quantity.
+type shoppingLine struct {
+	item     models.ShoppingListItem
+	quantity *units.Quantity
+	amounts  []string
+}
+
+// buildShoppingItems aggregates recipe ingredient amounts per ingredient, subtracts
+// what the pantry already holds and assigns each remaining item to its cheapest sale
+func buildShoppingItems(recipes []models.Recipe, pantry []models.PantryIngredient, sales saleIndex) []models.ShoppingListItem {
+	var order []shoppingKey
+	lines := make(map[shoppingKey][]*shoppingLine)
+	for _, recipe := range recipes {
+		for _, ri := range recipe.Ingredient {
+			key := shoppingKey{}
+			if ri.IngredientID != nil {
+				key.ingredientID = *ri.IngredientID
+			} else {
+				key.name = strings.ToLower(ri.Name)
+			}
+			if _, ok := lines[key]; !ok {
+				order = append(order, key)
+			}
+			lines[key] = addToShoppingLines(lines[key], ri)
+		}
+	}
+
+	pantryByID := make(map[uint][]models.PantryIngredient)
+	for _, item := range pantry {
+		if item.IngredientID != nil {
+			pantryByID[*item.IngredientID] = append(pantryByID[*item.IngredientID], item)
+		}
+	}
+
+	var items []models.ShoppingListItem
+	for _, key := range order {
+		ingredientLines := lines[key]
+		if key.ingredientID != 0 && !subtractPantry(ingredientLines, pantryByID[key.ingredientID]) {
+			continue
+		}
+		for _, line := range ingredientLines {
+			if line.quantity != nil {
+				if line.quantity.Value <= 0 {
+					continue
+				}
+				amount := units.FormatAmount(line.quantity.Value)
+				line.item.Amount = &amount
+				line.item.Unit = nil
+				if line.quantity.Unit != units.Each {
+					unit := line.quantity.Unit.Name
+					line.item.Unit = &unit
+				}
+			} else if len(line.amounts) > 0 {
+				amount := strings.Join(line.amounts, " + ")
+				line.item.Amount = &amount
+			}
+			assignStore(&line.item, sales)
+			items = append(items, line.item)
+		}
+	}
+	return items
+}
+
+// addToShoppingLines adds a recipe ingredient's amount to the first line it can be
+// converted into, starting a new line otherwise
+func addToShoppingLines(lines []*shoppingLine, ri models.RecipeIngredient) []*shoppingLine {
+	var amount, unit string
+	if ri.Amount != nil {
+		amount = strings.TrimSpace(*ri.Amount)
+	}
+	if ri.Unit != nil {
+		unit = *ri.Unit
+	}
+	quantity, err := units.ParseQuantity(amount, unit)
+	if err != nil {
+		for _, line := range lines {
+			if line.quantity == nil && normalizeUnitText(line.item.Unit) == units.NormalizeUnit(unit) {
+				if amount != "" {
+					line.amounts = append(line.amounts, amount)
+				}
+				return lines
+			}
+		}
+		line := &shoppingLine{item: *models.NewShoppingListItem(nil, ri.IngredientID, ri.Name, nil, ri.Unit)}
+		if amount != "" {
+			line.amounts = append(line.amounts, amount)
+		}
+		return append(lines, line)
+	}
+	for _, line := range lines {
+		if line.quantity == nil {
+			continue
+		}
+		sum, err := converter.Add(*line.quantity, quantity, ri.Name)
+		if err == nil {
+			line.quantity = &sum
+			return lines
+		}
+	}
+	return append(lines, &shoppingLine{
+		item:     *models.NewShoppingListItem(nil, ri.IngredientID, ri.Name, nil, ri.Unit),
+		quantity: &quantity,
+	})
+}
+
+// subtractPantry removes pantry quantities from an ingredient's lines, converting units
+// where possible, and reports whether anything is still needed. A pantry entry without
+// a quantity covers the ingredient entirely.
+func subtractPantry(lines []*shoppingLine, pantry []models.PantryIngredient) bool {
+	for _, item := range pantry {
+		if item.Quantity == nil || strings.TrimSpace(*item.Quantity) == "" {
+			return false
+		}
+		var unit string
+		if item.Unit != nil {
+			unit = *item.Unit
+		}
+		have, err := units.ParseQuantity(*item.Quantity, unit)
+		if err != nil {
+			continue
+		}
+		for _, line := range lines {
+			if line.quantity == nil || have.Value <= 0 {
+				continue
+			}
+			available, err := converter.Convert(have, line.quantity.Unit, line.item.Name)
+			if err != nil {
+				continue
+			}
+			used := math.Min(available.Value, line.quantity.Value)
+			line.quantity.Value -= used
+			usedInPantryUnit, err := converter.Convert(units.Quantity{Value: used, Unit: line.quantity.Unit}, have.Unit, line.item.Name)
+			if err != nil {
+				continue
+			}
+			have.Value -= usedInPantryUnit.Value
+		}
+	}
+	for _, line := range lines {
+		if line.quantity == nil || line.quantity.Value > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeUnitText(unit *string) string {
+	if unit == nil {
+		return units.NormalizeUnit("")
+	}
+	return units.NormalizeUnit(*unit)
+}
+
+// assignStore points an item at the cheapest current sale for its ingredient
+func assignStore(item *models.ShoppingListItem, sales saleIndex) {
+	if item.IngredientID == nil {
+		return
+	}
+	sale, ok := sales.best(*item.IngredientID)
+	if !ok {
+		return
+	}
+	item.StoreID = sale.Store.ID
+	item.StoreName = sale.Store.Name
+	item.Price = sale.Item.Price
+}
+
+func shoppingListItemFromPb(item *pb.ShoppingListItem) models.ShoppingListItem {
+	out := models.ShoppingListItem{
+		Name:    item.Name,
+		Amount:  item.Amount,
+		Unit:    item.Unit,
+		Price:   item.Price,
+		Checked: item.GetChecked(),
+	}
+	if item.Id > 0 {
+		id := uint(item.Id)
+		out.ID = &id
+	}
+	if item.IngredientId != nil {
+		id := uint(*item.IngredientId)
+		out.IngredientID = &id
+	}
+	if item.StoreId != nil {
+		id := uint(*item.StoreId)
+		out.StoreID = &id
+	}
+	return out
+}
+
+// shoppingListToPb groups items by store, then by FoodType aisle. Stores are ordered by
+// name, then ID, with unassigned items last; aisles follow the FoodType enum order.
+func shoppingListToPb(list models.ShoppingList) *pb.ShoppingList {
+	out := &pb.ShoppingList{
+		UserId:    int32(list.UserID),
+		Name:      list.Name,
+		CreatedAt: list.CreatedAt,
+	}
+	if list.ID != nil {
+		out.Id = int32(*list.ID)
+	}
+
+	stores := make(map[uint]*pb.ShoppingListStore)
+	aisles := make(map[uint]map[models.FoodType]*pb.ShoppingListAisle)
+	for _, item := range list.Item {
+		var storeKey uint
+		if item.StoreID != nil {
+			storeKey = *item.StoreID
+		}
+		store, ok := stores[storeKey]
+		if !ok {
+			store = &pb.ShoppingListStore{}
+			if item.StoreID != nil {
+				id := int32(*item.StoreID)
+				store.StoreId = &id
+			}
+			if item.StoreName != nil {
+				store.StoreName = *item.StoreName
+			}
+			stores[storeKey] = store
+			aisles[storeKey] = make(map[models.FoodType]*pb.ShoppingListAisle)
+		}
+		aisle, ok := aisles[storeKey][item.Type]
+		if !ok {
+			aisle = &pb.ShoppingListAisle{FoodType: item.Type.String()}
+			aisles[storeKey][item.Type] = aisle
+		}
+		aisle.Items = append(aisle.Items, shoppingListItemToPb(item))
+	}
+
+	for storeKey, store := range stores {
+		var types []models.FoodType
+		for foodType := range aisles[storeKey] {
+			types = append(types, foodType)
+		}
+		sort.Slice(types, func(a, b int) bool { return types[a] < types[b] })
+		for _, foodType := range types {
+			store.Aisles = append(store.Aisles, aisles[storeKey][foodType])
+		}
+		out.Stores = append(out.Stores, store)
+	}
+	sort.Slice(out.Stores, func(a, b int) bool {
+		if (out.Stores[a].StoreId == nil) != (out.Stores[b].StoreId == nil) {
+			return out.Stores[b].StoreId == nil
+		}
+		if out.Stores[a].StoreName != out.Stores[b].StoreName {
+			return out.Stores[a].StoreName < out.Stores[b].StoreName
+		}
+		// stores of one chain share a name
+		return out.Stores[a].GetStoreId() < out.Stores[b].GetStoreId()
+	})
+	return out
+}
+
+func shoppingListItemToPb(item models.ShoppingListItem) *pb.ShoppingListItem {
+	out := &pb.ShoppingListItem{
+		Name:     item.Name,
+		Amount:   item.Amount,
+		Unit:     item.Unit,
+		Price:    item.Price,
+		FoodType: item.Type.String(),
+		Checked:  &item.Checked,
+	}
+	if item.ID != nil {
+		out.Id = int32(*item.ID)
+	}
+	if item.IngredientID != nil {
+		id := int32(*item.IngredientID)
+		out.IngredientId = &id
+	}
+	if item.StoreID != nil {
+		id := int32(*item.StoreID)
+		out.StoreId = &id
+	}
+	return out
+}
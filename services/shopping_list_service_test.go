@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"backend/main/models"
+	"backend/main/models/memory"
+	"backend/main/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func recipeIngredient(id uint, name, amount, unit string) models.RecipeIngredient {
+	ri := models.RecipeIngredient{Name: name}
+	if id != 0 {
+		ri.IngredientID = ptr(id)
+	}
+	if amount != "" {
+		ri.Amount = ptr(amount)
+	}
+	if unit != "" {
+		ri.Unit = ptr(unit)
+	}
+	return ri
+}
+
+func pantryItem(id uint, quantity, unit string) models.PantryIngredient {
+	item := models.PantryIngredient{IngredientID: ptr(id)}
+	if quantity != "" {
+		item.Quantity = ptr(quantity)
+	}
+	if unit != "" {
+		item.Unit = ptr(unit)
+	}
+	return item
+}
+
+// saleAt is a sale of an ingredient at a store, unpriced when price is negative
+func saleAt(storeID uint, storeName string, ingredientID uint, price float32) SaleItem {
+	sale := SaleItem{
+		Store: models.Store{ID: ptr(storeID), Name: ptr(storeName)},
+		Item:  models.AdIngredient{IngredientID: ingredientID},
+	}
+	if price >= 0 {
+		sale.Item.Price = ptr(price)
+	}
+	return sale
+}
+
+// describeShoppingItem formats the parts of a shopping list item the tests compare
+func describeShoppingItem(item models.ShoppingListItem) string {
+	text := func(s *string) string {
+		if s == nil {
+			return "-"
+		}
+		return *s
+	}
+	price := "-"
+	if item.Price != nil {
+		price = fmt.Sprintf("%.2f", *item.Price)
+	}
+	return fmt.Sprintf("%s|%s|%s|%s|%s", item.Name, text(item.Amount), text(item.Unit), text(item.StoreName), price)
+}
+
+func TestBuildShoppingItems(t *testing.T) {
+	tests := []struct {
+		name    string
+		recipes [][]models.RecipeIngredient
+		pantry  []models.PantryIngredient
+		sales   saleIndex
+		want    []string
+	}{
+		{
+			name: "units converted across recipes",
+			recipes: [][]models.RecipeIngredient{
+				{recipeIngredient(1, "flour", "1", "cup")},
+				{recipeIngredient(1, "flour", "8", "tbsp")},
+			},
+			want: []string{"flour|1.5|cup|-|-"},
+		},
+		{
+			name: "units that do not convert get their own lines",
+			recipes: [][]models.RecipeIngredient{
+				{recipeIngredient(5, "tomatoes", "2", "cans")},
+				{recipeIngredient(5, "tomatoes", "400", "g")},
+			},
+			want: []string{"tomatoes|2|can|-|-", "tomatoes|400|g|-|-"},
+		},
+		{
+			name: "unparsed amounts are kept and items without an ingredient merge by name",
+			recipes: [][]models.RecipeIngredient{
+				{recipeIngredient(0, "Salt", "a pinch", "")},
+				{recipeIngredient(0, "salt", "to taste", "")},
+			},
+			want: []string{"Salt|a pinch + to taste|-|-|-"},
+		},
+		{
+			name: "partial pantry shortfall",
+			recipes: [][]models.RecipeIngredient{
+				{recipeIngredient(2, "milk", "1", "cup"), recipeIngredient(4, "butter", "500", "g")},
+				{recipeIngredient(2, "milk", "1", "cup")},
+			},
+			pantry: []models.PantryIngredient{pantryItem(2, "1", "cup"), pantryItem(4, "0.25", "kg")},
+			want:   []string{"milk|1|cup|-|-", "butter|250|g|-|-"},
+		},
+		{
+			name: "pantry covers the recipe",
+			recipes: [][]models.RecipeIngredient{
+				{recipeIngredient(2, "milk", "1", "cup"), recipeIngredient(3, "eggs", "3", ""), recipeIngredient(6, "rice", "2", "cups")},
+			},
+			pantry: []models.PantryIngredient{pantryItem(2, "1", "quart"), pantryItem(3, "12", ""), pantryItem(6, "", "")},
+			want:   nil,
+		},
+		{
+			name: "cheapest store",
+			recipes: [][]models.RecipeIngredient{
+				{recipeIngredient(3, "eggs", "12", ""), recipeIngredient(2, "milk", "1", "gallon"), recipeIngredient(7, "bread", "1", "")},
+			},
+			sales: saleIndex{
+				3: {saleAt(1, "Safeway", 3, 4.99), saleAt(2, "Trader Joe's", 3, 3.49), saleAt(3, "Berkeley Bowl", 3, 3.99)},
+				// an unpriced BOGO offer loses to any price
+				2: {saleAt(3, "Berkeley Bowl", 2, -1), saleAt(1, "Safeway", 2, 5.29)},
+				7: {saleAt(3, "Berkeley Bowl", 7, -1)},
+			},
+			want: []string{"eggs|12|-|Trader Joe's|3.49", "milk|1|gallon|Safeway|5.29", "bread|1|-|Berkeley Bowl|-"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var recipes []models.Recipe
+			for _, ingredients := range tt.recipes {
+				recipes = append(recipes, models.Recipe{Ingredient: ingredients})
+			}
+			var got []string
+			for _, item := range buildShoppingItems(recipes, tt.pantry, tt.sales) {
+				got = append(got, describeShoppingItem(item))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("buildShoppingItems() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestSubtractPantry(t *testing.T) {
+	tests := []struct {
+		name       string
+		recipe     []models.RecipeIngredient
+		pantry     []models.PantryIngredient
+		wantNeeded bool
+		want       []string
+	}{
+		{"nothing in the pantry", []models.RecipeIngredient{recipeIngredient(2, "milk", "2", "cups")}, nil, true, []string{"2 cup"}},
+		{"converted shortfall", []models.RecipeIngredient{recipeIngredient(2, "milk", "2", "cups")},
+			[]models.PantryIngredient{pantryItem(2, "8", "fl oz")}, true, []string{"1 cup"}},
+		{"several pantry entries", []models.RecipeIngredient{recipeIngredient(2, "milk", "2", "cups")},
+			[]models.PantryIngredient{pantryItem(2, "1", "cup"), pantryItem(2, "1", "pint")}, false, []string{"0 cup"}},
+		{"pantry spread over lines", []models.RecipeIngredient{recipeIngredient(5, "tomatoes", "2", "cans"), recipeIngredient(5, "tomatoes", "400", "g")},
+			[]models.PantryIngredient{pantryItem(5, "500", "g")}, true, []string{"2 can", "0 g"}},
+		{"unparsed pantry quantity is ignored", []models.RecipeIngredient{recipeIngredient(2, "milk", "2", "cups")},
+			[]models.PantryIngredient{pantryItem(2, "some", "")}, true, []string{"2 cup"}},
+		{"pantry without a quantity covers everything", []models.RecipeIngredient{recipeIngredient(2, "milk", "2", "cups")},
+			[]models.PantryIngredient{pantryItem(2, "", "")}, false, []string{"2 cup"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lines []*shoppingLine
+			for _, ri := range tt.recipe {
+				lines = addToShoppingLines(lines, ri)
+			}
+			if needed := subtractPantry(lines, tt.pantry); needed != tt.wantNeeded {
+				t.Errorf("subtractPantry() = %v, want %v", needed, tt.wantNeeded)
+			}
+			var got []string
+			for _, line := range lines {
+				got = append(got, line.quantity.String())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("remaining = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShoppingListToPbOrdersStores(t *testing.T) {
+	item := func(storeID uint, storeName string) models.ShoppingListItem {
+		it := models.ShoppingListItem{Name: "milk", Type: models.Dairy}
+		if storeID != 0 {
+			it.StoreID, it.StoreName = ptr(storeID), ptr(storeName)
+		}
+		return it
+	}
+	list := models.ShoppingList{Item: []models.ShoppingListItem{
+		item(0, ""), item(7, "Safeway"), item(3, "Trader Joe's"), item(2, "Safeway"), item(9, "Berkeley Bowl"),
+	}}
+	// map iteration makes the input order irrelevant; run enough times to catch an unstable sort
+	for range 20 {
+		var got []int32
+		for _, store := range shoppingListToPb(list).Stores {
+			got = append(got, store.GetStoreId())
+		}
+		if want := []int32{9, 2, 7, 3, 0}; !slices.Equal(got, want) {
+			t.Fatalf("store order = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestUpdateShoppingListItemKeepsUnsetFields(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.New()
+	if err := repo.CreateStore(ctx, models.Store{Name: ptr("Corner Market")}); err != nil {
+		t.Fatal(err)
+	}
+	listID, err := repo.CreateShoppingList(ctx, models.ShoppingList{UserID: 1, Name: "week", Item: []models.ShoppingListItem{
+		{Name: "apples", Amount: ptr("2"), Unit: ptr("lb"), StoreID: ptr(uint(1)), Price: ptr(float32(3.5))},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := &ShoppingListService{ShoppingListModel: repo}
+	update := func(item *pb.ShoppingListItem) *pb.ShoppingListItem {
+		t.Helper()
+		resp, err := service.UpdateShoppingListItem(ctx, &pb.UpdateShoppingListItemRequest{ShoppingListId: int32(listID), Item: item})
+		if err != nil {
+			t.Fatalf("UpdateShoppingListItem() error = %v", err)
+		}
+		return resp.Stores[0].Aisles[0].Items[0]
+	}
+
+	got := update(&pb.ShoppingListItem{Id: 1, Checked: ptr(true)})
+	if !got.GetChecked() || got.GetAmount() != "2" || got.GetUnit() != "lb" || got.GetStoreId() != 1 || got.GetPrice() != 3.5 {
+		t.Errorf("checking the item = %v, want it checked with its amount, unit, store and price kept", got)
+	}
+	got = update(&pb.ShoppingListItem{Id: 1, Amount: ptr("3")})
+	if !got.GetChecked() || got.GetAmount() != "3" || got.GetUnit() != "lb" || got.GetStoreId() != 1 || got.GetPrice() != 3.5 {
+		t.Errorf("changing the amount = %v, want amount 3 with the rest kept", got)
+	}
+	got = update(&pb.ShoppingListItem{Id: 1, Checked: ptr(false)})
+	if got.GetChecked() || got.GetAmount() != "3" {
+		t.Errorf("unchecking the item = %v, want it unchecked with amount 3", got)
+	}
+
+	_, err = service.UpdateShoppingListItem(ctx, &pb.UpdateShoppingListItemRequest{ShoppingListId: int32(listID), Item: &pb.ShoppingListItem{Id: 9, Checked: ptr(true)}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("updating a missing item: error = %v, want NotFound", err)
+	}
+}
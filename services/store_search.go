@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"backend/main/geo"
+	"backend/main/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultSearchRadiusKm = 10
+
+// SearchStores returns the stores within a radius of a postal code or a point, nearest
+// first. Candidates come from a bounding box around the circle; stores without
+// coordinates are left out until PlaceStores geocodes them.
+func (s *UserFeedService) SearchStores(ctx context.Context, req *pb.SearchStoresRequest) (*pb.SearchStoresResponse, error) {
+	origin, err := s.searchOrigin(req)
+	if err != nil {
+		return nil, err
+	}
+	radius := req.RadiusKm
+	if radius == 0 {
+		radius = defaultSearchRadiusKm
+	}
+	if radius < 0 || math.IsNaN(radius) {
+		return nil, status.Errorf(codes.InvalidArgument, "radius_km must be positive, got %v", radius)
+	}
+	if req.Limit < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must not be negative, got %d", req.Limit)
+	}
+	stores, err := s.StoreModel.GetStoresWithin(ctx, geo.BoundingBox(origin, radius))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "listing stores: %v", err)
+	}
+	subscribed := make(map[uint]bool)
+	if req.UserId > 0 {
+		subscriptions, err := s.StoreModel.GetSubscribedStores(ctx, uint(req.UserId))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "listing subscribed stores: %v", err)
+		}
+		for _, store := range subscriptions {
+			subscribed[*store.ID] = true
+		}
+	}
+	nearby := []*pb.NearbyStore{}
+	for _, store := range stores {
+		point, ok := store.Point()
+		if !ok {
+			continue
+		}
+		distance := geo.Distance(origin, point)
+		if distance > radius {
+			continue
+		}
+		nearby = append(nearby, &pb.NearbyStore{
+			Store:      storeToPb(store),
+			DistanceKm: distance,
+			Subscribed: subscribed[*store.ID],
+		})
+	}
+	sort.SliceStable(nearby, func(i, j int) bool { return nearby[i].DistanceKm < nearby[j].DistanceKm })
+	if req.Limit > 0 && len(nearby) > int(req.Limit) {
+		nearby = nearby[:req.Limit]
+	}
+	return &pb.SearchStoresResponse{Stores: nearby, Latitude: origin.Latitude, Longitude: origin.Longitude}, nil
+}
+
+// PlaceStores geocodes the stores saved without coordinates by their postal code and saves
+// them, so searches only read stores that are already placed. It returns how many stores
+// were placed and how many are left without coordinates.
+func (s *UserFeedService) PlaceStores(ctx context.Context) (placed int, unplaced int, err error) {
+	if s.Geocoder == nil {
+		return 0, 0, status.Error(codes.FailedPrecondition, "placing stores needs a postal code table")
+	}
+	stores, err := s.StoreModel.GetUnplacedStores(ctx)
+	if err != nil {
+		return 0, 0, status.Errorf(codes.Internal, "listing unplaced stores: %v", err)
+	}
+	for _, store := range stores {
+		if !store.Geocode(s.Geocoder) {
+			unplaced++
+			continue
+		}
+		if err := s.StoreModel.SetStoreCoordinates(ctx, store); err != nil {
+			return placed, unplaced, status.Errorf(codes.Internal, "saving coordinates of store %d: %v", *store.ID, err)
+		}
+		placed++
+	}
+	return placed, unplaced, nil
+}
+
+// searchOrigin is the point a search measures from: the request's coordinates when
+// given, otherwise its postal code geocoded
+func (s *UserFeedService) searchOrigin(req *pb.SearchStoresRequest) (geo.Point, error) {
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		return geo.Point{}, status.Error(codes.InvalidArgument, "latitude and longitude must be given together")
+	}
+	if req.Latitude != nil {
+		origin := geo.Point{Latitude: *req.Latitude, Longitude: *req.Longitude}
+		if err := origin.Validate(); err != nil {
+			return geo.Point{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return origin, nil
+	}
+	if req.PostalCode == "" {
+		return geo.Point{}, status.Error(codes.InvalidArgument, "postal_code or latitude and longitude are required")
+	}
+	if s.Geocoder == nil {
+		return geo.Point{}, status.Error(codes.FailedPrecondition, "searching by postal code needs a postal code table")
+	}
+	place, ok := s.Geocoder.Lookup(req.Country, req.PostalCode)
+	if !ok {
+		return geo.Point{}, status.Errorf(codes.NotFound, "postal code %q not found", req.PostalCode)
+	}
+	return place.Point, nil
+}
@@ -3,67 +3,265 @@ package services
 import (
 	"fmt"
 	"context"
+	"errors"
+	"sort"
+	"time"
+	"backend/main/deals"
+	"backend/main/geo"
 	"backend/main/pb"
 	"backend/main/models"
+	"backend/main/taxonomy"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type UserFeedService struct {
 	pb.UnimplementedUserFeedServiceServer
-	UserModel *models.UserModel
-	StoreModel *models.StoreModel
-	AdModel *models.AdModel
-	IngredientModel *models.IngredientModel
+	UserModel models.UserRepository
+	StoreModel models.StoreRepository
+	AdModel models.AdRepository
+	IngredientModel models.IngredientRepository
+	// SubstitutionDepth is how many levels up or down the ingredient hierarchy an ad item
+	// may be from a requested ingredient when a request does not say
+	SubstitutionDepth int
+	// Now decides which ads are active and the default month for in_season
+	Now func() time.Time
+	// Geocoder places postal codes for SearchStores. Without one only searches by
+	// coordinates of stores saved with coordinates work.
+	Geocoder *geo.Table
 }
 
-func NewUserFeedService(userModel *models.UserModel, storeModel *models.StoreModel, adModel *models.AdModel, ingredientModel *models.IngredientModel) *UserFeedService {
+func NewUserFeedService(userModel models.UserRepository, storeModel models.StoreRepository, adModel models.AdRepository, ingredientModel models.IngredientRepository) *UserFeedService {
 	return &UserFeedService{
 		UserModel: userModel,
 		StoreModel: storeModel,
 		AdModel: adModel,
 		IngredientModel: ingredientModel,
+		SubstitutionDepth: taxonomy.DefaultDepth,
+		Now: time.Now,
 	}
 }
 
-// GetUserAds retrieves ads for a user
+// GetUserAds retrieves every ad active today at the stores a user is subscribed to, one
+// Ad per flyer. Stores without an active ad are left out, and ad items whose ingredient
+// no longer exists are kept without an ingredient name.
 func (s (*UserFeedService)) GetUserAds(ctx context.Context, req *pb.GetUserAdsRequest) (*pb.GetUserAdsResponse, error) {
 	fmt.Println("GetUserAds called with UserId:", req.UserId)
-	stores, err := s.StoreModel.GetSubscribedStores(uint(req.UserId))
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	depth, err := substitutionDepth(req.SubstitutionDepth, s.SubstitutionDepth)
+	if err != nil {
+		return nil, err
+	}
+	now := s.Now()
+	when, err := parseSeason(req.Month, req.Hemisphere, now)
 	if err != nil {
 		return nil, err
 	}
-	var ads []models.Ad
+	var wanted map[uint]int
+	if len(req.IngredientIds) > 0 {
+		tree, err := loadTaxonomy(ctx, s.IngredientModel)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "loading ingredient hierarchy: %v", err)
+		}
+		wanted = relatedIngredients(req.IngredientIds, tree, depth)
+	}
+	stores, err := s.StoreModel.GetSubscribedStores(ctx, uint(req.UserId))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "listing subscribed stores: %v", err)
+	}
+	historyDays := defaultHistoryDays
+	if req.HistoryDays > 0 {
+		historyDays = int(req.HistoryDays)
+	}
+	adList := []*pb.Ad{}
 	for _, store := range stores {
-		ad, err := s.AdModel.GetRecentAd(*store.ID)
+		ads, err := s.AdModel.GetActiveAds(ctx, *store.ID, now)
 		if err != nil {
-			fmt.Println("Error retrieving ad for store:", store.Name, "Error:", err)
-			return nil, err
+			return nil, status.Errorf(codes.Internal, "getting ads for store %d: %v", *store.ID, err)
 		}
-		ads = append(ads, ad)
-	}
-	var adList []*pb.Ad
-	
-	for i, ad := range ads {
-		var adItemsList []*pb.AdItemData
-		for _, adItem := range ad.Ingredient {
-			ingredient, err := s.IngredientModel.GetIngredientByID(uint(adItem.IngredientID))
+		for _, ad := range ads {
+			var ingredientIDs []uint
+			for _, adItem := range ad.Ingredient {
+				ingredientIDs = append(ingredientIDs, adItem.IngredientID)
+			}
+			history, err := s.AdModel.GetPriceStats(ctx, ad.StoreID, ingredientIDs, historyDays, ad.ID)
 			if err != nil {
-				fmt.Println("Error retrieving ingredient for ad item:", adItem.Name, "Error:", err)
-				return nil, err
+				return nil, status.Errorf(codes.Internal, "getting price history for store %d: %v", *store.ID, err)
 			}
-			adItemsList = append(adItemsList, &pb.AdItemData{
-				Ingredient: ingredient.Name,
-				Name: adItem.Name,
-				Price: adItem.Price,
-				Sale: adItem.Sale,
-				IngredientType: ingredient.Type.String(),
+			var adItemsList []*pb.AdItemData
+			for _, adItem := range ad.Ingredient {
+				distance, ok := wanted[adItem.IngredientID]
+				if wanted != nil && !ok {
+					continue
+				}
+				ingredient, err := s.IngredientModel.GetIngredientByID(ctx, adItem.IngredientID)
+				if errors.Is(err, pgx.ErrNoRows) {
+					fmt.Println("Ingredient", adItem.IngredientID, "not found for ad item:", adItem.Name)
+					ingredient = models.Ingredient{Type: models.Other}
+				} else if err != nil {
+					return nil, status.Errorf(codes.Internal, "getting ingredient %d: %v", adItem.IngredientID, err)
+				}
+				adItemsList = append(adItemsList, &pb.AdItemData{
+					Ingredient: ingredient.Name,
+					Name: adItem.Name,
+					Price: adItem.Price,
+					Sale: adItem.Sale,
+					IngredientType: ingredient.Type.String(),
+					Deal: dealToPb(adItem.Deal),
+					PriceScore: scorePrice(adItem.Deal, history, adItem.IngredientID),
+					IngredientId: int32(adItem.IngredientID),
+					SubstitutionDistance: int32(distance),
+					InSeason: when.InSeason(ingredient.Season),
+					ValidFrom: ad.SaleStart,
+					ValidTo: ad.SaleEnd,
+				})
+			}
+			if req.SeasonalBoost {
+				// in-season items first, otherwise keeping the ad's order
+				sort.SliceStable(adItemsList, func(a, b int) bool {
+					return adItemsList[a].InSeason && !adItemsList[b].InSeason
+				})
+			}
+			pbStore := storeToPb(store)
+			adList = append(adList, &pb.Ad{
+				StoreId: pbStore.Id,
+				StoreName: pbStore.Name,
+				StoreAddress: pbStore.Address,
+				AdItems: adItemsList,
+				SaleStart: ad.SaleStart,
+				SaleEnd: ad.SaleEnd,
+				FlyerId: ad.SourceFlyerID,
 			})
 		}
-		adList = append(adList, &pb.Ad{
-			StoreName: *stores[i].Name,
-			StoreAddress: stores[i].Location,
-			AdItems: adItemsList,
-		})
 	}
 	fmt.Println("Ads retrieved successfully for UserId:", req.UserId)
 	return &pb.GetUserAdsResponse{Ads: adList}, nil
-}
\ No newline at end of file
+}
+
+// relatedIngredients maps every ingredient within depth levels of a requested one onto
+// its distance from the nearest requested ingredient
+func relatedIngredients(ids []int32, tree *taxonomy.Tree, depth int) map[uint]int {
+	related := make(map[uint]int)
+	for _, id := range ids {
+		for _, relative := range tree.Related(uint(id), depth) {
+			if distance, ok := related[relative.ID]; !ok || relative.Distance < distance {
+				related[relative.ID] = relative.Distance
+			}
+		}
+	}
+	return related
+}
+
+// SubscribeStore subscribes a user to a store
+func (s *UserFeedService) SubscribeStore(ctx context.Context, req *pb.SubscribeStoreRequest) (*pb.SubscribeStoreResponse, error) {
+	if req.UserId <= 0 || req.StoreId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id and store_id are required")
+	}
+	store, err := s.lookupStore(ctx, uint(req.StoreId))
+	if err != nil {
+		return nil, err
+	}
+	subscribed, err := s.StoreModel.IsSubscribed(ctx, uint(req.UserId), uint(req.StoreId))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "checking subscription: %v", err)
+	}
+	if subscribed {
+		return nil, status.Errorf(codes.AlreadyExists, "user %d is already subscribed to store %d", req.UserId, req.StoreId)
+	}
+	err = s.StoreModel.SubscribeStore(ctx, uint(req.UserId), uint(req.StoreId))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, status.Errorf(codes.AlreadyExists, "user %d is already subscribed to store %d", req.UserId, req.StoreId)
+		}
+		return nil, status.Errorf(codes.Internal, "subscribing to store: %v", err)
+	}
+	return &pb.SubscribeStoreResponse{Store: storeToPb(store)}, nil
+}
+
+// UnsubscribeStore removes a user's subscription to a store
+func (s *UserFeedService) UnsubscribeStore(ctx context.Context, req *pb.UnsubscribeStoreRequest) (*pb.UnsubscribeStoreResponse, error) {
+	if req.UserId <= 0 || req.StoreId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id and store_id are required")
+	}
+	if _, err := s.lookupStore(ctx, uint(req.StoreId)); err != nil {
+		return nil, err
+	}
+	subscribed, err := s.StoreModel.IsSubscribed(ctx, uint(req.UserId), uint(req.StoreId))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "checking subscription: %v", err)
+	}
+	if !subscribed {
+		return nil, status.Errorf(codes.NotFound, "user %d is not subscribed to store %d", req.UserId, req.StoreId)
+	}
+	err = s.StoreModel.UnsubscribeStore(ctx, uint(req.UserId), uint(req.StoreId))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unsubscribing from store: %v", err)
+	}
+	return &pb.UnsubscribeStoreResponse{}, nil
+}
+
+// ListSubscribedStores returns the stores a user is subscribed to
+func (s *UserFeedService) ListSubscribedStores(ctx context.Context, req *pb.ListSubscribedStoresRequest) (*pb.ListSubscribedStoresResponse, error) {
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	stores, err := s.StoreModel.GetSubscribedStores(ctx, uint(req.UserId))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "listing subscribed stores: %v", err)
+	}
+	var storeList []*pb.Store
+	for _, store := range stores {
+		storeList = append(storeList, storeToPb(store))
+	}
+	return &pb.ListSubscribedStoresResponse{Stores: storeList}, nil
+}
+
+// lookupStore fetches a store, mapping a missing row to codes.NotFound
+func (s *UserFeedService) lookupStore(ctx context.Context, storeID uint) (models.Store, error) {
+	store, err := s.StoreModel.GetStoreByID(ctx, storeID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Store{}, status.Errorf(codes.NotFound, "store %d not found", storeID)
+	}
+	if err != nil {
+		return models.Store{}, status.Errorf(codes.Internal, "getting store: %v", err)
+	}
+	return store, nil
+}
+
+func dealToPb(deal deals.Deal) *pb.Deal {
+	return &pb.Deal{
+		UnitPrice:            deal.UnitPrice,
+		Unit:                 deal.Unit,
+		Quantity:             int32(deal.Quantity),
+		MultiBuyPrice:        deal.MultiBuyPrice,
+		MembershipRequired:   deal.MembershipRequired,
+		Bogo:                 deal.BOGO,
+		SecondItemPercentOff: deal.SecondItemPercentOff,
+	}
+}
+
+func storeToPb(store models.Store) *pb.Store {
+	pbStore := &pb.Store{
+		Address:    store.Location,
+		Street:     store.Address.Street,
+		City:       store.Address.City,
+		State:      store.Address.State,
+		PostalCode: store.Address.PostalCode,
+		Country:    store.Address.Country,
+		Latitude:   store.Latitude,
+		Longitude:  store.Longitude,
+	}
+	if store.ID != nil {
+		pbStore.Id = int32(*store.ID)
+	}
+	if store.Name != nil {
+		pbStore.Name = *store.Name
+	}
+	return pbStore
+}
@@ -0,0 +1,323 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/main/geo"
+	"backend/main/models"
+	"backend/main/models/memory"
+	"backend/main/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testUser uint = 1
+
+// testToday falls in the seeded flyers' sale window of 2025-06-01 to 2025-06-07
+var testToday = time.Date(2025, time.June, 5, 12, 0, 0, 0, time.UTC)
+
+func seedStore(t *testing.T, repo *memory.Repository, id uint, name string, items ...models.AdIngredient) {
+	t.Helper()
+	ctx := context.Background()
+	if err := repo.CreateStore(ctx, models.Store{ID: &id, Name: &name, Location: name + " St"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SubscribeStore(ctx, testUser, id); err != nil {
+		t.Fatal(err)
+	}
+	if len(items) > 0 {
+		ad := models.Ad{StoreID: id, SaleStart: "2025-06-01", SaleEnd: "2025-06-07", Ingredient: items}
+		if err := repo.CreateAd(ctx, ad); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func seedIngredient(t *testing.T, repo *memory.Repository, name string, foodType models.FoodType) uint {
+	t.Helper()
+	id, err := repo.CreateIngredient(context.Background(), models.Ingredient{Name: name, Type: foodType})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+// feedItem is the part of an AdItemData the tests compare
+type feedItem struct {
+	Name       string
+	Ingredient string
+	Type       string
+	ValidTo    string
+}
+
+type feedAd struct {
+	Store string
+	Flyer string
+	Items []feedItem
+}
+
+func TestGetUserAds(t *testing.T) {
+	tests := []struct {
+		name string
+		seed func(t *testing.T, repo *memory.Repository)
+		want []feedAd
+	}{
+		{
+			name: "no subscriptions",
+			seed: func(t *testing.T, repo *memory.Repository) {},
+			want: []feedAd{},
+		},
+		{
+			name: "store with no ad",
+			seed: func(t *testing.T, repo *memory.Repository) {
+				apple := seedIngredient(t, repo, "apple", models.Fruit)
+				seedStore(t, repo, 1, "Empty Mart")
+				seedStore(t, repo, 2, "Fresh Foods", models.AdIngredient{IngredientID: apple, Name: "Gala Apples"})
+			},
+			want: []feedAd{{"Fresh Foods", "", []feedItem{{"Gala Apples", "apple", "Fruit", "2025-06-07"}}}},
+		},
+		{
+			name: "missing ingredient",
+			seed: func(t *testing.T, repo *memory.Repository) {
+				milk := seedIngredient(t, repo, "milk", models.Dairy)
+				seedStore(t, repo, 1, "Corner Store",
+					models.AdIngredient{IngredientID: 99, Name: "Mystery Item"},
+					models.AdIngredient{IngredientID: milk, Name: "Whole Milk"})
+			},
+			want: []feedAd{{"Corner Store", "", []feedItem{{"Mystery Item", "", "Other", "2025-06-07"}, {"Whole Milk", "milk", "Dairy", "2025-06-07"}}}},
+		},
+		{
+			name: "multiple stores",
+			seed: func(t *testing.T, repo *memory.Repository) {
+				apple := seedIngredient(t, repo, "apple", models.Fruit)
+				milk := seedIngredient(t, repo, "milk", models.Dairy)
+				seedStore(t, repo, 1, "Fresh Foods", models.AdIngredient{IngredientID: apple, Name: "Gala Apples"})
+				seedStore(t, repo, 2, "Corner Store", models.AdIngredient{IngredientID: milk, Name: "Whole Milk"})
+				// an ad that has ended is left out
+				if err := repo.CreateAd(context.Background(), models.Ad{StoreID: 2, SaleStart: "2025-05-01", SaleEnd: "2025-05-07",
+					Ingredient: []models.AdIngredient{{IngredientID: apple, Name: "Old Apples"}}}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: []feedAd{
+				{"Fresh Foods", "", []feedItem{{"Gala Apples", "apple", "Fruit", "2025-06-07"}}},
+				{"Corner Store", "", []feedItem{{"Whole Milk", "milk", "Dairy", "2025-06-07"}}},
+			},
+		},
+		{
+			name: "concurrent flyers",
+			seed: func(t *testing.T, repo *memory.Repository) {
+				apple := seedIngredient(t, repo, "apple", models.Fruit)
+				milk := seedIngredient(t, repo, "milk", models.Dairy)
+				seedStore(t, repo, 1, "Fresh Foods")
+				for _, ad := range []models.Ad{
+					{StoreID: 1, SaleStart: "2025-06-01", SaleEnd: "2025-06-30", SourceFlyerID: "monthly",
+						Ingredient: []models.AdIngredient{{IngredientID: milk, Name: "Whole Milk"}}},
+					{StoreID: 1, SaleStart: "2025-06-04", SaleEnd: "2025-06-10", SourceFlyerID: "weekly",
+						Ingredient: []models.AdIngredient{{IngredientID: apple, Name: "Gala Apples"}}},
+					{StoreID: 1, SaleStart: "2025-06-06", SaleEnd: "2025-06-12", SourceFlyerID: "next week",
+						Ingredient: []models.AdIngredient{{IngredientID: apple, Name: "Fuji Apples"}}},
+				} {
+					if err := repo.CreateAd(context.Background(), ad); err != nil {
+						t.Fatal(err)
+					}
+				}
+			},
+			want: []feedAd{
+				{"Fresh Foods", "weekly", []feedItem{{"Gala Apples", "apple", "Fruit", "2025-06-10"}}},
+				{"Fresh Foods", "monthly", []feedItem{{"Whole Milk", "milk", "Dairy", "2025-06-30"}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := memory.New()
+			tt.seed(t, repo)
+			service := NewUserFeedService(repo, repo, repo, repo)
+			service.Now = func() time.Time { return testToday }
+			resp, err := service.GetUserAds(context.Background(), &pb.GetUserAdsRequest{UserId: int32(testUser)})
+			if err != nil {
+				t.Fatalf("GetUserAds() error = %v", err)
+			}
+			got := []feedAd{}
+			for _, ad := range resp.Ads {
+				summary := feedAd{Store: ad.StoreName, Flyer: ad.FlyerId}
+				for _, item := range ad.AdItems {
+					summary.Items = append(summary.Items, feedItem{item.Name, item.Ingredient, item.IngredientType, item.ValidTo})
+				}
+				got = append(got, summary)
+			}
+			if !slices.EqualFunc(got, tt.want, func(a, b feedAd) bool {
+				return a.Store == b.Store && a.Flyer == b.Flyer && slices.Equal(a.Items, b.Items)
+			}) {
+				t.Errorf("GetUserAds() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetUserAdsInvalidUser(t *testing.T) {
+	repo := memory.New()
+	service := NewUserFeedService(repo, repo, repo, repo)
+	_, err := service.GetUserAds(context.Background(), &pb.GetUserAdsRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetUserAds() error = %v, want InvalidArgument", err)
+	}
+}
+
+// testPostalCodes is a GeoNames style postal code table for SearchStores
+const testPostalCodes = `US	94105	San Francisco	California	CA	San Francisco	075			37.7864	-122.3892	4
+US	94107	San Francisco	California	CA	San Francisco	075			37.7621	-122.3971	4
+US	94607	Oakland	California	CA	Alameda	001			37.8043	-122.2708	4
+US	10001	New York	New York	NY	New York	061			40.7484	-73.9967	4
+US	98101	Seattle	Washington	WA	King	033			47.6114	-122.3305	4
+`
+
+func testGeocoder(t *testing.T) *geo.Table {
+	t.Helper()
+	table, err := geo.Parse(strings.NewReader(testPostalCodes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return table
+}
+
+func seedDirectory(t *testing.T, repo *memory.Repository) {
+	t.Helper()
+	ctx := context.Background()
+	latitude, longitude := 37.7890, -122.4010
+	for _, store := range []models.Store{
+		{Address: models.Address{PostalCode: "94105"}},
+		{Address: models.Address{PostalCode: "94107"}},
+		{Address: models.Address{PostalCode: "94607"}},
+		{Address: models.Address{PostalCode: "10001"}},
+		// placed by its coordinates rather than its postal code
+		{Address: models.Address{PostalCode: "10001"}, Latitude: &latitude, Longitude: &longitude},
+		// cannot be placed
+		{Address: models.Address{PostalCode: "00000"}},
+	} {
+		if err := repo.CreateStore(ctx, store); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := repo.SubscribeStore(ctx, testUser, 2); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSearchStores(t *testing.T) {
+	latitude, longitude := 37.7864, -122.3892
+	tests := []struct {
+		name           string
+		req            *pb.SearchStoresRequest
+		want           []int32
+		wantSubscribed []int32
+	}{
+		{"postal code", &pb.SearchStoresRequest{PostalCode: "94105", RadiusKm: 5}, []int32{1, 5, 2}, nil},
+		{"default radius", &pb.SearchStoresRequest{PostalCode: "94105-1234"}, []int32{1, 5, 2}, nil},
+		{"wider radius", &pb.SearchStoresRequest{PostalCode: "94105", RadiusKm: 15}, []int32{1, 5, 2, 3}, nil},
+		{"coordinates win over postal code", &pb.SearchStoresRequest{PostalCode: "10001", Latitude: &latitude, Longitude: &longitude, RadiusKm: 5},
+			[]int32{1, 5, 2}, nil},
+		{"limit", &pb.SearchStoresRequest{PostalCode: "94105", Limit: 2}, []int32{1, 5}, nil},
+		{"subscribed", &pb.SearchStoresRequest{PostalCode: "94105", UserId: int32(testUser)}, []int32{1, 5, 2}, []int32{2}},
+		{"nothing nearby", &pb.SearchStoresRequest{PostalCode: "98101"}, []int32{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := memory.New()
+			seedDirectory(t, repo)
+			service := NewUserFeedService(repo, repo, repo, repo)
+			service.Geocoder = testGeocoder(t)
+			if _, _, err := service.PlaceStores(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			resp, err := service.SearchStores(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("SearchStores() error = %v", err)
+			}
+			got, subscribed := []int32{}, []int32(nil)
+			for i, nearby := range resp.Stores {
+				got = append(got, nearby.Store.Id)
+				if nearby.Subscribed {
+					subscribed = append(subscribed, nearby.Store.Id)
+				}
+				if i > 0 && nearby.DistanceKm < resp.Stores[i-1].DistanceKm {
+					t.Errorf("store %d is nearer than the store before it", nearby.Store.Id)
+				}
+				if nearby.Store.Latitude == nil || nearby.Store.Longitude == nil {
+					t.Errorf("store %d was not geocoded: %+v", nearby.Store.Id, nearby.Store)
+				}
+			}
+			if !slices.Equal(got, tt.want) || !slices.Equal(subscribed, tt.wantSubscribed) {
+				t.Errorf("SearchStores() = %v subscribed to %v, want %v subscribed to %v", got, subscribed, tt.want, tt.wantSubscribed)
+			}
+		})
+	}
+}
+
+func TestPlaceStores(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.New()
+	seedDirectory(t, repo)
+	service := NewUserFeedService(repo, repo, repo, repo)
+	service.Geocoder = testGeocoder(t)
+	placed, unplaced, err := service.PlaceStores(ctx)
+	if err != nil {
+		t.Fatalf("PlaceStores() error = %v", err)
+	}
+	if placed != 4 || unplaced != 1 {
+		t.Errorf("PlaceStores() = %d placed, %d unplaced, want 4 and 1", placed, unplaced)
+	}
+	store, err := repo.GetStoreByID(ctx, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if point, ok := store.Point(); !ok || point.Latitude != 40.7484 || store.Address.City != "New York" || store.Address.State != "NY" {
+		t.Errorf("store 4 = %+v, want placed in New York, NY", store)
+	}
+	remaining, err := repo.GetUnplacedStores(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 1 || *remaining[0].ID != 6 {
+		t.Errorf("GetUnplacedStores() = %+v, want only store 6", remaining)
+	}
+	// a second run has nothing new to place
+	if placed, unplaced, err = service.PlaceStores(ctx); err != nil || placed != 0 || unplaced != 1 {
+		t.Errorf("PlaceStores() again = %d placed, %d unplaced, %v, want 0 and 1", placed, unplaced, err)
+	}
+}
+
+func TestSearchStoresInvalid(t *testing.T) {
+	latitude, longitude := 91.0, -122.3892
+	tests := []struct {
+		name       string
+		req        *pb.SearchStoresRequest
+		noGeocoder bool
+		want       codes.Code
+	}{
+		{"no origin", &pb.SearchStoresRequest{}, false, codes.InvalidArgument},
+		{"latitude only", &pb.SearchStoresRequest{Latitude: &longitude}, false, codes.InvalidArgument},
+		{"off the globe", &pb.SearchStoresRequest{Latitude: &latitude, Longitude: &longitude}, false, codes.InvalidArgument},
+		{"negative radius", &pb.SearchStoresRequest{PostalCode: "94105", RadiusKm: -1}, false, codes.InvalidArgument},
+		{"unknown postal code", &pb.SearchStoresRequest{PostalCode: "00000"}, false, codes.NotFound},
+		{"no postal code table", &pb.SearchStoresRequest{PostalCode: "94105"}, true, codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewUserFeedService(memory.New(), memory.New(), memory.New(), memory.New())
+			if !tt.noGeocoder {
+				service.Geocoder = testGeocoder(t)
+			}
+			_, err := service.SearchStores(context.Background(), tt.req)
+			if status.Code(err) != tt.want {
+				t.Errorf("SearchStores() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -1,90 +1,162 @@
+// Command start refreshes expired store ads through Temporal.
+//
+//	start [run]                 run RefreshExpiredAds once and wait for it
+//	start schedule [create]     create or update the daily refresh schedule
+//	start schedule status       show the schedule and per-store outcomes of recent runs
+//	start schedule trigger      start a scheduled run now
+//	start schedule delete       remove the schedule
 package main
 
 import (
-	"backend/main/config"
 	"backend/main/workflows"
 
 	"context"
-
-	"go.temporal.io/sdk/client"
-
+	"errors"
 	"fmt"
-	"time"
+	"os"
 
-	"github.com/robfig/cron/v3"
-	"go.uber.org/zap"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+)
 
+const (
+	// ScheduleID is the Temporal Schedule that runs RefreshExpiredAds
+	ScheduleID = "refresh-expired-ads"
+	// RefreshCron is when the schedule runs, daily at 3am
+	RefreshCron = "0 3 * * *"
+	// refreshWorkflowID prefixes every RefreshExpiredAds run; scheduled runs get the
+	// scheduled time appended by Temporal
+	refreshWorkflowID = "refresh-expired-ads"
 )
 
-func runWorkflow() {
-	fmt.Println("executing workflow at", time.Now())
+func main() {
+	if err := run(context.Background(), os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "start:", err)
+		os.Exit(1)
+	}
+}
 
+func run(ctx context.Context, args []string) error {
 	c, err := client.Dial(client.Options{})
-
 	if err != nil {
-		fmt.Println("Failed to create Temporal client", zap.Error(err))
-		return
+		return fmt.Errorf("creating Temporal client: %w", err)
 	}
 	defer c.Close()
 
-	options := client.StartWorkflowOptions{
-		ID:		"get_expired_ad_stores_workflow_" + string(time.Now().GoString()),
-		TaskQueue: workflows.GetExpiredAdStoresTaskQueueName,
+	if len(args) == 0 || args[0] == "run" {
+		return runOnce(ctx, c)
+	}
+	if args[0] != "schedule" {
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+	command := "create"
+	if len(args) > 1 {
+		command = args[1]
 	}
+	handle := c.ScheduleClient().GetHandle(ctx, ScheduleID)
+	switch command {
+	case "create":
+		return createSchedule(ctx, c)
+	case "status":
+		return scheduleStatus(ctx, c, handle)
+	case "trigger":
+		return handle.Trigger(ctx, client.ScheduleTriggerOptions{})
+	case "delete":
+		return handle.Delete(ctx)
+	}
+	return fmt.Errorf("unknown schedule command %q", command)
+}
 
-	we, err := c.ExecuteWorkflow(context.Background(), options, workflows.GetExpiredAdStores)
+func refreshAction() *client.ScheduleWorkflowAction {
+	return &client.ScheduleWorkflowAction{
+		ID:        refreshWorkflowID,
+		Workflow:  workflows.RefreshExpiredAds,
+		Args:      []interface{}{workflows.RefreshExpiredAdsInput{}},
+		TaskQueue: workflows.RefreshExpiredAdsTaskQueueName,
+	}
+}
 
+// createSchedule creates the refresh schedule, or updates its spec and action if it
+// already exists. A run still going when the next one is due makes the next one skip.
+func createSchedule(ctx context.Context, c client.Client) error {
+	spec := client.ScheduleSpec{CronExpressions: []string{RefreshCron}}
+	handle, err := c.ScheduleClient().Create(ctx, client.ScheduleOptions{
+		ID:      ScheduleID,
+		Spec:    spec,
+		Action:  refreshAction(),
+		Overlap: enumspb.SCHEDULE_OVERLAP_POLICY_SKIP,
+	})
+	if errors.Is(err, temporal.ErrScheduleAlreadyRunning) {
+		handle = c.ScheduleClient().GetHandle(ctx, ScheduleID)
+		err = handle.Update(ctx, client.ScheduleUpdateOptions{
+			DoUpdate: func(input client.ScheduleUpdateInput) (*client.ScheduleUpdate, error) {
+				schedule := input.Description.Schedule
+				schedule.Spec = &spec
+				schedule.Action = refreshAction()
+				return &client.ScheduleUpdate{Schedule: &schedule}, nil
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("updating schedule: %w", err)
+		}
+		fmt.Println("updated schedule", ScheduleID)
+		return nil
+	}
 	if err != nil {
-		fmt.Println("Failed to execute workflow", zap.Error(err))
-		return
+		return fmt.Errorf("creating schedule: %w", err)
 	}
-	var expiredAdStores []workflows.AdProcessInput
-	err = we.Get(context.Background(), &expiredAdStores)
+	fmt.Println("created schedule", handle.GetID())
+	return nil
+}
+
+func scheduleStatus(ctx context.Context, c client.Client, handle client.ScheduleHandle) error {
+	description, err := handle.Describe(ctx)
 	if err != nil {
-		fmt.Println("Failed to get workflow result", zap.Error(err))
-		return
+		return fmt.Errorf("describing schedule: %w", err)
 	}
-	options = client.StartWorkflowOptions{
-		ID:		"ad_workflow_" + string(time.Now().GoString()),
-		TaskQueue: workflows.AdProcessingTaskQueueName,
+	if len(description.Info.NextActionTimes) > 0 {
+		fmt.Println("next run", description.Info.NextActionTimes[0])
 	}
-	for _, v := range expiredAdStores {
-		we, err = c.ExecuteWorkflow(context.Background(), options, workflows.AdProcess, v.StoreID, v.ZipCode, v.StoreFlippName)
+	for _, action := range description.Info.RecentActions {
+		execution := action.StartWorkflowResult
+		if execution == nil {
+			continue
+		}
+		fmt.Println("run", execution.WorkflowID, "at", action.ActualTime)
+		var result workflows.RefreshExpiredAdsResult
+		err := c.GetWorkflow(ctx, execution.WorkflowID, execution.FirstExecutionRunID).Get(ctx, &result)
 		if err != nil {
-			fmt.Println("Failed to execute workflow", zap.Error(err))
-			return
+			fmt.Println("  ", err)
+			continue
 		}
+		printOutcomes(result)
 	}
+	return nil
+}
 
-	var result string
-
-	err = we.Get(context.Background(), &result)
-
+func runOnce(ctx context.Context, c client.Client) error {
+	we, err := c.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		ID:        refreshWorkflowID + "-manual",
+		TaskQueue: workflows.RefreshExpiredAdsTaskQueueName,
+		// a manual run is refused while another is still going
+		WorkflowIDConflictPolicy: enumspb.WORKFLOW_ID_CONFLICT_POLICY_FAIL,
+	}, workflows.RefreshExpiredAds, workflows.RefreshExpiredAdsInput{})
 	if err != nil {
-		fmt.Println("Failed to get workflow result", zap.Error(err))
-		return
+		return fmt.Errorf("starting refresh: %w", err)
+	}
+	var result workflows.RefreshExpiredAdsResult
+	if err := we.Get(ctx, &result); err != nil {
+		return fmt.Errorf("refreshing expired ads: %w", err)
 	}
-	fmt.Println("Workflow result:", result)
+	printOutcomes(result)
+	return nil
 }
 
-func main() {
-	config.ConnectPostgreSQL()
-	config.InitFirebase()
-	config.InitLogger()
-	config.InitGenAI()
-	ctx := context.Background()
-	_, err := config.FirebaseApp.Database(ctx)
-	if err != nil {
-		fmt.Print(err)
-		return
+func printOutcomes(result workflows.RefreshExpiredAdsResult) {
+	for _, store := range result.Stores {
+		fmt.Printf("  store %d: %s %s\n", store.StoreID, store.Outcome, store.Error)
 	}
-	runWorkflow()
-	
-	c := cron.New()
-	c.AddFunc("0 3 * * *", runWorkflow)
-	c.Start()
-	
-	
-	
-	select {}
-}
\ No newline at end of file
+	fmt.Printf("  %d succeeded, %d failed, %d skipped\n", result.Count(workflows.OutcomeSucceeded),
+		result.Count(workflows.OutcomeFailed), result.Count(workflows.OutcomeSkipped))
+}
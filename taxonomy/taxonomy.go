@@ -0,0 +1,111 @@
+// Package taxonomy walks the parent/child ingredient hierarchy, e.g. cheddar ->
+// sharp cheddar or chicken -> chicken breast, so that matching can roll up to a
+// parent or down to a child ingredient.
+package taxonomy
+
+import (
+	"slices"
+)
+
+// DefaultDepth is how many levels up or down an ingredient may be substituted by default
+const DefaultDepth = 1
+
+// Relative is an ingredient in the same lineage as another, Distance levels away.
+// Distance is 0 for the ingredient itself.
+type Relative struct {
+	ID       uint
+	Distance int
+}
+
+// Tree is the ingredient hierarchy. A nil *Tree is a flat hierarchy in which every
+// ingredient is related only to itself.
+type Tree struct {
+	parent   map[uint]uint
+	children map[uint][]uint
+}
+
+// New builds a Tree from a child to parent map, as returned by
+// IngredientModel.GetIngredientParents. Edges that would close a cycle are dropped.
+func New(parents map[uint]uint) *Tree {
+	t := &Tree{
+		parent:   make(map[uint]uint, len(parents)),
+		children: make(map[uint][]uint),
+	}
+	ids := make([]uint, 0, len(parents))
+	for child := range parents {
+		ids = append(ids, child)
+	}
+	// add edges in a stable order so the same input always drops the same cycle edge
+	slices.Sort(ids)
+	for _, child := range ids {
+		parent := parents[child]
+		if parent == child || t.isAncestor(child, parent) {
+			continue
+		}
+		t.parent[child] = parent
+		t.children[parent] = append(t.children[parent], child)
+	}
+	for parent := range t.children {
+		slices.Sort(t.children[parent])
+	}
+	return t
+}
+
+// isAncestor reports whether ancestor is on the path from id to its root
+func (t *Tree) isAncestor(ancestor uint, id uint) bool {
+	for {
+		if id == ancestor {
+			return true
+		}
+		parent, ok := t.parent[id]
+		if !ok {
+			return false
+		}
+		id = parent
+	}
+}
+
+// Related returns the ingredient itself, then its ancestors and descendants within depth
+// levels, nearest first. Siblings are never related: a recipe calling for cheddar does
+// not match mozzarella just because both are cheeses.
+func (t *Tree) Related(id uint, depth int) []Relative {
+	related := []Relative{{ID: id}}
+	if t == nil || depth <= 0 {
+		return related
+	}
+	ancestor := id
+	for distance := 1; distance <= depth; distance++ {
+		parent, ok := t.parent[ancestor]
+		if !ok {
+			break
+		}
+		related = append(related, Relative{ID: parent, Distance: distance})
+		ancestor = parent
+	}
+	level := []uint{id}
+	for distance := 1; distance <= depth && len(level) > 0; distance++ {
+		var next []uint
+		for _, node := range level {
+			for _, child := range t.children[node] {
+				related = append(related, Relative{ID: child, Distance: distance})
+				next = append(next, child)
+			}
+		}
+		level = next
+	}
+	slices.SortStableFunc(related, func(a, b Relative) int {
+		return a.Distance - b.Distance
+	})
+	return related
+}
+
+// Distance returns how many levels apart two ingredients in the same lineage are, and
+// false when they are not within depth of each other
+func (t *Tree) Distance(a uint, b uint, depth int) (int, bool) {
+	for _, relative := range t.Related(a, depth) {
+		if relative.ID == b {
+			return relative.Distance, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,83 @@
+package taxonomy
+
+import (
+	"slices"
+	"testing"
+)
+
+const (
+	cheese uint = iota + 1
+	cheddar
+	sharpCheddar
+	mozzarella
+	chicken
+	chickenBreast
+	boneless
+)
+
+var testParents = map[uint]uint{
+	cheddar:       cheese,
+	sharpCheddar:  cheddar,
+	mozzarella:    cheese,
+	chickenBreast: chicken,
+	boneless:      chickenBreast,
+}
+
+func TestRelated(t *testing.T) {
+	tree := New(testParents)
+	tests := []struct {
+		name  string
+		id    uint
+		depth int
+		want  []Relative
+	}{
+		{"depth zero is exact", cheddar, 0, []Relative{{cheddar, 0}}},
+		{"rolls up and down one level", cheddar, 1, []Relative{{cheddar, 0}, {cheese, 1}, {sharpCheddar, 1}}},
+		{"siblings are not related", mozzarella, 2, []Relative{{mozzarella, 0}, {cheese, 1}}},
+		{"root rolls down", cheese, 2, []Relative{{cheese, 0}, {cheddar, 1}, {mozzarella, 1}, {sharpCheddar, 2}}},
+		{"leaf rolls up", boneless, 2, []Relative{{boneless, 0}, {chickenBreast, 1}, {chicken, 2}}},
+		{"unknown ingredient", 99, 3, []Relative{{99, 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tree.Related(tt.id, tt.depth); !slices.Equal(got, tt.want) {
+				t.Errorf("Related(%d, %d) = %v, want %v", tt.id, tt.depth, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tree := New(testParents)
+	if d, ok := tree.Distance(cheddar, sharpCheddar, 1); !ok || d != 1 {
+		t.Errorf("Distance(cheddar, sharp cheddar) = %d, %v", d, ok)
+	}
+	if d, ok := tree.Distance(chickenBreast, chicken, 1); !ok || d != 1 {
+		t.Errorf("Distance(chicken breast, chicken) = %d, %v", d, ok)
+	}
+	if _, ok := tree.Distance(boneless, chicken, 1); ok {
+		t.Error("Distance(boneless, chicken, 1) matched beyond depth")
+	}
+	if _, ok := tree.Distance(cheddar, mozzarella, 3); ok {
+		t.Error("Distance(cheddar, mozzarella) matched siblings")
+	}
+}
+
+func TestNilTree(t *testing.T) {
+	var flat *Tree
+	if got := flat.Related(cheddar, 3); !slices.Equal(got, []Relative{{cheddar, 0}}) {
+		t.Errorf("nil Related() = %v", got)
+	}
+}
+
+func TestNewDropsCycles(t *testing.T) {
+	tree := New(map[uint]uint{1: 2, 2: 3, 3: 1, 4: 4})
+	if got := tree.Related(4, 1); !slices.Equal(got, []Relative{{4, 0}}) {
+		t.Errorf("Related(4) = %v, self parent kept", got)
+	}
+	for _, id := range []uint{1, 2, 3} {
+		if got := tree.Related(id, 10); len(got) != 3 {
+			t.Errorf("Related(%d) = %v, want each of the three once", id, got)
+		}
+	}
+}
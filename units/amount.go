@@ -0,0 +1,153 @@
+package units
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidAmount is returned when an amount string cannot be parsed
+var ErrInvalidAmount = errors.New("units: invalid amount")
+
+// Range is a parsed amount. Single values have Min == Max; "2-3" parses to {2, 3}.
+type Range struct {
+	Min float64
+	Max float64
+}
+
+// IsRange reports whether the amount spans more than one value
+func (r Range) IsRange() bool {
+	return r.Min != r.Max
+}
+
+var unicodeFractions = map[rune]string{
+	'½': "1/2",
+	'⅓': "1/3",
+	'⅔': "2/3",
+	'¼': "1/4",
+	'¾': "3/4",
+	'⅕': "1/5",
+	'⅖': "2/5",
+	'⅗': "3/5",
+	'⅘': "4/5",
+	'⅙': "1/6",
+	'⅚': "5/6",
+	'⅛': "1/8",
+	'⅜': "3/8",
+	'⅝': "5/8",
+	'⅞': "7/8",
+}
+
+var rangeSeparators = []string{" to ", " or ", "–", "—", "-"}
+
+// ParseAmount parses recipe and pantry amounts such as "2", "1.5", "1/2", "1 1/2",
+// "1½", "2-3" and "2 to 3". "1-1/2" is read as the mixed number 1 1/2, not a range.
+func ParseAmount(s string) (Range, error) {
+	s = expandUnicodeFractions(strings.ToLower(strings.TrimSpace(s)))
+	if s == "" {
+		return Range{}, ErrInvalidAmount
+	}
+	for _, sep := range rangeSeparators {
+		left, right, ok := strings.Cut(s, sep)
+		if !ok || strings.TrimSpace(left) == "" {
+			continue
+		}
+		min, err := parseNumber(left)
+		if err != nil {
+			return Range{}, err
+		}
+		max, err := parseNumber(right)
+		if err != nil {
+			return Range{}, err
+		}
+		// "1-1/2" is a mixed number written with a hyphen
+		if sep == "-" && isWhole(min) && strings.Contains(right, "/") && max < 1 {
+			return Range{Min: min + max, Max: min + max}, nil
+		}
+		if max < min {
+			return Range{}, ErrInvalidAmount
+		}
+		return Range{Min: min, Max: max}, nil
+	}
+	value, err := parseNumber(s)
+	if err != nil {
+		return Range{}, err
+	}
+	return Range{Min: value, Max: value}, nil
+}
+
+// FormatAmount renders a value with at most two decimals and no trailing zeros
+func FormatAmount(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+func expandUnicodeFractions(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r == '⁄' {
+			b.WriteRune('/')
+			continue
+		}
+		if fraction, ok := unicodeFractions[r]; ok {
+			b.WriteRune(' ')
+			b.WriteString(fraction)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// parseNumber parses a whole, decimal, fraction or mixed number
+func parseNumber(s string) (float64, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields) > 2 {
+		return 0, ErrInvalidAmount
+	}
+	var total float64
+	for n, field := range fields {
+		value, isFraction, err := parseTerm(field)
+		if err != nil {
+			return 0, err
+		}
+		// only "<whole> <fraction>" is accepted as two terms
+		if n == 1 && (!isFraction || !isWhole(total)) {
+			return 0, ErrInvalidAmount
+		}
+		total += value
+	}
+	return total, nil
+}
+
+func parseTerm(s string) (value float64, isFraction bool, err error) {
+	if num, den, ok := strings.Cut(s, "/"); ok {
+		n, err := strconv.ParseFloat(num, 64)
+		if err != nil || !validNumber(n) {
+			return 0, false, ErrInvalidAmount
+		}
+		d, err := strconv.ParseFloat(den, 64)
+		if err != nil || !validNumber(d) || d == 0 {
+			return 0, false, ErrInvalidAmount
+		}
+		value = n / d
+		if !validNumber(value) {
+			return 0, false, ErrInvalidAmount
+		}
+		return value, true, nil
+	}
+	value, err = strconv.ParseFloat(s, 64)
+	if err != nil || !validNumber(value) {
+		return 0, false, ErrInvalidAmount
+	}
+	return value, false, nil
+}
+
+// validNumber reports whether v is a finite, non-negative amount
+func validNumber(v float64) bool {
+	return v >= 0 && !math.IsInf(v, 0) && !math.IsNaN(v)
+}
+
+func isWhole(v float64) bool {
+	return v == math.Trunc(v)
+}
@@ -0,0 +1,120 @@
+package units
+
+import (
+	"strings"
+)
+
+// DefaultDensities holds grams per millilitre for common ingredients, keyed by the
+// ingredient names used in the ingredient table
+var DefaultDensities = map[string]float64{
+	"water":             1.0,
+	"milk":              1.03,
+	"heavy cream":       0.99,
+	"butter":            0.96,
+	"vegetable oil":     0.92,
+	"olive oil":         0.91,
+	"honey":             1.42,
+	"maple syrup":       1.32,
+	"flour":             0.53,
+	"all-purpose flour": 0.53,
+	"sugar":             0.85,
+	"brown sugar":       0.93,
+	"powdered sugar":    0.56,
+	"salt":              1.22,
+	"rice":              0.85,
+	"oats":              0.41,
+	"cocoa powder":      0.44,
+	"yogurt":            1.03,
+	"sour cream":        0.96,
+	"peanut butter":     1.09,
+}
+
+// Converter converts quantities between units. Volume and mass convert into each other
+// only for ingredients with a known density.
+type Converter struct {
+	Densities map[string]float64
+}
+
+// NewConverter returns a Converter using DefaultDensities with the given per-ingredient
+// overrides applied on top
+func NewConverter(overrides map[string]float64) *Converter {
+	densities := make(map[string]float64, len(DefaultDensities)+len(overrides))
+	for name, density := range DefaultDensities {
+		densities[name] = density
+	}
+	for name, density := range overrides {
+		densities[strings.ToLower(name)] = density
+	}
+	return &Converter{Densities: densities}
+}
+
+// Density returns grams per millilitre for an ingredient name
+func (c *Converter) Density(ingredient string) (float64, bool) {
+	density, ok := c.Densities[strings.ToLower(strings.TrimSpace(ingredient))]
+	return density, ok && density > 0
+}
+
+// Convert expresses q in the target unit. ingredient is only used to look up a density
+// when converting between volume and mass.
+func (c *Converter) Convert(q Quantity, to Unit, ingredient string) (Quantity, error) {
+	from := q.Unit
+	if from.Name == to.Name {
+		return Quantity{Value: q.Value, Unit: to}, nil
+	}
+	if from.Factor == 0 || to.Factor == 0 {
+		return Quantity{}, ErrIncompatible
+	}
+	base := q.Value * from.Factor
+	switch {
+	case from.Dimension == to.Dimension:
+	case from.Dimension == Volume && to.Dimension == Mass:
+		density, ok := c.Density(ingredient)
+		if !ok {
+			return Quantity{}, ErrIncompatible
+		}
+		base *= density
+	case from.Dimension == Mass && to.Dimension == Volume:
+		density, ok := c.Density(ingredient)
+		if !ok {
+			return Quantity{}, ErrIncompatible
+		}
+		base /= density
+	default:
+		return Quantity{}, ErrIncompatible
+	}
+	return Quantity{Value: base / to.Factor, Unit: to}, nil
+}
+
+// Add returns a + b in a's unit
+func (c *Converter) Add(a Quantity, b Quantity, ingredient string) (Quantity, error) {
+	converted, err := c.Convert(b, a.Unit, ingredient)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return Quantity{Value: a.Value + converted.Value, Unit: a.Unit}, nil
+}
+
+// Sub returns a - b in a's unit. The result may be negative.
+func (c *Converter) Sub(a Quantity, b Quantity, ingredient string) (Quantity, error) {
+	converted, err := c.Convert(b, a.Unit, ingredient)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return Quantity{Value: a.Value - converted.Value, Unit: a.Unit}, nil
+}
+
+// Compare returns -1, 0 or 1 as a is less than, equal to or greater than b
+func (c *Converter) Compare(a Quantity, b Quantity, ingredient string) (int, error) {
+	diff, err := c.Sub(a, b, ingredient)
+	if err != nil {
+		return 0, err
+	}
+	const epsilon = 1e-9
+	switch {
+	case diff.Value < -epsilon:
+		return -1, nil
+	case diff.Value > epsilon:
+		return 1, nil
+	}
+	return 0, nil
+}
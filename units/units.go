@@ -0,0 +1,144 @@
+// Package units parses and converts the free-form amounts and units stored on
+// recipe ingredients, pantry ingredients and shopping list items.
+package units
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrIncompatible is returned when two quantities cannot be converted into each other
+var ErrIncompatible = errors.New("units: incompatible units")
+
+// Dimension is what a unit measures
+type Dimension int
+
+const (
+	Unknown Dimension = iota
+	Mass
+	Volume
+	Count
+)
+
+var dimensionName = map[Dimension]string{
+	Unknown: "Unknown",
+	Mass:    "Mass",
+	Volume:  "Volume",
+	Count:   "Count",
+}
+
+func (d Dimension) String() string {
+	return dimensionName[d]
+}
+
+// Unit is a canonical unit. Factor converts one of the unit into the base unit of its
+// dimension (grams, millilitres or each). Units with a zero Factor, such as "can" or
+// "clove", only convert to themselves.
+type Unit struct {
+	Name      string
+	Dimension Dimension
+	Factor    float64
+}
+
+// Each is the unit used when an amount has no unit, e.g. "2" eggs
+var Each = Unit{Name: "each", Dimension: Count, Factor: 1}
+
+var canonicalUnits = []struct {
+	unit    Unit
+	aliases []string
+}{
+	{Unit{"g", Mass, 1}, []string{"g", "gram", "grams", "gr", "gm"}},
+	{Unit{"kg", Mass, 1000}, []string{"kg", "kgs", "kilogram", "kilograms", "kilo", "kilos"}},
+	{Unit{"mg", Mass, 0.001}, []string{"mg", "milligram", "milligrams"}},
+	{Unit{"oz", Mass, 28.349523125}, []string{"oz", "ounce", "ounces", "oz."}},
+	{Unit{"lb", Mass, 453.59237}, []string{"lb", "lbs", "lb.", "lbs.", "pound", "pounds", "#"}},
+
+	{Unit{"ml", Volume, 1}, []string{"ml", "milliliter", "milliliters", "millilitre", "millilitres", "mls"}},
+	{Unit{"l", Volume, 1000}, []string{"l", "liter", "liters", "litre", "litres"}},
+	{Unit{"tsp", Volume, 4.92892159375}, []string{"tsp", "tsps", "tsp.", "t", "teaspoon", "teaspoons"}},
+	{Unit{"tbsp", Volume, 14.78676478125}, []string{"tbsp", "tbsps", "tbsp.", "tbs", "tbl", "tablespoon", "tablespoons"}},
+	{Unit{"fl oz", Volume, 29.5735295625}, []string{"fl oz", "fl. oz.", "fl oz.", "fluid ounce", "fluid ounces", "floz"}},
+	{Unit{"cup", Volume, 236.5882365}, []string{"cup", "cups", "c", "c."}},
+	{Unit{"pint", Volume, 473.176473}, []string{"pint", "pints", "pt", "pts"}},
+	{Unit{"quart", Volume, 946.352946}, []string{"quart", "quarts", "qt", "qts"}},
+	{Unit{"gallon", Volume, 3785.411784}, []string{"gallon", "gallons", "gal"}},
+
+	{Each, []string{"", "each", "ea", "ea.", "whole", "piece", "pieces", "pc", "pcs", "ct", "count", "unit", "units", "item", "items"}},
+	{Unit{"pair", Count, 2}, []string{"pair", "pairs"}},
+	{Unit{"dozen", Count, 12}, []string{"dozen", "doz", "dz"}},
+
+	{Unit{"can", Count, 0}, []string{"can", "cans", "tin", "tins"}},
+	{Unit{"clove", Count, 0}, []string{"clove", "cloves"}},
+	{Unit{"bunch", Count, 0}, []string{"bunch", "bunches"}},
+	{Unit{"package", Count, 0}, []string{"package", "packages", "pkg", "pkgs", "pack", "packs", "packet", "packets"}},
+	{Unit{"slice", Count, 0}, []string{"slice", "slices"}},
+	{Unit{"stalk", Count, 0}, []string{"stalk", "stalks", "rib", "ribs"}},
+	{Unit{"head", Count, 0}, []string{"head", "heads"}},
+	{Unit{"jar", Count, 0}, []string{"jar", "jars"}},
+	{Unit{"bottle", Count, 0}, []string{"bottle", "bottles"}},
+	{Unit{"pinch", Count, 0}, []string{"pinch", "pinches", "dash", "dashes"}},
+}
+
+var aliases = func() map[string]Unit {
+	m := make(map[string]Unit)
+	for _, entry := range canonicalUnits {
+		for _, alias := range entry.aliases {
+			m[alias] = entry.unit
+		}
+	}
+	return m
+}()
+
+// LookupUnit resolves a unit alias such as "Tablespoons" or "lbs". Single-letter "T"
+// is read as tablespoon and "t" as teaspoon; every other alias is case-insensitive.
+func LookupUnit(s string) (Unit, bool) {
+	s = strings.TrimSpace(s)
+	if s == "T" || s == "Tbsp" || s == "TBSP" {
+		return aliases["tbsp"], true
+	}
+	if s != "t" {
+		s = strings.ToLower(s)
+	}
+	s = strings.Join(strings.Fields(s), " ")
+	unit, ok := aliases[s]
+	return unit, ok
+}
+
+// ParseUnit resolves a unit alias. Unrecognized units are returned lowercased with an
+// Unknown dimension so that identical spellings can still be combined.
+func ParseUnit(s string) Unit {
+	if unit, ok := LookupUnit(s); ok {
+		return unit
+	}
+	return Unit{Name: strings.ToLower(strings.Join(strings.Fields(s), " ")), Dimension: Unknown}
+}
+
+// NormalizeUnit returns the canonical spelling of a unit alias, or the lowercased input
+// when the unit is not recognized
+func NormalizeUnit(s string) string {
+	return ParseUnit(s).Name
+}
+
+// Quantity is an amount in a unit. Ranges are resolved to their upper bound so that a
+// recipe calling for "2-3 cups" is never under-bought.
+type Quantity struct {
+	Value float64
+	Unit  Unit
+}
+
+// ParseQuantity parses an amount and unit pair such as ("1 1/2", "tbsp")
+func ParseQuantity(amount string, unit string) (Quantity, error) {
+	r, err := ParseAmount(amount)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return Quantity{Value: r.Max, Unit: ParseUnit(unit)}, nil
+}
+
+// String renders the quantity as "1.5 cup"
+func (q Quantity) String() string {
+	if q.Unit.Name == "" {
+		return FormatAmount(q.Value)
+	}
+	return FormatAmount(q.Value) + " " + q.Unit.Name
+}
@@ -0,0 +1,205 @@
+package units
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Range
+		wantErr bool
+	}{
+		{in: "2", want: Range{2, 2}},
+		{in: " 1.5 ", want: Range{1.5, 1.5}},
+		{in: "1/2", want: Range{0.5, 0.5}},
+		{in: "1 1/2", want: Range{1.5, 1.5}},
+		{in: "1-1/2", want: Range{1.5, 1.5}},
+		{in: "½", want: Range{0.5, 0.5}},
+		{in: "1½", want: Range{1.5, 1.5}},
+		{in: "1 ½", want: Range{1.5, 1.5}},
+		{in: "2 ¾", want: Range{2.75, 2.75}},
+		{in: "1⁄3", want: Range{1.0 / 3, 1.0 / 3}},
+		{in: "2-3", want: Range{2, 3}},
+		{in: "2 - 3", want: Range{2, 3}},
+		{in: "2–3", want: Range{2, 3}},
+		{in: "2 to 3", want: Range{2, 3}},
+		{in: "1/2-1", want: Range{0.5, 1}},
+		{in: "1 or 2", want: Range{1, 2}},
+		{in: "", wantErr: true},
+		{in: "a pinch", wantErr: true},
+		{in: "1/0", wantErr: true},
+		{in: "-1/2", wantErr: true},
+		{in: "1/-2", wantErr: true},
+		{in: "NaN/2", wantErr: true},
+		{in: "1/NaN", wantErr: true},
+		{in: "Inf/1", wantErr: true},
+		{in: "1 Inf/1", wantErr: true},
+		{in: "1e308/1e-308", wantErr: true},
+		{in: "NaN", wantErr: true},
+		{in: "Inf", wantErr: true},
+		{in: "-2", wantErr: true},
+		{in: "3-2", wantErr: true},
+		{in: "1/2 1", wantErr: true},
+		{in: "1 2 3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseAmount(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidAmount) {
+					t.Fatalf("ParseAmount(%q) error = %v, want ErrInvalidAmount", tt.in, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAmount(%q) unexpected error: %v", tt.in, err)
+			}
+			if !approx(got.Min, tt.want.Min) || !approx(got.Max, tt.want.Max) {
+				t.Errorf("ParseAmount(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeUnit(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"tbsp", "tbsp"},
+		{"Tablespoons", "tbsp"},
+		{"T", "tbsp"},
+		{"t", "tsp"},
+		{"tsp.", "tsp"},
+		{"Cups", "cup"},
+		{"lbs", "lb"},
+		{"Pound", "lb"},
+		{"oz", "oz"},
+		{"fl  oz", "fl oz"},
+		{"Fluid Ounces", "fl oz"},
+		{"", "each"},
+		{"pcs", "each"},
+		{"Cloves", "clove"},
+		{"Handful", "handful"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := NormalizeUnit(tt.in); got != tt.want {
+				t.Errorf("NormalizeUnit(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	c := NewConverter(map[string]float64{"Chocolate Chips": 0.6})
+	tests := []struct {
+		name       string
+		amount     string
+		from       string
+		to         string
+		ingredient string
+		want       float64
+		wantErr    bool
+	}{
+		{name: "tbsp to tsp", amount: "1", from: "tbsp", to: "tsp", want: 3},
+		{name: "cup to tbsp", amount: "1/4", from: "cup", to: "tbsp", want: 4},
+		{name: "lb to oz", amount: "1 1/2", from: "lbs", to: "oz", want: 24},
+		{name: "kg to g", amount: "2", from: "kg", to: "g", want: 2000},
+		{name: "dozen to each", amount: "1", from: "dozen", to: "", want: 12},
+		{name: "same unknown unit", amount: "2", from: "handful", to: "Handful", want: 2},
+		{name: "can to can", amount: "2", from: "cans", to: "can", want: 2},
+		{name: "volume to mass with density", amount: "1", from: "cup", to: "g", ingredient: "water", want: 236.5882365},
+		{name: "mass to volume with density", amount: "125", from: "g", to: "cup", ingredient: "flour", want: 125 / 0.53 / 236.5882365},
+		{name: "density override", amount: "100", from: "ml", to: "g", ingredient: "chocolate chips", want: 60},
+		{name: "volume to mass without density", amount: "1", from: "cup", to: "g", ingredient: "kale", wantErr: true},
+		{name: "count to mass", amount: "2", from: "", to: "g", ingredient: "water", wantErr: true},
+		{name: "can to each", amount: "1", from: "can", to: "each", wantErr: true},
+		{name: "different unknown units", amount: "1", from: "handful", to: "sprig", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseQuantity(tt.amount, tt.from)
+			if err != nil {
+				t.Fatalf("ParseQuantity(%q, %q) unexpected error: %v", tt.amount, tt.from, err)
+			}
+			got, err := c.Convert(q, ParseUnit(tt.to), tt.ingredient)
+			if tt.wantErr {
+				if !errors.Is(err, ErrIncompatible) {
+					t.Fatalf("Convert error = %v, want ErrIncompatible", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Convert unexpected error: %v", err)
+			}
+			if !approx(got.Value, tt.want) {
+				t.Errorf("Convert = %v, want %v", got.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	c := NewConverter(nil)
+	tests := []struct {
+		name        string
+		a, b        Quantity
+		wantSum     float64
+		wantDiff    float64
+		wantCompare int
+	}{
+		{
+			name: "cups and tbsp", a: Quantity{1, ParseUnit("cup")}, b: Quantity{8, ParseUnit("tbsp")},
+			wantSum: 1.5, wantDiff: 0.5, wantCompare: 1,
+		},
+		{
+			name: "lb and oz", a: Quantity{1, ParseUnit("lb")}, b: Quantity{16, ParseUnit("oz")},
+			wantSum: 2, wantDiff: 0, wantCompare: 0,
+		},
+		{
+			name: "each and dozen", a: Quantity{6, Each}, b: Quantity{1, ParseUnit("dozen")},
+			wantSum: 18, wantDiff: -6, wantCompare: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sum, err := c.Add(tt.a, tt.b, "")
+			if err != nil || !approx(sum.Value, tt.wantSum) || sum.Unit != tt.a.Unit {
+				t.Errorf("Add = %v, %v, want %v %s", sum, err, tt.wantSum, tt.a.Unit.Name)
+			}
+			diff, err := c.Sub(tt.a, tt.b, "")
+			if err != nil || !approx(diff.Value, tt.wantDiff) {
+				t.Errorf("Sub = %v, %v, want %v", diff, err, tt.wantDiff)
+			}
+			cmp, err := c.Compare(tt.a, tt.b, "")
+			if err != nil || cmp != tt.wantCompare {
+				t.Errorf("Compare = %v, %v, want %v", cmp, err, tt.wantCompare)
+			}
+		})
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want string
+	}{
+		{2, "2"},
+		{1.5, "1.5"},
+		{1.0 / 3, "0.33"},
+		{0.999, "1"},
+	}
+	for _, tt := range tests {
+		if got := FormatAmount(tt.in); got != tt.want {
+			t.Errorf("FormatAmount(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -2,57 +2,37 @@ package workflows
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
 
-	"strings"
-	
+	"backend/main/classifier"
 	"backend/main/config"
+	"backend/main/deals"
 	"backend/main/models"
+	"backend/main/normalize"
 	
-	"github.com/google/generative-ai-go/genai"
+	"go.temporal.io/sdk/temporal"
 	"go.uber.org/zap"
 )
 
-func GetFlippData(ctx context.Context, zipCode string, merchant string) (RequestData, error) {
-	httpClient := &http.Client{}
-	resp, err := httpClient.Get("https://backflipp.wishabi.com/flipp/items/search?locale=en&postal_code=" + zipCode + "&q=" + merchant)
-	if err != nil {
-		return RequestData{}, err
-	}
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return RequestData{}, err
-	}
-	var requestData RequestData
-	err = json.Unmarshal(body, &requestData)
-	if err != nil {
-		return RequestData{}, err
-	}
-	foodItemsRequestData := RequestData{}
-	for _, value := range requestData.Items {
-		if value.L2 == "Food Items" {
-			foodItemsRequestData.Items = append(foodItemsRequestData.Items, value)
-		}
-	}
-	return foodItemsRequestData, nil
-}
+// ErrNoAdItems is the error type of RetrieveTranslations when there are no items to
+// take the ad's sale window from
+const ErrNoAdItems = "NoAdItems"
 
-func RetrieveTranslations(store_id uint, data RequestData) (result RetrieveTranslationsResult, err error) {
-	translationModel := models.NewTranslationModel(config.PostgreSQL, *config.Logger)
+func RetrieveTranslations(ctx context.Context, store_id uint, items []AdItem) (result RetrieveTranslationsResult, err error) {
+	if len(items) == 0 {
+		return RetrieveTranslationsResult{}, temporal.NewNonRetryableApplicationError("ad has no items", ErrNoAdItems, nil)
+	}
+	translationModel := models.NewTranslationModel(database(), *config.Logger)
 	if translationModel == nil {
 		config.Logger.Error("Failed to create translation model")
 		return RetrieveTranslationsResult{}, err
 	}
 	result.Ad.StoreID = store_id
-	result.Ad.SaleStart = data.Items[0].ValidFrom
-	result.Ad.SaleEnd = data.Items[0].ValidTo
+	result.Ad.SaleStart = items[0].ValidFrom
+	result.Ad.SaleEnd = items[0].ValidTo
+	result.Ad.SourceFlyerID = items[0].FlyerID
 	var adIngredientMap = make(map[string]models.AdIngredient)
-	for _, item := range data.Items {
-		ingredient_id, err := translationModel.GetTranslationByName(item.Name)
+	for _, item := range items {
+		ingredient_id, err := translationModel.GetTranslationByName(ctx, item.Name)
 		if err != nil {
 			config.Logger.Error("Failed to get translation by name", zap.Error(err))
 			return RetrieveTranslationsResult{}, err
@@ -61,149 +41,195 @@ func RetrieveTranslations(store_id uint, data RequestData) (result RetrieveTrans
 			result.UntranslatedIngredients = append(result.UntranslatedIngredients, item)
 			continue
 		}
-		adIngredientMap[item.Name] = models.AdIngredient{
-			IngredientID: uint(ingredient_id),
-			Price:        item.CurrentPrice,
-			Sale:		 item.PostPriceText,
-			Name:		 item.Name,
-		}
+		adIngredientMap[item.Name] = newAdIngredient(uint(ingredient_id), item)
 	}
 	for _, value := range adIngredientMap {
-		result.Ad.Ingredient = append(result.Ad.Ingredient, models.AdIngredient{
-			IngredientID: value.IngredientID,
-			Price:        value.Price,
-			Sale:		 value.Sale,
-			Name:		 value.Name,
-		})
+		result.Ad.Ingredient = append(result.Ad.Ingredient, value)
 	}
 	return result, nil
 }
 
-func GetIngredientNamesAndIds() (ingredientMap map[string]uint, err error) {
-	ingredientModel := models.NewIngredientModel(config.PostgreSQL, *config.Logger)
-	ingredientMap, err = ingredientModel.GetAllIngredientsNameID()
+// newAdIngredient maps an ad item onto an ad ingredient, parsing its price text into a deal
+func newAdIngredient(ingredientID uint, item AdItem) models.AdIngredient {
+	return models.AdIngredient{
+		IngredientID:  ingredientID,
+		Price:         item.CurrentPrice,
+		OriginalPrice: item.OriginalPrice,
+		Sale:          item.PostPriceText,
+		Name:          item.Name,
+		Deal:          deals.Parse(item.PostPriceText, item.CurrentPrice),
+	}
+}
+
+// GetIngredientNamesAndIds maps every ingredient name, and every alias not shadowing a
+// name, onto its ingredient ID
+func GetIngredientNamesAndIds(ctx context.Context) (ingredientMap map[string]uint, err error) {
+	ingredientModel := models.NewIngredientModel(database(), *config.Logger)
+	ingredientMap, err = ingredientModel.GetAllIngredientsNameID(ctx)
 	if err != nil {
 		return nil, err
 	}
+	aliases, err := ingredientModel.GetAllAliases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for alias, id := range aliases {
+		if _, ok := ingredientMap[alias]; !ok {
+			ingredientMap[alias] = id
+		}
+	}
 	return ingredientMap, nil
 }
 
-func AddTranslations(ctx context.Context, untranslatedIngredients []ItemData, ingredientMap map[string]uint) (adIngredients []models.AdIngredient,err error) {
-	geminiModel := config.GeminiModel
-	translationModel := models.NewTranslationModel(config.PostgreSQL, *config.Logger)
-	ingredientModel := models.NewIngredientModel(config.PostgreSQL, *config.Logger)
-	var IngredientsString string = ""
-	for _, item := range untranslatedIngredients {
-		IngredientsString += item.Name + ", "
+// DB is the database the activities run against. The worker sets it to its connection
+// pool at startup; when nil, the connection from config is used.
+var DB models.DB
+
+func database() models.DB {
+	if DB == nil {
+		return config.PostgreSQL
 	}
-	fmt.Println(geminiModel)
-	resp, err := geminiModel.GenerateContent(context.Background(), 
-	genai.Text("Given the following names of grocery ingredients, return a simple ingredient name. The ingredient name has to be a food. For example: Fresh Green Bell Pepper -> bell peppers. Fresh Antibiotic Free Family Pack Thin Sliced Chicken Breast -> chicken breast. The output should be only a map with the key being the original input ingredient name and the value being the output simple ingredient name. For example: {\"Fresh Green Bell Pepper\": \"bell peppers\", \"Fresh Antibiotic Free Family Pack Thin Sliced Chicken Breast\": \"chicken breast\"}. Do not return anything else except a json. If the item name is two or more items (ex: Green Peppers and Cucumbers, Salmon and Ocean Perch, etc.) generalize the food (vegetables, fish, etc.) If the item is a fruit or vegetable, make sure the returned ingredient is plural. For example fresh avocados -> avocados"), genai.Text(IngredientsString))
-	if err != nil {
-		return nil, err
+	return DB
+}
+
+// Classifier simplifies and classifies ingredient names for the translation activities.
+// The worker sets it at startup; when nil, the Gemini model from config is used.
+var Classifier classifier.IngredientClassifier
+
+func ingredientClassifier() classifier.IngredientClassifier {
+	if Classifier == nil {
+		return classifier.NewGemini(config.GeminiModel)
 	}
-	rawResponse := config.PrintResponse(resp)
-	cleanedJSON := strings.TrimPrefix(rawResponse, "```json\n")
-	cleanedJSON = strings.ReplaceAll(cleanedJSON, "`", "")
+	return Classifier
+}
 
-	var foodMap map[string]string
+// AddTranslations resolves untranslated ad items onto ingredients. Names the normalizer
+// can match against existing ingredients skip the classifier; only the leftovers are sent to it.
+// Concurrent AdProcess runs may add the same ingredients and translations, so both are only
+// added when missing and the stored IDs win; only the run that adds a row queues its review.
+func AddTranslations(ctx context.Context, untranslatedIngredients []AdItem, ingredientMap map[string]uint) (adIngredients []models.AdIngredient,err error) {
+	translationModel := models.NewTranslationModel(database(), *config.Logger)
 	var translationMap = make(map[string]uint)
 	var adIngredientMap = make(map[string]models.AdIngredient)
-	err = json.Unmarshal([]byte(cleanedJSON), &foodMap)
-	if err != nil {
-		fmt.Println("Error decoding translations gemini JSON:", err)
-		fmt.Println(cleanedJSON)
-		return
-	}
 
+	normalizer := normalize.New(ingredientMap)
+	var stats normalize.Stats
+	var leftovers []AdItem
+	var reviewItems []models.ReviewItem
+	translationReviews := make(map[string]models.ReviewItem)
 	for _, item := range untranslatedIngredients {
-		var ingredientID uint
-		simpleIngredientName := foodMap[item.Name]
-		// if item.Name is not in ingredientMap
-		if _, ok := ingredientMap[simpleIngredientName]; !ok {
-			// add ingredient and get new ingredient id 
-			newIngredient, err := CreateNewIngredientByName(simpleIngredientName)
-			if err != nil {
-				return nil, err
-			}
-			ingredientID, err = ingredientModel.CreateIngredient(*newIngredient)
-			if err != nil {
-				return nil, err
-			}
-			ingredientMap[simpleIngredientName] = ingredientID
-		} else {
-			ingredientID = ingredientMap[simpleIngredientName]
+		match := normalizer.Match(item.Name)
+		stats.Add(match)
+		if match.Kind == normalize.NoMatch {
+			leftovers = append(leftovers, item)
+			continue
+		}
+		adIngredientMap[item.Name] = newAdIngredient(match.IngredientID, item)
+		translationMap[item.Name] = match.IngredientID
+	}
+	config.Logger.Info("Normalized ad item names",
+		zap.Int("total", stats.Total),
+		zap.Int("exact", stats.Exact),
+		zap.Int("fuzzy", stats.Fuzzy),
+		zap.Int("llm", stats.LLM),
+		zap.Float64("hit_rate", stats.HitRate()))
+
+	if len(leftovers) > 0 {
+		var names []string
+		for _, item := range leftovers {
+			names = append(names, item.Name)
+		}
+		simplified, err := ingredientClassifier().Simplify(ctx, names)
+		if err != nil {
+			config.Logger.Error("Failed to simplify ingredient names", zap.Error(err))
+			return nil, err
 		}
-		adIngredientMap[item.Name] = models.AdIngredient{
-			IngredientID: ingredientID,
-			Price:        item.CurrentPrice,
-			Sale:		 item.PostPriceText,
-			Name:		 item.Name,
+		ingredientModel := models.NewIngredientModel(database(), *config.Logger)
+		for _, item := range leftovers {
+			var ingredientID uint
+			suggestion := simplified.Names[item.Name]
+			simpleIngredientName := suggestion.Name
+			// if item.Name is not in ingredientMap
+			if _, ok := ingredientMap[simpleIngredientName]; !ok {
+				// add ingredient and get new ingredient id 
+				newIngredient, classification, err := classifyIngredient(ctx, simpleIngredientName)
+				if err != nil {
+					return nil, err
+				}
+				var created bool
+				ingredientID, created, err = ingredientModel.CreateIngredientIfMissing(ctx, *newIngredient)
+				if err != nil {
+					return nil, err
+				}
+				ingredientMap[simpleIngredientName] = ingredientID
+				if created {
+					reviewItems = append(reviewItems, *models.NewReviewItem(models.ReviewIngredient, simpleIngredientName,
+						ingredientID, classification.Confidence, classification.Prompt, classification.Response))
+				}
+			} else {
+				ingredientID = ingredientMap[simpleIngredientName]
+			}
+			adIngredientMap[item.Name] = newAdIngredient(ingredientID, item)
+			translationMap[item.Name] = ingredientID
+			translationReviews[item.Name] = *models.NewReviewItem(models.ReviewTranslation, item.Name,
+				ingredientID, suggestion.Confidence, simplified.Prompt, simplified.Response)
 		}
-		translationMap[item.Name] = ingredientID
 	}
 	var translations []models.Translation
 	for key, value := range translationMap {
 		translations = append(translations, *models.NewTranslation(key, value))
 	}
-	for _, value := range adIngredientMap {
-	adIngredients = append(adIngredients, models.AdIngredient{
-		IngredientID: value.IngredientID,
-		Price:        value.Price,
-		Sale:		 value.Sale,
-		Name:		 value.Name,
-	})
-	}
 	// add translations to database
-	err = translationModel.CreateTranslations(translations)
+	stored, added, err := translationModel.CreateTranslations(ctx, translations)
 	if err != nil {
 		return nil, err
 	}
+	for name, value := range adIngredientMap {
+		if ingredientID, ok := stored[name]; ok {
+			value.IngredientID = ingredientID
+		}
+		adIngredients = append(adIngredients, value)
+	}
+	for name, review := range translationReviews {
+		if added[name] {
+			reviewItems = append(reviewItems, review)
+		}
+	}
+	// queue everything the classifier created for human review
+	if len(reviewItems) > 0 {
+		err = models.NewReviewModel(database(), *config.Logger).CreateReviewItems(ctx, reviewItems)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return adIngredients, nil
 }
 
-func CreateNewIngredientByName(name string) (ingredient *models.Ingredient, err error) {
-	ingredientModel := models.NewIngredientModel(config.PostgreSQL, *config.Logger)
-	geminiModel := config.GeminiModel
-	var foodTypesString = ""
-	for _, foodType := range ingredientModel.GetFoodTypes() {
-		foodTypesString += foodType + ", "
-	}
-	queryString := "Given the following name of an ingredient, return only a json for the type: choose out of " + 
-	foodTypesString + ", " + " and if the type is fruit or vegetable, provide a season as an array of ints." +
-	"This means if let's say brussel sprouts -> Vegetable for type -> [9, 10 , 11] for season."
-	resp, err := geminiModel.GenerateContent(context.Background(), 
-	
-	genai.Text(queryString), genai.Text(name))
-	if err != nil {
-		return nil, err
-	}
-	rawResponse := config.PrintResponse(resp)
-	cleanedJSON := strings.TrimPrefix(rawResponse, "```json\n")
-	cleanedJSON = strings.ReplaceAll(cleanedJSON, "`", "")
+func CreateNewIngredientByName(ctx context.Context, name string) (ingredient *models.Ingredient, err error) {
+	ingredient, _, err = classifyIngredient(ctx, name)
+	return ingredient, err
+}
 
-	var ingredientRaw RawGeneratedIngredientData
-	err = json.Unmarshal([]byte(cleanedJSON), &ingredientRaw)
+// classifyIngredient builds a new ingredient from the classifier's answer, which is
+// returned as well so that it can be queued for review
+func classifyIngredient(ctx context.Context, name string) (*models.Ingredient, classifier.Classification, error) {
+	classification, err := ingredientClassifier().Classify(ctx, name)
 	if err != nil {
-		fmt.Println("Error decoding ingredient gemini JSON:", err)
-		fmt.Println(cleanedJSON)
-		return nil, err
-	}
-	if ingredientRaw.Type == "" {
-		ingredientRaw.Type = "Other"
+		config.Logger.Error("Failed to classify ingredient", zap.Error(err), zap.String("ingredient", name))
+		return nil, classification, err
 	}
-	ingredient = &models.Ingredient{
+	ingredient := &models.Ingredient{
 		Name: name,
-		Type: ingredientModel.ToFoodType(ingredientRaw.Type),
-		Season: ingredientRaw.Season,
+		Type: classification.Type,
+		Season: classification.Season,
 	}
-	return ingredient, nil
+	return ingredient, classification, nil
 }
 
-func CreateAd(ad models.Ad) (err error) {
-	adModel := models.NewAdModel(config.PostgreSQL, *config.Logger)
-	err = adModel.CreateAd(ad)
+func CreateAd(ctx context.Context, ad models.Ad) (err error) {
+	adModel := models.NewAdModel(database(), *config.Logger)
+	err = adModel.CreateAd(ctx, ad)
 	if err != nil {
 		adModel.Logger.Error("Failed to create ad", zap.Error(err))
 		return err
@@ -211,14 +237,29 @@ func CreateAd(ad models.Ad) (err error) {
 	return nil
 }
 
-func GetExpiredAdStores() (stores []AdProcessInput, err error) {
-	storeModel := models.NewStoreModel(config.PostgreSQL, *config.Logger)
-	rawStores, err := storeModel.GetExpiredAdStores()
+// GetExpiredAdStores returns an AdProcess input for every store due for new ads,
+// including stores that have never had one
+func GetExpiredAdStores(ctx context.Context) (stores []AdProcessInput, err error) {
+	return expiredAdStores(ctx, models.NewStoreModel(database(), *config.Logger))
+}
+
+func expiredAdStores(ctx context.Context, storeModel models.StoreRepository) (stores []AdProcessInput, err error) {
+	rawStores, err := storeModel.GetExpiredAdStores(ctx)
+	if err != nil {
+		return nil, err
+	}
 	for _, v := range rawStores {
-		var store AdProcessInput;
-		store.StoreFlippName = v.FlippMerchantName
-		store.ZipCode = v.Location[len(v.Location)-5:]
+		var store AdProcessInput
 		store.StoreID = int(*v.ID)
+		store.Source = AdSourceConfig{
+			Type:         v.AdSource,
+			MerchantName: v.FlippMerchantName,
+			ZipCode:      v.PostalCode(),
+		}
+		if v.AdSourcePath != nil {
+			store.Source.Path = *v.AdSourcePath
+		}
+		stores = append(stores, store)
 	}
 	return stores, nil
 }
\ No newline at end of file
@@ -0,0 +1,84 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	FlippAdSource = "flipp"
+	FileAdSource  = "file"
+)
+
+// AdItem is a source-neutral sale item with the window the sale is valid for
+type AdItem struct {
+	Name          string   `json:"name"`
+	CurrentPrice  *float32 `json:"current_price"`
+	OriginalPrice *float32 `json:"original_price"`
+	PostPriceText *string  `json:"post_price_text"`
+	ValidFrom     string   `json:"valid_from"`
+	ValidTo       string   `json:"valid_to"`
+	// FlyerID identifies the flyer the item is from; items of one flyer share it
+	FlyerID string `json:"flyer_id"`
+}
+
+// GroupFlyers splits items into one group per flyer and validity window, in the order
+// each flyer first appears. Sources list the items of several overlapping flyers
+// together, e.g. a weekly, a monthly and a pharmacy flyer.
+func GroupFlyers(items []AdItem) [][]AdItem {
+	type flyerKey struct {
+		flyerID, validFrom, validTo string
+	}
+	var flyers [][]AdItem
+	index := make(map[flyerKey]int)
+	for _, item := range items {
+		key := flyerKey{item.FlyerID, item.ValidFrom, item.ValidTo}
+		n, ok := index[key]
+		if !ok {
+			n = len(flyers)
+			index[key] = n
+			flyers = append(flyers, nil)
+		}
+		flyers[n] = append(flyers[n], item)
+	}
+	return flyers
+}
+
+// AdSource fetches the current sale items for one store
+type AdSource interface {
+	FetchItems(ctx context.Context) ([]AdItem, error)
+}
+
+// AdSourceConfig is a store's ad source configuration. Type selects the implementation;
+// Flipp uses ZipCode, MerchantName and optionally BaseURL, the file source uses Path.
+type AdSourceConfig struct {
+	Type         string
+	ZipCode      string
+	MerchantName string
+	Path         string
+	// BaseURL overrides DefaultFlippBaseURL, e.g. to point at a flipptest server
+	BaseURL string
+}
+
+// NewAdSource builds the AdSource a store is configured for. An empty type means Flipp.
+func NewAdSource(config AdSourceConfig) (AdSource, error) {
+	switch config.Type {
+	case "", FlippAdSource:
+		return NewFlippSource(config.ZipCode, config.MerchantName, NewFlippClient(config.BaseURL, nil)), nil
+	case FileAdSource:
+		if config.Path == "" {
+			return nil, fmt.Errorf("file ad source requires a path")
+		}
+		return NewFileSource(config.Path), nil
+	}
+	return nil, fmt.Errorf("unknown ad source %q", config.Type)
+}
+
+// FetchAdItems retrieves the current sale items from a store's configured ad source
+func FetchAdItems(ctx context.Context, config AdSourceConfig) ([]AdItem, error) {
+	source, err := NewAdSource(config)
+	if err != nil {
+		return nil, err
+	}
+	return source.FetchItems(ctx)
+}
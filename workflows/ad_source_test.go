@@ -0,0 +1,33 @@
+package workflows
+
+import (
+	"testing"
+)
+
+func TestGroupFlyers(t *testing.T) {
+	items := []AdItem{
+		{Name: "Gala Apples", FlyerID: "1", ValidFrom: "2025-06-04", ValidTo: "2025-06-10"},
+		{Name: "Whole Milk", FlyerID: "2", ValidFrom: "2025-06-01", ValidTo: "2025-06-30"},
+		{Name: "Bananas", FlyerID: "1", ValidFrom: "2025-06-04", ValidTo: "2025-06-10"},
+		// same flyer, but a different validity window
+		{Name: "Ice Cream", FlyerID: "1", ValidFrom: "2025-06-06", ValidTo: "2025-06-08"},
+	}
+	flyers := GroupFlyers(items)
+	want := [][]string{{"Gala Apples", "Bananas"}, {"Whole Milk"}, {"Ice Cream"}}
+	if len(flyers) != len(want) {
+		t.Fatalf("GroupFlyers() = %d flyers, want %d", len(flyers), len(want))
+	}
+	for n, flyer := range flyers {
+		if len(flyer) != len(want[n]) {
+			t.Fatalf("flyer %d = %+v, want %v", n, flyer, want[n])
+		}
+		for i, item := range flyer {
+			if item.Name != want[n][i] {
+				t.Errorf("flyer %d item %d = %q, want %q", n, i, item.Name, want[n][i])
+			}
+		}
+	}
+	if GroupFlyers(nil) != nil {
+		t.Error("GroupFlyers(nil) is not empty")
+	}
+}
@@ -0,0 +1,167 @@
+package workflows
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// FileSource reads a weekly circular dropped on disk as CSV or JSON. When Path is a
+// directory, the most recently modified .csv or .json file in it is used.
+//
+// JSON files hold an array of AdItem objects, or {"items": [...]}. CSV files have a
+// header row naming AdItem's JSON fields, e.g.
+//
+//	name,current_price,original_price,post_price_text,valid_from,valid_to,flyer_id
+//
+// flyer_id is optional; an ad without one is identified by its store and sale window.
+type FileSource struct {
+	Path string
+}
+
+func NewFileSource(path string) *FileSource {
+	return &FileSource{Path: path}
+}
+
+// FetchItems parses the circular file
+func (f *FileSource) FetchItems(ctx context.Context) ([]AdItem, error) {
+	path, err := f.resolvePath()
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return parseJSONCircular(file)
+	case ".csv":
+		return parseCSVCircular(file)
+	}
+	return nil, fmt.Errorf("unsupported circular file %s: expected .csv or .json", path)
+}
+
+func (f *FileSource) resolvePath() (string, error) {
+	info, err := os.Stat(f.Path)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return f.Path, nil
+	}
+	entries, err := os.ReadDir(f.Path)
+	if err != nil {
+		return "", err
+	}
+	var latest string
+	var latestInfo os.FileInfo
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".csv" && ext != ".json") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return "", err
+		}
+		if latestInfo == nil || info.ModTime().After(latestInfo.ModTime()) {
+			latest, latestInfo = filepath.Join(f.Path, entry.Name()), info
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("no .csv or .json circular in %s", f.Path)
+	}
+	return latest, nil
+}
+
+func parseJSONCircular(r io.Reader) ([]AdItem, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var items []AdItem
+	if err := json.Unmarshal(body, &items); err == nil {
+		return items, nil
+	}
+	var wrapped struct {
+		Items []AdItem `json:"items"`
+	}
+	if err := json.Unmarshal(body, &wrapped); err != nil {
+		return nil, err
+	}
+	return wrapped.Items, nil
+}
+
+func parseCSVCircular(r io.Reader) ([]AdItem, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for n, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = n
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, fmt.Errorf("circular CSV is missing a name column")
+	}
+	field := func(record []string, name string) string {
+		if n, ok := columns[name]; ok && n < len(record) {
+			return strings.TrimSpace(record[n])
+		}
+		return ""
+	}
+
+	var items []AdItem
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		item := AdItem{
+			Name:      field(record, "name"),
+			ValidFrom: field(record, "valid_from"),
+			ValidTo:   field(record, "valid_to"),
+			FlyerID:   field(record, "flyer_id"),
+		}
+		if item.Name == "" {
+			continue
+		}
+		if item.CurrentPrice, err = parseOptionalPrice(field(record, "current_price")); err != nil {
+			return nil, fmt.Errorf("line %d: current_price: %w", line, err)
+		}
+		if item.OriginalPrice, err = parseOptionalPrice(field(record, "original_price")); err != nil {
+			return nil, fmt.Errorf("line %d: original_price: %w", line, err)
+		}
+		if text := field(record, "post_price_text"); text != "" {
+			item.PostPriceText = &text
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func parseOptionalPrice(s string) (*float32, error) {
+	s = strings.TrimPrefix(s, "$")
+	if s == "" {
+		return nil, nil
+	}
+	price, err := strconv.ParseFloat(s, 32)
+	if err != nil {
+		return nil, err
+	}
+	p := float32(price)
+	return &p, nil
+}
@@ -0,0 +1,105 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// describeItem formats an AdItem with its optional fields dereferenced
+func describeItem(item AdItem) string {
+	price := func(p *float32) string {
+		if p == nil {
+			return "-"
+		}
+		return fmt.Sprintf("%.2f", *p)
+	}
+	text := "-"
+	if item.PostPriceText != nil {
+		text = *item.PostPriceText
+	}
+	return fmt.Sprintf("%s|%s|%s|%s|%s..%s|%s", item.Name, price(item.CurrentPrice), price(item.OriginalPrice),
+		text, item.ValidFrom, item.ValidTo, item.FlyerID)
+}
+
+func TestFileSourceFetchItems(t *testing.T) {
+	apples := "Gala Apples|1.29|1.99|/lb|2025-06-04..2025-06-10|6721843"
+	milk := "Whole Milk, 1 Gallon|3.49|-|-|2025-06-04..2025-06-10|6721843"
+	cheddar := "Sharp Cheddar|-|-|2 for $5|2025-06-01..2025-06-30|"
+	tests := []struct {
+		name    string
+		file    string
+		want    []string
+		wantErr string
+	}{
+		{"csv", "weekly.csv", []string{apples, milk, cheddar}, ""},
+		{"json array", "weekly.json", []string{apples, milk}, ""},
+		{"json items object", "wrapped.json", []string{cheddar}, ""},
+		{"bad price", "bad_price.csv", nil, "line 3: current_price"},
+		{"no name column", "no_name.csv", nil, "missing a name column"},
+		{"malformed json", "malformed.json", nil, "unexpected end of JSON input"},
+		{"unsupported extension", "weekly.txt", nil, "expected .csv or .json"},
+		{"missing file", "missing.csv", nil, "no such file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := FetchAdItems(context.Background(), AdSourceConfig{
+				Type: FileAdSource,
+				Path: filepath.Join("testdata", "circulars", tt.file),
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("FetchAdItems() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FetchAdItems() error = %v", err)
+			}
+			var got []string
+			for _, item := range items {
+				got = append(got, describeItem(item))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("FetchAdItems() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestFileSourceDirectory(t *testing.T) {
+	dir := t.TempDir()
+	source := NewFileSource(dir)
+	if _, err := source.FetchItems(context.Background()); err == nil || !strings.Contains(err.Error(), "no .csv or .json circular") {
+		t.Fatalf("FetchItems() on an empty directory error = %v", err)
+	}
+
+	// the most recently modified circular is used and other files are ignored
+	week := time.Date(2025, time.June, 4, 0, 0, 0, 0, time.UTC)
+	for n, name := range []string{"weekly.json", "wrapped.json", "weekly.txt"} {
+		body, err := os.ReadFile(filepath.Join("testdata", "circulars", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, body, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		modified := week.AddDate(0, 0, 7*n)
+		if err := os.Chtimes(path, modified, modified); err != nil {
+			t.Fatal(err)
+		}
+	}
+	items, err := source.FetchItems(context.Background())
+	if err != nil {
+		t.Fatalf("FetchItems() error = %v", err)
+	}
+	if len(items) != 1 || items[0].Name != "Sharp Cheddar" {
+		t.Errorf("FetchItems() = %+v, want the items of wrapped.json", items)
+	}
+}
@@ -0,0 +1,151 @@
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultFlippBaseURL = "https://backflipp.wishabi.com"
+	flippSearchPath     = "/flipp/items/search"
+	flippTimeout        = 30 * time.Second
+	flippUserAgent      = "grocery-app-backend/1.0"
+	// flippErrorBodyLimit caps how much of an error response is kept on FlippStatusError
+	flippErrorBodyLimit = 512
+)
+
+// FlippStatusError is returned when Flipp answers with a non-200 status
+type FlippStatusError struct {
+	StatusCode int
+	URL        string
+	Body       string
+}
+
+func (e *FlippStatusError) Error() string {
+	return fmt.Sprintf("flipp: %s returned status %d: %s", e.URL, e.StatusCode, e.Body)
+}
+
+// FlippDecodeError is returned when a 200 response is not valid search JSON
+type FlippDecodeError struct {
+	URL string
+	Err error
+}
+
+func (e *FlippDecodeError) Error() string {
+	return fmt.Sprintf("flipp: decoding %s: %v", e.URL, e.Err)
+}
+
+func (e *FlippDecodeError) Unwrap() error {
+	return e.Err
+}
+
+// FlippClient calls the Flipp item search API
+type FlippClient struct {
+	BaseURL   string
+	HTTP      HTTPGetter
+	UserAgent string
+}
+
+// NewFlippClient returns a client for baseURL, or DefaultFlippBaseURL when empty. A nil
+// httpGetter uses an *http.Client with a 30 second timeout.
+func NewFlippClient(baseURL string, httpGetter HTTPGetter) *FlippClient {
+	if baseURL == "" {
+		baseURL = DefaultFlippBaseURL
+	}
+	if httpGetter == nil {
+		httpGetter = &http.Client{Timeout: flippTimeout}
+	}
+	return &FlippClient{
+		BaseURL:   strings.TrimRight(baseURL, "/"),
+		HTTP:      httpGetter,
+		UserAgent: flippUserAgent,
+	}
+}
+
+// SearchItems returns every item Flipp lists for a merchant near a postal code
+func (c *FlippClient) SearchItems(ctx context.Context, postalCode string, merchant string) (RequestData, error) {
+	query := url.Values{}
+	query.Set("locale", "en")
+	query.Set("postal_code", postalCode)
+	query.Set("q", merchant)
+	searchURL := c.BaseURL + flippSearchPath + "?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, searchURL, nil)
+	if err != nil {
+		return RequestData{}, err
+	}
+	req.Header.Set("User-Agent", c.UserAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return RequestData{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, flippErrorBodyLimit))
+		return RequestData{}, &FlippStatusError{StatusCode: resp.StatusCode, URL: searchURL, Body: string(body)}
+	}
+	var requestData RequestData
+	if err := json.NewDecoder(resp.Body).Decode(&requestData); err != nil {
+		return RequestData{}, &FlippDecodeError{URL: searchURL, Err: err}
+	}
+	return requestData, nil
+}
+
+// FlippSource reads a merchant's current items from the Flipp search API
+type FlippSource struct {
+	ZipCode      string
+	MerchantName string
+	Client       *FlippClient
+}
+
+func NewFlippSource(zipCode string, merchantName string, client *FlippClient) *FlippSource {
+	if client == nil {
+		client = NewFlippClient("", nil)
+	}
+	return &FlippSource{
+		ZipCode:      zipCode,
+		MerchantName: merchantName,
+		Client:       client,
+	}
+}
+
+// FetchItems returns the merchant's food items near the source's postal code
+func (f *FlippSource) FetchItems(ctx context.Context) ([]AdItem, error) {
+	requestData, err := f.Client.SearchItems(ctx, f.ZipCode, f.MerchantName)
+	if err != nil {
+		return nil, err
+	}
+	var items []AdItem
+	for _, value := range requestData.Items {
+		if value.L2 == "Food Items" {
+			items = append(items, value.AdItem())
+		}
+	}
+	return items, nil
+}
+
+// AdItem converts a Flipp item to the source-neutral form
+func (i ItemData) AdItem() AdItem {
+	var flyerID string
+	if i.FlyerID != 0 {
+		flyerID = strconv.FormatInt(i.FlyerID, 10)
+	}
+	return AdItem{
+		Name:          i.Name,
+		CurrentPrice:  i.CurrentPrice,
+		OriginalPrice: i.OriginalPrice,
+		PostPriceText: i.PostPriceText,
+		ValidFrom:     i.ValidFrom,
+		ValidTo:       i.ValidTo,
+		FlyerID:       flyerID,
+	}
+}
@@ -0,0 +1,155 @@
+package workflows
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"backend/main/workflows/flipptest"
+)
+
+func TestFetchAdItemsFromFakeFlipp(t *testing.T) {
+	server := flipptest.NewServer()
+	defer server.Close()
+
+	items, err := FetchAdItems(context.Background(), AdSourceConfig{
+		Type:         FlippAdSource,
+		ZipCode:      "94105",
+		MerchantName: "Safeway",
+		BaseURL:      server.URL,
+	})
+	if err != nil {
+		t.Fatalf("FetchAdItems: %v", err)
+	}
+	if len(items) != 5 {
+		t.Fatalf("got %d items, want the 5 food items", len(items))
+	}
+	for _, item := range items {
+		if strings.Contains(item.Name, "Detergent") {
+			t.Errorf("non-food item %q was not filtered out", item.Name)
+		}
+	}
+	first := items[0]
+	if first.Name != "Fresh Boneless Skinless Chicken Breast Family Pack" || first.CurrentPrice == nil || *first.CurrentPrice != 2.99 ||
+		first.OriginalPrice == nil || *first.OriginalPrice != 5.49 || first.PostPriceText == nil || *first.PostPriceText != "/lb with card" {
+		t.Errorf("unexpected first item %+v", first)
+	}
+	if first.ValidFrom != "2025-06-04T00:00:00-07:00" || first.ValidTo != "2025-06-10T23:59:59-07:00" {
+		t.Errorf("unexpected validity window %s - %s", first.ValidFrom, first.ValidTo)
+	}
+	if first.FlyerID != "6721843" {
+		t.Errorf("FlyerID = %q, want 6721843", first.FlyerID)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(requests))
+	}
+	if requests[0].PostalCode != "94105" || requests[0].Merchant != "Safeway" || requests[0].Locale != "en" {
+		t.Errorf("unexpected request %+v", requests[0])
+	}
+	if requests[0].UserAgent != flippUserAgent {
+		t.Errorf("User-Agent = %q, want %q", requests[0].UserAgent, flippUserAgent)
+	}
+}
+
+func TestFlippClientEscapesQuery(t *testing.T) {
+	server := flipptest.NewServer()
+	defer server.Close()
+
+	client := NewFlippClient(server.URL, nil)
+	data, err := client.SearchItems(context.Background(), "M5V 2T6", "Trader Joe's & Co")
+	if err != nil {
+		t.Fatalf("SearchItems: %v", err)
+	}
+	if len(data.Items) != 0 {
+		t.Errorf("got %d items for a merchant without a fixture, want 0", len(data.Items))
+	}
+	requests := server.Requests()
+	if len(requests) != 1 || requests[0].PostalCode != "M5V 2T6" || requests[0].Merchant != "Trader Joe's & Co" {
+		t.Errorf("query was not escaped, server saw %+v", requests)
+	}
+}
+
+func TestFlippClientErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		check  func(t *testing.T, err error)
+	}{
+		{
+			name:   "non-200 status",
+			status: http.StatusServiceUnavailable,
+			body:   "upstream down",
+			check: func(t *testing.T, err error) {
+				var statusErr *FlippStatusError
+				if !errors.As(err, &statusErr) {
+					t.Fatalf("error %v is not a *FlippStatusError", err)
+				}
+				if statusErr.StatusCode != http.StatusServiceUnavailable || statusErr.Body != "upstream down" {
+					t.Errorf("unexpected status error %+v", statusErr)
+				}
+			},
+		},
+		{
+			name:   "malformed JSON",
+			status: http.StatusOK,
+			body:   `{"items": [`,
+			check: func(t *testing.T, err error) {
+				var decodeErr *FlippDecodeError
+				if !errors.As(err, &decodeErr) {
+					t.Fatalf("error %v is not a *FlippDecodeError", err)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := flipptest.NewServer()
+			defer server.Close()
+			server.RespondWith(tt.status, tt.body)
+
+			_, err := NewFlippSource("94105", "Safeway", NewFlippClient(server.URL, nil)).FetchItems(context.Background())
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			tt.check(t, err)
+		})
+	}
+}
+
+type recordingGetter struct {
+	req *http.Request
+}
+
+func (g *recordingGetter) Do(req *http.Request) (*http.Response, error) {
+	g.req = req
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(string(flipptest.Fixture("Trader Joe's")))),
+	}, nil
+}
+
+func TestFlippClientUsesInjectedGetter(t *testing.T) {
+	getter := &recordingGetter{}
+	items, err := NewFlippSource("94105", "Trader Joe's", NewFlippClient("http://flipp.invalid/", getter)).FetchItems(context.Background())
+	if err != nil {
+		t.Fatalf("FetchItems: %v", err)
+	}
+	if len(items) != 2 {
+		t.Errorf("got %d items, want 2", len(items))
+	}
+	if getter.req == nil {
+		t.Fatal("injected getter was not used")
+	}
+	if got := getter.req.URL.String(); got != "http://flipp.invalid/flipp/items/search?locale=en&postal_code=94105&q=Trader+Joe%27s" {
+		t.Errorf("unexpected URL %s", got)
+	}
+	if getter.req.Header.Get("User-Agent") != flippUserAgent {
+		t.Errorf("User-Agent header not set")
+	}
+}
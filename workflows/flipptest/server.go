@@ -0,0 +1,118 @@
+// Package flipptest serves fixture Flipp search responses from an httptest server so
+// the ad ingest path can run without network access.
+package flipptest
+
+import (
+	"embed"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// emptyResponse is returned for merchants without a fixture
+const emptyResponse = `{"items": []}`
+
+// Request is a search request the server received
+type Request struct {
+	PostalCode string
+	Merchant   string
+	Locale     string
+	UserAgent  string
+}
+
+// Server is a fake backflipp.wishabi.com. Searches for a merchant are answered with
+// fixtures/<merchant>.json, where the merchant is lowercased and every run of
+// non-alphanumeric characters becomes "_" ("Trader Joe's" -> trader_joe_s.json).
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []Request
+	status   int
+	body     string
+}
+
+// NewServer starts a fake Flipp server; callers must Close it
+func NewServer() *Server {
+	s := &Server{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/flipp/items/search", s.search)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// RespondWith makes every following search return status and body instead of a fixture
+func (s *Server) RespondWith(status int, body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status, s.body = status, body
+}
+
+// Requests returns the searches received so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Fixture returns the fixture for a merchant, or an empty search response
+func Fixture(merchant string) []byte {
+	body, err := fixtures.ReadFile("fixtures/" + FixtureName(merchant) + ".json")
+	if err != nil {
+		return []byte(emptyResponse)
+	}
+	return body
+}
+
+// FixtureName maps a merchant name to its fixture file name without extension
+func FixtureName(merchant string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(strings.TrimSpace(merchant)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			underscore = false
+			continue
+		}
+		if !underscore {
+			b.WriteRune('_')
+			underscore = true
+		}
+	}
+	return strings.Trim(b.String(), "_")
+}
+
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := Request{
+		PostalCode: query.Get("postal_code"),
+		Merchant:   query.Get("q"),
+		Locale:     query.Get("locale"),
+		UserAgent:  r.UserAgent(),
+	}
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	status, body := s.status, s.body
+	s.mu.Unlock()
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if status != 0 {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+		return
+	}
+	if req.PostalCode == "" || req.Merchant == "" {
+		http.Error(w, `{"error": "postal_code and q are required"}`, http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(Fixture(req.Merchant))
+}
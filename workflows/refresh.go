@@ -0,0 +1,125 @@
+package workflows
+
+import (
+	"fmt"
+	"time"
+
+	"backend/main/config"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"go.uber.org/zap"
+)
+
+// DefaultMaxConcurrentAdProcesses is how many AdProcess children RefreshExpiredAds runs
+// at once when its input does not say
+const DefaultMaxConcurrentAdProcesses = 5
+
+// Outcomes of a store's AdProcess child
+const (
+	OutcomeSucceeded = "succeeded"
+	OutcomeFailed    = "failed"
+	// OutcomeSkipped means the store's ad was already refreshed that day
+	OutcomeSkipped = "skipped"
+)
+
+type RefreshExpiredAdsInput struct {
+	MaxConcurrent int
+}
+
+// StoreOutcome is how refreshing one store's ad went
+type StoreOutcome struct {
+	StoreID    int
+	WorkflowID string
+	Outcome    string
+	Error      string `json:",omitempty"`
+}
+
+type RefreshExpiredAdsResult struct {
+	Stores []StoreOutcome
+}
+
+// Count returns how many stores ended with outcome
+func (r RefreshExpiredAdsResult) Count(outcome string) int {
+	count := 0
+	for _, store := range r.Stores {
+		if store.Outcome == outcome {
+			count++
+		}
+	}
+	return count
+}
+
+// AdProcessWorkflowID is the ID of a store's AdProcess child for the day the refresh runs,
+// so a manually triggered refresh does not process a store the daily schedule already
+// has, while a store whose flyer expires again later in the week is processed again
+func AdProcessWorkflowID(storeID int, day time.Time) string {
+	return fmt.Sprintf("ad-process-store-%d-%s", storeID, day.Format(time.DateOnly))
+}
+
+// RefreshExpiredAds starts an AdProcess child for every store whose ad has expired,
+// at most MaxConcurrent at a time, and reports how each store went. A failed child does
+// not fail the refresh; it is reported and retried on the next run.
+func RefreshExpiredAds(ctx workflow.Context, input RefreshExpiredAdsInput) (RefreshExpiredAdsResult, error) {
+	logger := config.Logger
+	stores, err := RetrieveAds(ctx)
+	if err != nil {
+		return RefreshExpiredAdsResult{}, err
+	}
+	limit := input.MaxConcurrent
+	if limit <= 0 {
+		limit = DefaultMaxConcurrentAdProcesses
+	}
+	day := workflow.Now(ctx).UTC()
+
+	result := RefreshExpiredAdsResult{Stores: make([]StoreOutcome, len(stores))}
+	selector := workflow.NewSelector(ctx)
+	running := 0
+	for i, store := range stores {
+		if running == limit {
+			selector.Select(ctx)
+			running--
+		}
+		outcome := &result.Stores[i]
+		outcome.StoreID = store.StoreID
+		outcome.WorkflowID = AdProcessWorkflowID(store.StoreID, day)
+		childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+			WorkflowID: outcome.WorkflowID,
+			TaskQueue:  AdProcessingTaskQueueName,
+			// a store whose ad failed today is tried again, one that succeeded is not
+			WorkflowIDReusePolicy: enumspb.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE_FAILED_ONLY,
+		})
+		selector.AddFuture(workflow.ExecuteChildWorkflow(childCtx, AdProcess, store), func(f workflow.Future) {
+			outcome.record(f.Get(ctx, nil))
+		})
+		running++
+	}
+	for ; running > 0; running-- {
+		selector.Select(ctx)
+	}
+
+	for _, store := range result.Stores {
+		if store.Outcome == OutcomeFailed {
+			logger.Error("Failed to refresh store ad", zap.Int("store_id", store.StoreID), zap.String("error", store.Error))
+		}
+	}
+	logger.Info("Refreshed expired ads",
+		zap.Int("stores", len(result.Stores)),
+		zap.Int("succeeded", result.Count(OutcomeSucceeded)),
+		zap.Int("failed", result.Count(OutcomeFailed)),
+		zap.Int("skipped", result.Count(OutcomeSkipped)))
+	return result, nil
+}
+
+func (o *StoreOutcome) record(err error) {
+	switch {
+	case err == nil:
+		o.Outcome = OutcomeSucceeded
+	case temporal.IsWorkflowExecutionAlreadyStartedError(err):
+		o.Outcome = OutcomeSkipped
+	default:
+		o.Outcome = OutcomeFailed
+		o.Error = err.Error()
+	}
+}
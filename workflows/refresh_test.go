@@ -0,0 +1,176 @@
+package workflows
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"backend/main/models"
+	"backend/main/models/memory"
+
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+func expiredStores(ids ...int) []AdProcessInput {
+	var stores []AdProcessInput
+	for _, id := range ids {
+		stores = append(stores, AdProcessInput{StoreID: id, Source: AdSourceConfig{Type: FlippAdSource}})
+	}
+	return stores
+}
+
+func newRefreshEnvironment(stores []AdProcessInput, child func(ctx workflow.Context, input AdProcessInput) error) *testsuite.TestWorkflowEnvironment {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(RefreshExpiredAds)
+	env.RegisterWorkflow(AdProcess)
+	env.RegisterActivity(GetExpiredAdStores)
+	env.SetStartTime(time.Date(2025, time.June, 11, 3, 0, 0, 0, time.UTC))
+	env.OnActivity(GetExpiredAdStores, mock.Anything).Return(stores, nil)
+	env.OnWorkflow(AdProcess, mock.Anything, mock.Anything).Return(child)
+	return env
+}
+
+func TestRefreshExpiredAds(t *testing.T) {
+	childIDs := make(map[int]string)
+	env := newRefreshEnvironment(expiredStores(1, 2, 3), func(ctx workflow.Context, input AdProcessInput) error {
+		childIDs[input.StoreID] = workflow.GetInfo(ctx).WorkflowExecution.ID
+		if input.StoreID == 2 {
+			return temporal.NewNonRetryableApplicationError("flipp unavailable", "TestFailure", nil)
+		}
+		return nil
+	})
+
+	env.ExecuteWorkflow(RefreshExpiredAds, RefreshExpiredAdsInput{})
+
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatalf("workflow error = %v", err)
+	}
+	var result RefreshExpiredAdsResult
+	if err := env.GetWorkflowResult(&result); err != nil {
+		t.Fatal(err)
+	}
+	want := []StoreOutcome{
+		{StoreID: 1, WorkflowID: "ad-process-store-1-2025-06-11", Outcome: OutcomeSucceeded},
+		{StoreID: 2, WorkflowID: "ad-process-store-2-2025-06-11", Outcome: OutcomeFailed},
+		{StoreID: 3, WorkflowID: "ad-process-store-3-2025-06-11", Outcome: OutcomeSucceeded},
+	}
+	if len(result.Stores) != len(want) {
+		t.Fatalf("outcomes = %+v, want %+v", result.Stores, want)
+	}
+	for i, got := range result.Stores {
+		got.Error = "" // checked below
+		if got != want[i] {
+			t.Errorf("outcome %d = %+v, want %+v", i, result.Stores[i], want[i])
+		}
+		if childIDs[got.StoreID] != want[i].WorkflowID {
+			t.Errorf("store %d child ran as %q, want %q", got.StoreID, childIDs[got.StoreID], want[i].WorkflowID)
+		}
+	}
+	if result.Stores[1].Error == "" {
+		t.Error("failed outcome has no error")
+	}
+}
+
+func TestRefreshExpiredAdsConcurrency(t *testing.T) {
+	running, peak := 0, 0
+	env := newRefreshEnvironment(expiredStores(1, 2, 3, 4, 5), func(ctx workflow.Context, input AdProcessInput) error {
+		running++
+		peak = max(peak, running)
+		defer func() { running-- }()
+		return workflow.Sleep(ctx, time.Minute)
+	})
+
+	env.ExecuteWorkflow(RefreshExpiredAds, RefreshExpiredAdsInput{MaxConcurrent: 2})
+
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatalf("workflow error = %v", err)
+	}
+	var result RefreshExpiredAdsResult
+	if err := env.GetWorkflowResult(&result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Count(OutcomeSucceeded) != 5 {
+		t.Errorf("outcomes = %+v, want all 5 succeeded", result.Stores)
+	}
+	if peak != 2 {
+		t.Errorf("peak concurrent children = %d, want 2", peak)
+	}
+}
+
+func TestRefreshExpiredAdsStoreWithoutAds(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.New()
+	repo.Now = func() time.Time { return time.Date(2025, time.June, 11, 3, 0, 0, 0, time.UTC) }
+	for _, store := range []models.Store{
+		{FlippMerchantName: "Corner Market", Address: models.Address{PostalCode: "94105"}},
+		{FlippMerchantName: "Fresh Foods", Address: models.Address{PostalCode: "94107"}},
+	} {
+		if err := repo.CreateStore(ctx, store); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// store 1 has a current ad, store 2 has never had one
+	if err := repo.CreateAd(ctx, models.Ad{StoreID: 1, SaleStart: "2025-06-09", SaleEnd: "2025-06-15"}); err != nil {
+		t.Fatal(err)
+	}
+	stores, err := expiredAdStores(ctx, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var processed []AdProcessInput
+	env := newRefreshEnvironment(stores, func(ctx workflow.Context, input AdProcessInput) error {
+		processed = append(processed, input)
+		return nil
+	})
+
+	env.ExecuteWorkflow(RefreshExpiredAds, RefreshExpiredAdsInput{})
+
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatalf("workflow error = %v", err)
+	}
+	want := AdProcessInput{StoreID: 2, Source: AdSourceConfig{Type: FlippAdSource, MerchantName: "Fresh Foods", ZipCode: "94107"}}
+	if len(processed) != 1 || processed[0] != want {
+		t.Errorf("processed %+v, want only %+v", processed, want)
+	}
+}
+
+func TestRefreshExpiredAdsActivityFailure(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(RefreshExpiredAds)
+	env.RegisterActivity(GetExpiredAdStores)
+	env.OnActivity(GetExpiredAdStores, mock.Anything).Return(nil, temporal.NewNonRetryableApplicationError("database down", "TestFailure", nil))
+
+	env.ExecuteWorkflow(RefreshExpiredAds, RefreshExpiredAdsInput{})
+
+	var appErr *temporal.ApplicationError
+	if err := env.GetWorkflowError(); !errors.As(err, &appErr) || appErr.Message() != "database down" {
+		t.Errorf("workflow error = %v, want the activity failure", err)
+	}
+}
+
+func TestStoreOutcomeRecord(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"success", nil, OutcomeSucceeded},
+		{"already refreshed today", &temporal.ChildWorkflowExecutionAlreadyStartedError{}, OutcomeSkipped},
+		{"failure", errors.New("boom"), OutcomeFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var outcome StoreOutcome
+			outcome.record(tt.err)
+			if outcome.Outcome != tt.want {
+				t.Errorf("record(%v) = %q, want %q", tt.err, outcome.Outcome, tt.want)
+			}
+		})
+	}
+}
@@ -7,12 +7,15 @@ import (
 )
 
 const AdProcessingTaskQueueName = "AD_PROCESSING_TASK_QUEUE"
-const GetExpiredAdStoresTaskQueueName = "GET_EXPIRED_AD_STORES_TASK_QUEUE"
+const RefreshExpiredAdsTaskQueueName = "REFRESH_EXPIRED_ADS_TASK_QUEUE"
 
+// HTTPGetter sends the ad sources' GET requests. It takes a full *http.Request so the
+// caller can attach a context and headers; *http.Client satisfies it.
 type HTTPGetter interface {
-	Get(url string) (*http.Response, error)
+	Do(req *http.Request) (*http.Response, error)
 }
 
+// RequestData is the Flipp item search response
 type RequestData struct {
 	Items []ItemData `json:"items"`
 }
@@ -21,23 +24,19 @@ type ItemData struct {
 	L2            string   `json:"_L2"`
 	Name          string   `json:"name"`
 	CurrentPrice  *float32 `json:"current_price"`
+	OriginalPrice *float32 `json:"original_price"`
 	PostPriceText *string  `json:"post_price_text"`
 	ValidFrom	 string   `json:"valid_from"`
 	ValidTo		 string   `json:"valid_to"`
-}
-
-type RawGeneratedIngredientData struct {
-	Type string `json:"type"`
-	Season *[]int `json:"season"`
+	FlyerID       int64    `json:"flyer_id"`
 }
 
 type RetrieveTranslationsResult struct {
 	Ad models.Ad
-	UntranslatedIngredients []ItemData
+	UntranslatedIngredients []AdItem
 }
 
 type AdProcessInput struct {
 	StoreID int
-	ZipCode string
-	StoreFlippName string
+	Source  AdSourceConfig
 }
\ No newline at end of file
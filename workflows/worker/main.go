@@ -2,8 +2,11 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"backend/main/classifier"
 	"backend/main/workflows"
 	"backend/main/config"
+	"backend/main/models"
 	"context"
 
 	"go.temporal.io/sdk/client"
@@ -12,7 +15,6 @@ import (
 
 
 func main() {
-	config.ConnectPostgreSQL()
 	config.InitFirebase()
 	config.InitLogger()
 	config.InitGenAI()
@@ -22,6 +24,21 @@ func main() {
 		fmt.Print(err)
 		return
 	}
+	pool, err := models.NewPool(ctx, os.Getenv("DATABASE_URL"))
+	if err != nil {
+		fmt.Println("Failed to connect to PostgreSQL", err)
+		return
+	}
+	defer pool.Close()
+	workflows.DB = pool
+
+	// LLM_PROVIDER=openai points ingredient classification at a local OpenAI-compatible
+	// endpoint instead of Gemini
+	if os.Getenv("LLM_PROVIDER") == "openai" {
+		workflows.Classifier = classifier.NewOpenAI(os.Getenv("LLM_BASE_URL"), os.Getenv("LLM_MODEL"), os.Getenv("LLM_API_KEY"), nil)
+	} else {
+		workflows.Classifier = classifier.NewGemini(config.GeminiModel)
+	}
 
 	c, err := client.Dial(client.Options{})
 	if err != nil {
@@ -30,12 +47,12 @@ func main() {
 	defer c.Close()
 
 	w := worker.New(c, workflows.AdProcessingTaskQueueName, worker.Options{})
-	w0 := worker.New(c, workflows.GetExpiredAdStoresTaskQueueName, worker.Options{})
+	w0 := worker.New(c, workflows.RefreshExpiredAdsTaskQueueName, worker.Options{})
 
 	w.RegisterWorkflow(workflows.AdProcess)
-	w0.RegisterWorkflow(workflows.GetExpiredAdStores)
+	w0.RegisterWorkflow(workflows.RefreshExpiredAds)
 
-	w.RegisterActivity(workflows.GetFlippData)
+	w.RegisterActivity(workflows.FetchAdItems)
 	w.RegisterActivity(workflows.RetrieveTranslations)
 	w.RegisterActivity(workflows.GetIngredientNamesAndIds)
 	w.RegisterActivity(workflows.AddTranslations)
@@ -43,12 +60,15 @@ func main() {
 	w.RegisterActivity(workflows.CreateNewIngredientByName)
 	w0.RegisterActivity(workflows.GetExpiredAdStores)
 
-	err = w0.Run(worker.InterruptCh())
+	// Run blocks, so the refresh worker is started in the background first
+	err = w0.Start()
 	if err != nil {
 		fmt.Println("Failed to start worker", err)
+		return
 	}
+	defer w0.Stop()
 	err = w.Run(worker.InterruptCh())
 	if err != nil {
 		fmt.Println("Failed to start worker", err)
 	}
-} 
\ No newline at end of file
+}
@@ -10,43 +10,66 @@ import (
 	"go.uber.org/zap"
 )
 
-func RetrieveAds(ctx workflow.Context) (stores []AdProcessInput) {
+// adRetryPolicy specifies how to automatically handle retries if an Activity fails.
+var adRetryPolicy = &temporal.RetryPolicy{
+	InitialInterval:        time.Second,
+	BackoffCoefficient:     2.0,
+	MaximumInterval:        100 * time.Second,
+	MaximumAttempts:        10, // 0 is unlimited retries
+}
+
+// adActivityOptions are the options every ad activity runs with
+func adActivityOptions(ctx workflow.Context) workflow.Context {
+	return workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 5 * time.Minute,
+		RetryPolicy: adRetryPolicy,
+	})
+}
+
+func RetrieveAds(ctx workflow.Context) (stores []AdProcessInput, err error) {
 	logger := config.Logger
-	err := workflow.ExecuteActivity(ctx, GetExpiredAdStores).Get(ctx, &stores)
+	ctx = adActivityOptions(ctx)
+	err = workflow.ExecuteActivity(ctx, GetExpiredAdStores).Get(ctx, &stores)
 	if err != nil {
 		logger.Error("Failed to get ads", zap.Error(err))
+		return nil, err
 	}
-	return stores
+	return stores, nil
 }
 
-func AdProcess(ctx workflow.Context, store_id int, zip_code string, store_flipp_name string) (error) {
+func AdProcess(ctx workflow.Context, input AdProcessInput) (error) {
 	logger := config.Logger
 	
-	// RetryPolicy specifies how to automatically handle retries if an Activity fails.
-	retrypolicy := &temporal.RetryPolicy{
-		InitialInterval:        time.Second,
-		BackoffCoefficient:     2.0,
-		MaximumInterval:        100 * time.Second,
-		MaximumAttempts:        10, // 0 is unlimited retries
-	}
+	ctx = adActivityOptions(ctx)
 
-	options := workflow.ActivityOptions {
-		StartToCloseTimeout: 5 * time.Minute,
-		RetryPolicy: retrypolicy,
-	}
-
-	ctx = workflow.WithActivityOptions(ctx, options)
+	var items []AdItem
 
-	var rawData RequestData
-
-	err := workflow.ExecuteActivity(ctx, GetFlippData, zip_code, store_flipp_name).Get(ctx, &rawData)
+	err := workflow.ExecuteActivity(ctx, FetchAdItems, input.Source).Get(ctx, &items)
 	if err != nil {
-		logger.Error("Failed to get Flipp data", zap.Error(err))
+		logger.Error("Failed to fetch ad items", zap.Error(err), zap.String("source", input.Source.Type))
 		return err
 	}
-	
+	if len(items) == 0 {
+		logger.Info("No ad items to process", zap.Int("store_id", input.StoreID))
+		return nil
+	}
+
+	// each flyer is stored as its own ad
+	for _, flyer := range GroupFlyers(items) {
+		err = processFlyer(ctx, input.StoreID, flyer)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processFlyer translates the items of one flyer and stores them as an ad
+func processFlyer(ctx workflow.Context, storeID int, items []AdItem) error {
+	logger := config.Logger
+
 	var result RetrieveTranslationsResult
-	err = workflow.ExecuteActivity(ctx, RetrieveTranslations, store_id, rawData).Get(ctx, &result)
+	err := workflow.ExecuteActivity(ctx, RetrieveTranslations, storeID, items).Get(ctx, &result)
 	if err != nil {
 		logger.Error("Failed to retrieve translations", zap.Error(err))
 		return err
@@ -54,6 +77,7 @@ func AdProcess(ctx workflow.Context, store_id int, zip_code string, store_flipp_
 	ad := result.Ad
 	untranslatedIngredients := result.UntranslatedIngredients
 
+	// fetched per flyer so that ingredients added for an earlier flyer are reused
 	var ingredientMap map[string]uint
 	err = workflow.ExecuteActivity(ctx, GetIngredientNamesAndIds).Get(ctx, &ingredientMap)
 	if err != nil {
@@ -74,8 +98,8 @@ func AdProcess(ctx workflow.Context, store_id int, zip_code string, store_flipp_
 	
 	err = workflow.ExecuteActivity(ctx, CreateAd, ad).Get(ctx, nil)
 	if err != nil {
-		logger.Error("Failed to create ad", zap.Error(err))
+		logger.Error("Failed to create ad", zap.Error(err), zap.String("flyer_id", ad.SourceFlyerID))
+		return err
 	}
 	return nil
 }
-
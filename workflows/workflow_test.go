@@ -0,0 +1,268 @@
+package workflows
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"backend/main/config"
+	"backend/main/models"
+
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	config.Logger = zap.NewNop()
+	os.Exit(m.Run())
+}
+
+var (
+	testInput = AdProcessInput{StoreID: 7, Source: AdSourceConfig{Type: FileAdSource, Path: "ad.json"}}
+	testItems = []AdItem{
+		{Name: "Gala Apples", ValidFrom: "2025-06-04", ValidTo: "2025-06-10"},
+		{Name: "Sharp Cheddar Block", ValidFrom: "2025-06-04", ValidTo: "2025-06-10"},
+	}
+	testTranslated = RetrieveTranslationsResult{
+		Ad: models.Ad{StoreID: 7, SaleStart: "2025-06-04", SaleEnd: "2025-06-10",
+			Ingredient: []models.AdIngredient{{IngredientID: 1, Name: "Gala Apples"}}},
+		UntranslatedIngredients: testItems[1:],
+	}
+	testIngredientMap = map[string]uint{"apple": 1, "cheddar": 2}
+	testAdded         = []models.AdIngredient{{IngredientID: 2, Name: "Sharp Cheddar Block"}}
+)
+
+// newAdEnvironment registers AdProcess and its activities
+func newAdEnvironment() *testsuite.TestWorkflowEnvironment {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(AdProcess)
+	env.RegisterActivity(FetchAdItems)
+	env.RegisterActivity(RetrieveTranslations)
+	env.RegisterActivity(GetIngredientNamesAndIds)
+	env.RegisterActivity(AddTranslations)
+	env.RegisterActivity(CreateAd)
+	return env
+}
+
+// mockAdActivities mocks each activity to succeed except the one named by fail, which
+// returns a non-retryable error. Mocks set up before it take precedence.
+func mockAdActivities(env *testsuite.TestWorkflowEnvironment, fail string) {
+	failure := func(name string) error {
+		if name == fail {
+			return temporal.NewNonRetryableApplicationError(name+" failed", "TestFailure", nil)
+		}
+		return nil
+	}
+	env.OnActivity(FetchAdItems, mock.Anything, mock.Anything).Return(testItems, failure("FetchAdItems"))
+	env.OnActivity(RetrieveTranslations, mock.Anything, mock.Anything, mock.Anything).Return(testTranslated, failure("RetrieveTranslations"))
+	env.OnActivity(GetIngredientNamesAndIds, mock.Anything).Return(testIngredientMap, failure("GetIngredientNamesAndIds"))
+	env.OnActivity(AddTranslations, mock.Anything, mock.Anything, mock.Anything).Return(testAdded, failure("AddTranslations"))
+	env.OnActivity(CreateAd, mock.Anything, mock.Anything).Return(failure("CreateAd"))
+}
+
+func TestAdProcess(t *testing.T) {
+	env := newAdEnvironment()
+	var created models.Ad
+	env.OnActivity(CreateAd, mock.Anything, mock.Anything).Return(func(ctx context.Context, ad models.Ad) error {
+		created = ad
+		return nil
+	})
+	mockAdActivities(env, "")
+
+	env.ExecuteWorkflow(AdProcess, testInput)
+
+	if !env.IsWorkflowCompleted() {
+		t.Fatal("workflow did not complete")
+	}
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatalf("workflow error = %v", err)
+	}
+	if created.StoreID != 7 || created.SaleStart != "2025-06-04" || len(created.Ingredient) != 2 {
+		t.Fatalf("created ad = %+v, want store 7 with the translated and added items", created)
+	}
+	if created.Ingredient[0].IngredientID != 1 || created.Ingredient[1].IngredientID != 2 {
+		t.Errorf("created ad items = %+v", created.Ingredient)
+	}
+}
+
+func TestAdProcessFlyers(t *testing.T) {
+	env := newAdEnvironment()
+	weekly := AdItem{Name: "Gala Apples", FlyerID: "1", ValidFrom: "2025-06-04", ValidTo: "2025-06-10"}
+	monthly := AdItem{Name: "Whole Milk", FlyerID: "2", ValidFrom: "2025-06-01", ValidTo: "2025-06-30"}
+	env.OnActivity(FetchAdItems, mock.Anything, mock.Anything).Return([]AdItem{weekly, monthly}, nil)
+	env.OnActivity(RetrieveTranslations, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, storeID uint, items []AdItem) (RetrieveTranslationsResult, error) {
+			ad := models.Ad{StoreID: storeID, SaleStart: items[0].ValidFrom, SaleEnd: items[0].ValidTo, SourceFlyerID: items[0].FlyerID}
+			return RetrieveTranslationsResult{Ad: ad, UntranslatedIngredients: items}, nil
+		})
+	var created []models.Ad
+	env.OnActivity(CreateAd, mock.Anything, mock.Anything).Return(func(ctx context.Context, ad models.Ad) error {
+		created = append(created, ad)
+		return nil
+	})
+	mockAdActivities(env, "")
+
+	env.ExecuteWorkflow(AdProcess, testInput)
+
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatalf("workflow error = %v", err)
+	}
+	if len(created) != 2 {
+		t.Fatalf("created %d ads, want one per flyer", len(created))
+	}
+	if created[0].SourceFlyerID != "1" || created[0].SaleEnd != "2025-06-10" ||
+		created[1].SourceFlyerID != "2" || created[1].SaleEnd != "2025-06-30" {
+		t.Errorf("created ads = %+v", created)
+	}
+}
+
+func TestAdProcessActivityFailure(t *testing.T) {
+	activities := []string{"FetchAdItems", "RetrieveTranslations", "GetIngredientNamesAndIds", "AddTranslations", "CreateAd"}
+	for i, failing := range activities {
+		t.Run(failing, func(t *testing.T) {
+			env := newAdEnvironment()
+			mockAdActivities(env, failing)
+			var started []string
+			env.SetOnActivityStartedListener(func(info *activity.Info, ctx context.Context, args converter.EncodedValues) {
+				started = append(started, info.ActivityType.Name)
+			})
+
+			env.ExecuteWorkflow(AdProcess, testInput)
+
+			if !env.IsWorkflowCompleted() {
+				t.Fatal("workflow did not complete")
+			}
+			var appErr *temporal.ApplicationError
+			if err := env.GetWorkflowError(); !errors.As(err, &appErr) || appErr.Message() != failing+" failed" {
+				t.Fatalf("workflow error = %v, want the %s failure", err, failing)
+			}
+			// the failing activity is the last one to run
+			if want := activities[:i+1]; len(started) != len(want) || started[len(started)-1] != failing {
+				t.Errorf("started activities = %v, want %v", started, want)
+			}
+		})
+	}
+}
+
+func TestAdProcessNoItems(t *testing.T) {
+	env := newAdEnvironment()
+	env.OnActivity(FetchAdItems, mock.Anything, mock.Anything).Return([]AdItem{}, nil)
+	mockAdActivities(env, "")
+	var started []string
+	env.SetOnActivityStartedListener(func(info *activity.Info, ctx context.Context, args converter.EncodedValues) {
+		started = append(started, info.ActivityType.Name)
+	})
+
+	env.ExecuteWorkflow(AdProcess, testInput)
+
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatalf("workflow error = %v", err)
+	}
+	if len(started) != 1 {
+		t.Errorf("started activities = %v, want only FetchAdItems", started)
+	}
+}
+
+func TestRetrieveTranslationsNoItems(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestActivityEnvironment()
+	env.RegisterActivity(RetrieveTranslations)
+
+	_, err := env.ExecuteActivity(RetrieveTranslations, uint(7), []AdItem{})
+
+	var appErr *temporal.ApplicationError
+	if !errors.As(err, &appErr) || appErr.Type() != ErrNoAdItems || !appErr.NonRetryable() {
+		t.Errorf("RetrieveTranslations() error = %v, want a non-retryable %s error", err, ErrNoAdItems)
+	}
+}
+
+func TestAdProcessRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int32
+		wantAttempts int32
+		wantErr      bool
+	}{
+		{"recovers after retries", 2, 3, false},
+		{"gives up after maximum attempts", 100, adRetryPolicy.MaximumAttempts, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newAdEnvironment()
+			var attempts int32
+			env.OnActivity(FetchAdItems, mock.Anything, mock.Anything).Return(func(ctx context.Context, config AdSourceConfig) ([]AdItem, error) {
+				attempts = activity.GetInfo(ctx).Attempt
+				if attempts <= tt.failures {
+					return nil, errors.New("flipp unavailable")
+				}
+				return testItems, nil
+			})
+			mockAdActivities(env, "")
+			start := env.Now()
+
+			env.ExecuteWorkflow(AdProcess, testInput)
+
+			if err := env.GetWorkflowError(); (err != nil) != tt.wantErr {
+				t.Fatalf("workflow error = %v, want error %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+			// retries back off exponentially from InitialInterval up to MaximumInterval
+			var wantBackoff time.Duration
+			interval := adRetryPolicy.InitialInterval
+			for range tt.wantAttempts - 1 {
+				wantBackoff += interval
+				interval = min(time.Duration(float64(interval)*adRetryPolicy.BackoffCoefficient), adRetryPolicy.MaximumInterval)
+			}
+			if elapsed := env.Now().Sub(start); elapsed < wantBackoff {
+				t.Errorf("retries took %v, want at least %v of backoff", elapsed, wantBackoff)
+			}
+		})
+	}
+}
+
+func TestRetrieveAds(t *testing.T) {
+	stores := []AdProcessInput{testInput}
+	tests := []struct {
+		name    string
+		err     error
+		want    int
+		wantErr bool
+	}{
+		{"returns expired stores", nil, 1, false},
+		{"activity fails", temporal.NewNonRetryableApplicationError("database down", "TestFailure", nil), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var suite testsuite.WorkflowTestSuite
+			env := suite.NewTestWorkflowEnvironment()
+			env.RegisterWorkflow(RetrieveAds)
+			env.RegisterActivity(GetExpiredAdStores)
+			env.OnActivity(GetExpiredAdStores, mock.Anything).Return(stores, tt.err)
+
+			env.ExecuteWorkflow(RetrieveAds)
+
+			if err := env.GetWorkflowError(); (err != nil) != tt.wantErr {
+				t.Fatalf("workflow error = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			var got []AdProcessInput
+			if err := env.GetWorkflowResult(&got); err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != tt.want || got[0] != testInput {
+				t.Errorf("RetrieveAds() = %+v, want %+v", got, stores)
+			}
+		})
+	}
+}